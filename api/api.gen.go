@@ -0,0 +1,3907 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.3.0 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	ApiKeyHeaderScopes = "apiKeyHeader.Scopes"
+	BearerAuthScopes   = "bearerAuth.Scopes"
+)
+
+// Defines values for ExportLinksParamsFormat.
+const (
+	ExportLinksParamsFormatCsv    ExportLinksParamsFormat = "csv"
+	ExportLinksParamsFormatNdjson ExportLinksParamsFormat = "ndjson"
+)
+
+// Defines values for ImportLinksParamsFormat.
+const (
+	ImportLinksParamsFormatCsv    ImportLinksParamsFormat = "csv"
+	ImportLinksParamsFormatNdjson ImportLinksParamsFormat = "ndjson"
+)
+
+// CacheStoreRequestModel defines model for CacheStoreRequestModel.
+type CacheStoreRequestModel struct {
+	ShortUrlPath string `json:"shorturlpath"`
+
+	// Url Original long URL.
+	Url string `json:"url"`
+
+	// ExpiresAt Expiry of the short URL. The link is not cached past it.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// CacheWarmRequestModel defines model for CacheWarmRequestModel.
+type CacheWarmRequestModel struct {
+	// Source top for the most clicked links or recent for the most recently created ones.
+	Source string `json:"source,omitempty"`
+
+	// Limit Number of links to load.
+	Limit int `json:"limit,omitempty"`
+
+	// Days Number of days of clicks the top links are counted over.
+	Days int `json:"days,omitempty"`
+}
+
+// DailyClicksModel defines model for DailyClicksModel.
+type DailyClicksModel struct {
+	Day   time.Time `json:"day"`
+	Count int64     `json:"count"`
+}
+
+// ExportRequestModel defines model for ExportRequestModel.
+type ExportRequestModel struct {
+	Tag   string `json:"tag,omitempty"`
+	Owner string `json:"owner,omitempty"`
+}
+
+// HealthResponseModel defines model for HealthResponseModel.
+type HealthResponseModel struct {
+	// Status ok, degraded when only an optional check failed, or unavailable when a readiness check failed.
+	Status string `json:"status"`
+
+	// Checks Outcome of every readiness check, ok or the error it failed with.
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// ImportRequestModel defines model for ImportRequestModel.
+type ImportRequestModel struct {
+	Links []LinkModel `json:"links"`
+}
+
+// ImportResponseModel defines model for ImportResponseModel.
+type ImportResponseModel struct {
+	Results []ImportResultModel `json:"results"`
+}
+
+// ImportResultModel defines model for ImportResultModel.
+type ImportResultModel struct {
+	// Index Position of the link in the request.
+	Index        int    `json:"index"`
+	ShortUrlPath string `json:"shorturlpath"`
+
+	// Status created, conflict, invalid or failed.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportRowModel defines model for ImportRowModel.
+type ImportRowModel struct {
+	// Row Row number in the uploaded file.
+	Row          int    `json:"row"`
+	ShortUrlPath string `json:"shorturlpath,omitempty"`
+
+	// Status created, conflict, invalid or failed.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportSummaryModel defines model for ImportSummaryModel.
+type ImportSummaryModel struct {
+	Created   int `json:"created"`
+	Conflicts int `json:"conflicts"`
+	Invalid   int `json:"invalid"`
+	Failed    int `json:"failed"`
+
+	// Rows Rows that were not created.
+	Rows []ImportRowModel `json:"rows"`
+}
+
+// LinkModel defines model for LinkModel.
+type LinkModel struct {
+	ShortUrlPath string `json:"shorturlpath"`
+
+	// ShortUrl Full short URL. Only returned by the main service.
+	ShortUrl  string    `json:"short_url,omitempty"`
+	Url       string    `json:"url"`
+	Title     string    `json:"title"`
+	Note      string    `json:"note"`
+	Tags      []string  `json:"tags"`
+	Owner     string    `json:"owner"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ListRequestModel defines model for ListRequestModel.
+type ListRequestModel struct {
+	Tag string `json:"tag,omitempty"`
+
+	// Query Full text search on title, note and url.
+	Query        string    `json:"q,omitempty"`
+	CreatedAfter time.Time `json:"created_after,omitempty"`
+
+	// Sort created_at or expires_at, prefixed with - for descending order.
+	Sort   string `json:"sort,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+
+	// Limit Page size, at most 100.
+	Limit int `json:"limit,omitempty"`
+}
+
+// ListResponseModel defines model for ListResponseModel.
+type ListResponseModel struct {
+	Links []LinkModel `json:"links"`
+
+	// NextCursor Cursor of the next page, empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// RecordClicksRequestModel defines model for RecordClicksRequestModel.
+type RecordClicksRequestModel struct {
+	// Clicks Number of redirects per short url path.
+	Clicks map[string]int64 `json:"clicks"`
+}
+
+// RedirectRequestModel defines model for RedirectRequestModel.
+type RedirectRequestModel struct {
+	ShortUrlPath string `json:"shorturlpath"`
+}
+
+// RedirectResponseModel defines model for RedirectResponseModel.
+type RedirectResponseModel struct {
+	// Url Original long URL.
+	Url string `json:"redirecturl"`
+}
+
+// ShortenRequestModel defines model for ShortenRequestModel.
+type ShortenRequestModel struct {
+	// Url Long URL to shorten.
+	Url string `json:"url"`
+
+	// ExpiresAt Expiry of the short URL. Defaults to 10 days when empty.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Note      string    `json:"note,omitempty"`
+
+	// Tags Tags are lowercased and deduplicated.
+	Tags  []string `json:"tags,omitempty"`
+	Owner string   `json:"owner,omitempty"`
+}
+
+// ShortenResponseModel defines model for ShortenResponseModel.
+type ShortenResponseModel struct {
+	// ShortUrlPath Generated short code.
+	ShortUrlPath string `json:"shorturlpath"`
+
+	// Url Full short URL. Only returned by the main service.
+	Url string `json:"url,omitempty"`
+}
+
+// StatsResponseModel defines model for StatsResponseModel.
+type StatsResponseModel struct {
+	ShortUrlPath string             `json:"shorturlpath"`
+	TotalClicks  int64              `json:"total_clicks"`
+	Daily        []DailyClicksModel `json:"daily"`
+}
+
+// TopLinksRequestModel defines model for TopLinksRequestModel.
+type TopLinksRequestModel struct {
+	// Limit Number of links, at most 1000.
+	Limit int `json:"limit,omitempty"`
+
+	// Days Number of days of clicks to count, 7 by default.
+	Days int `json:"days,omitempty"`
+}
+
+// UpdateRequestModel Only the given fields are changed.
+type UpdateRequestModel struct {
+	Url       *string    `json:"url,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Title     *string    `json:"title,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+	Tags      *[]string  `json:"tags,omitempty"`
+}
+
+// ListLinksParams defines parameters for ListLinks.
+type ListLinksParams struct {
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Q Full text search on title, note and url.
+	Q            *string    `form:"q,omitempty" json:"q,omitempty"`
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// Sort created_at or expires_at, prefixed with - for descending order. Defaults to -created_at.
+	Sort   *string `form:"sort,omitempty" json:"sort,omitempty"`
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ExportLinksParams defines parameters for ExportLinks.
+type ExportLinksParams struct {
+	Format *ExportLinksParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+	Tag    *string                  `form:"tag,omitempty" json:"tag,omitempty"`
+	Owner  *string                  `form:"owner,omitempty" json:"owner,omitempty"`
+}
+
+// ExportLinksParamsFormat defines parameters for ExportLinks.
+type ExportLinksParamsFormat string
+
+// ImportLinksParams defines parameters for ImportLinks.
+type ImportLinksParams struct {
+	Format *ImportLinksParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ImportLinksParamsFormat defines parameters for ImportLinks.
+type ImportLinksParamsFormat string
+
+// GetLinkStatsParams defines parameters for GetLinkStats.
+type GetLinkStatsParams struct {
+	// Days Number of days of daily clicks, defaults to 30.
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// FindLinkStatsParams defines parameters for FindLinkStats.
+type FindLinkStatsParams struct {
+	// Days Number of days of daily clicks, defaults to 30.
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// UpdateLinkJSONRequestBody defines body for UpdateLink for application/json ContentType.
+type UpdateLinkJSONRequestBody = UpdateRequestModel
+
+// StoreCacheJSONRequestBody defines body for StoreCache for application/json ContentType.
+type StoreCacheJSONRequestBody = CacheStoreRequestModel
+
+// WarmCacheJSONRequestBody defines body for WarmCache for application/json ContentType.
+type WarmCacheJSONRequestBody = CacheWarmRequestModel
+
+// RecordClicksJSONRequestBody defines body for RecordClicks for application/json ContentType.
+type RecordClicksJSONRequestBody = RecordClicksRequestModel
+
+// QueryLinksJSONRequestBody defines body for QueryLinks for application/json ContentType.
+type QueryLinksJSONRequestBody = ListRequestModel
+
+// StreamLinksJSONRequestBody defines body for StreamLinks for application/json ContentType.
+type StreamLinksJSONRequestBody = ExportRequestModel
+
+// InsertLinksJSONRequestBody defines body for InsertLinks for application/json ContentType.
+type InsertLinksJSONRequestBody = ImportRequestModel
+
+// QueryTopLinksJSONRequestBody defines body for QueryTopLinks for application/json ContentType.
+type QueryTopLinksJSONRequestBody = TopLinksRequestModel
+
+// PatchLinkJSONRequestBody defines body for PatchLink for application/json ContentType.
+type PatchLinkJSONRequestBody = UpdateRequestModel
+
+// LookupRedirectJSONRequestBody defines body for LookupRedirect for application/json ContentType.
+type LookupRedirectJSONRequestBody = RedirectRequestModel
+
+// ShortenJSONRequestBody defines body for Shorten for application/json ContentType.
+type ShortenJSONRequestBody = ShortenRequestModel
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ListLinks request
+	ListLinks(ctx context.Context, params *ListLinksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportLinks request
+	ExportLinks(ctx context.Context, params *ExportLinksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportLinksWithBody request with any body
+	ImportLinksWithBody(ctx context.Context, params *ImportLinksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteLink request
+	DeleteLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLink request
+	GetLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateLinkWithBody request with any body
+	UpdateLinkWithBody(ctx context.Context, shorturlpath string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateLink(ctx context.Context, shorturlpath string, body UpdateLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RestoreLink request
+	RestoreLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLinkStats request
+	GetLinkStats(ctx context.Context, shorturlpath string, params *GetLinkStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StoreCacheWithBody request with any body
+	StoreCacheWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StoreCache(ctx context.Context, body StoreCacheJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FlushCache request
+	FlushCache(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// WarmCacheWithBody request with any body
+	WarmCacheWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	WarmCache(ctx context.Context, body WarmCacheJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EvictCache request
+	EvictCache(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RecordClicksWithBody request with any body
+	RecordClicksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RecordClicks(ctx context.Context, body RecordClicksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CheckLiveness request
+	CheckLiveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QueryLinksWithBody request with any body
+	QueryLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	QueryLinks(ctx context.Context, body QueryLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamLinksWithBody request with any body
+	StreamLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StreamLinks(ctx context.Context, body StreamLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// InsertLinksWithBody request with any body
+	InsertLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	InsertLinks(ctx context.Context, body InsertLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QueryTopLinksWithBody request with any body
+	QueryTopLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	QueryTopLinks(ctx context.Context, body QueryTopLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveLink request
+	RemoveLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FindLink request
+	FindLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchLinkWithBody request with any body
+	PatchLinkWithBody(ctx context.Context, shorturlpath string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchLink(ctx context.Context, shorturlpath string, body PatchLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReviveLink request
+	ReviveLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FindLinkStats request
+	FindLinkStats(ctx context.Context, shorturlpath string, params *FindLinkStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPISpec request
+	GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CheckReadiness request
+	CheckReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LookupRedirectWithBody request with any body
+	LookupRedirectWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	LookupRedirect(ctx context.Context, body LookupRedirectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ShortenWithBody request with any body
+	ShortenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Shorten(ctx context.Context, body ShortenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FollowShortUrl request
+	FollowShortUrl(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ListLinks(ctx context.Context, params *ListLinksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListLinksRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportLinks(ctx context.Context, params *ExportLinksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportLinksRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportLinksWithBody(ctx context.Context, params *ImportLinksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportLinksRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteLinkRequest(c.Server, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLinkRequest(c.Server, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateLinkWithBody(ctx context.Context, shorturlpath string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateLinkRequestWithBody(c.Server, shorturlpath, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateLink(ctx context.Context, shorturlpath string, body UpdateLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateLinkRequest(c.Server, shorturlpath, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RestoreLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestoreLinkRequest(c.Server, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLinkStats(ctx context.Context, shorturlpath string, params *GetLinkStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLinkStatsRequest(c.Server, shorturlpath, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StoreCacheWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStoreCacheRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StoreCache(ctx context.Context, body StoreCacheJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStoreCacheRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FlushCache(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFlushCacheRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) WarmCacheWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWarmCacheRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) WarmCache(ctx context.Context, body WarmCacheJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWarmCacheRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EvictCache(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEvictCacheRequest(c.Server, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RecordClicksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRecordClicksRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RecordClicks(ctx context.Context, body RecordClicksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRecordClicksRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CheckLiveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckLivenessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryLinksRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryLinks(ctx context.Context, body QueryLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryLinksRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamLinksRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StreamLinks(ctx context.Context, body StreamLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamLinksRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) InsertLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInsertLinksRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) InsertLinks(ctx context.Context, body InsertLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInsertLinksRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryTopLinksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryTopLinksRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryTopLinks(ctx context.Context, body QueryTopLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryTopLinksRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveLinkRequest(c.Server, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FindLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFindLinkRequest(c.Server, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchLinkWithBody(ctx context.Context, shorturlpath string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchLinkRequestWithBody(c.Server, shorturlpath, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchLink(ctx context.Context, shorturlpath string, body PatchLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchLinkRequest(c.Server, shorturlpath, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReviveLink(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReviveLinkRequest(c.Server, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FindLinkStats(ctx context.Context, shorturlpath string, params *FindLinkStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFindLinkStatsRequest(c.Server, shorturlpath, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPISpecRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CheckReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckReadinessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupRedirectWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupRedirectRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LookupRedirect(ctx context.Context, body LookupRedirectJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLookupRedirectRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ShortenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewShortenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Shorten(ctx context.Context, body ShortenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewShortenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FollowShortUrl(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFollowShortUrlRequest(c.Server, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListLinksRequest generates requests for ListLinks
+func NewListLinksRequest(server string, params *ListLinksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/links")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_after", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportLinksRequest generates requests for ExportLinks
+func NewExportLinksRequest(server string, params *ExportLinksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/links/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Owner != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "owner", runtime.ParamLocationQuery, *params.Owner); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportLinksRequestWithBody generates requests for ImportLinks with any type of body
+func NewImportLinksRequestWithBody(server string, params *ImportLinksParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/links/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteLinkRequest generates requests for DeleteLink
+func NewDeleteLinkRequest(server string, shorturlpath string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/links/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLinkRequest generates requests for GetLink
+func NewGetLinkRequest(server string, shorturlpath string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/links/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateLinkRequest calls the generic UpdateLink builder with application/json body
+func NewUpdateLinkRequest(server string, shorturlpath string, body UpdateLinkJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateLinkRequestWithBody(server, shorturlpath, "application/json", bodyReader)
+}
+
+// NewUpdateLinkRequestWithBody generates requests for UpdateLink with any type of body
+func NewUpdateLinkRequestWithBody(server string, shorturlpath string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/links/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRestoreLinkRequest generates requests for RestoreLink
+func NewRestoreLinkRequest(server string, shorturlpath string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/links/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLinkStatsRequest generates requests for GetLinkStats
+func NewGetLinkStatsRequest(server string, shorturlpath string, params *GetLinkStatsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/links/%s/stats", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Days != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "days", runtime.ParamLocationQuery, *params.Days); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStoreCacheRequest calls the generic StoreCache builder with application/json body
+func NewStoreCacheRequest(server string, body StoreCacheJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStoreCacheRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewStoreCacheRequestWithBody generates requests for StoreCache with any type of body
+func NewStoreCacheRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cache")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewFlushCacheRequest generates requests for FlushCache
+func NewFlushCacheRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cache/flush")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewWarmCacheRequest calls the generic WarmCache builder with application/json body
+func NewWarmCacheRequest(server string, body WarmCacheJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewWarmCacheRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewWarmCacheRequestWithBody generates requests for WarmCache with any type of body
+func NewWarmCacheRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cache/warm")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewEvictCacheRequest generates requests for EvictCache
+func NewEvictCacheRequest(server string, shorturlpath string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cache/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRecordClicksRequest calls the generic RecordClicks builder with application/json body
+func NewRecordClicksRequest(server string, body RecordClicksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRecordClicksRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRecordClicksRequestWithBody generates requests for RecordClicks with any type of body
+func NewRecordClicksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/clicks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCheckLivenessRequest generates requests for CheckLiveness
+func NewCheckLivenessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewQueryLinksRequest calls the generic QueryLinks builder with application/json body
+func NewQueryLinksRequest(server string, body QueryLinksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewQueryLinksRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewQueryLinksRequestWithBody generates requests for QueryLinks with any type of body
+func NewQueryLinksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewStreamLinksRequest calls the generic StreamLinks builder with application/json body
+func NewStreamLinksRequest(server string, body StreamLinksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStreamLinksRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewStreamLinksRequestWithBody generates requests for StreamLinks with any type of body
+func NewStreamLinksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewInsertLinksRequest calls the generic InsertLinks builder with application/json body
+func NewInsertLinksRequest(server string, body InsertLinksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewInsertLinksRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewInsertLinksRequestWithBody generates requests for InsertLinks with any type of body
+func NewInsertLinksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewQueryTopLinksRequest calls the generic QueryTopLinks builder with application/json body
+func NewQueryTopLinksRequest(server string, body QueryTopLinksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewQueryTopLinksRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewQueryTopLinksRequestWithBody generates requests for QueryTopLinks with any type of body
+func NewQueryTopLinksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/top")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemoveLinkRequest generates requests for RemoveLink
+func NewRemoveLinkRequest(server string, shorturlpath string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewFindLinkRequest generates requests for FindLink
+func NewFindLinkRequest(server string, shorturlpath string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchLinkRequest calls the generic PatchLink builder with application/json body
+func NewPatchLinkRequest(server string, shorturlpath string, body PatchLinkJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchLinkRequestWithBody(server, shorturlpath, "application/json", bodyReader)
+}
+
+// NewPatchLinkRequestWithBody generates requests for PatchLink with any type of body
+func NewPatchLinkRequestWithBody(server string, shorturlpath string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReviveLinkRequest generates requests for ReviveLink
+func NewReviveLinkRequest(server string, shorturlpath string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewFindLinkStatsRequest generates requests for FindLinkStats
+func NewFindLinkStatsRequest(server string, shorturlpath string, params *FindLinkStatsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/links/%s/stats", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Days != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "days", runtime.ParamLocationQuery, *params.Days); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOpenAPISpecRequest generates requests for GetOpenAPISpec
+func NewGetOpenAPISpecRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCheckReadinessRequest generates requests for CheckReadiness
+func NewCheckReadinessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLookupRedirectRequest calls the generic LookupRedirect builder with application/json body
+func NewLookupRedirectRequest(server string, body LookupRedirectJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLookupRedirectRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewLookupRedirectRequestWithBody generates requests for LookupRedirect with any type of body
+func NewLookupRedirectRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/redirect")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewShortenRequest calls the generic Shorten builder with application/json body
+func NewShortenRequest(server string, body ShortenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewShortenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewShortenRequestWithBody generates requests for Shorten with any type of body
+func NewShortenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/shorten")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewFollowShortUrlRequest generates requests for FollowShortUrl
+func NewFollowShortUrlRequest(server string, shorturlpath string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "shorturlpath", runtime.ParamLocationPath, shorturlpath)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListLinksWithResponse request
+	ListLinksWithResponse(ctx context.Context, params *ListLinksParams, reqEditors ...RequestEditorFn) (*ListLinksResponse, error)
+
+	// ExportLinksWithResponse request
+	ExportLinksWithResponse(ctx context.Context, params *ExportLinksParams, reqEditors ...RequestEditorFn) (*ExportLinksResponse, error)
+
+	// ImportLinksWithBodyWithResponse request with any body
+	ImportLinksWithBodyWithResponse(ctx context.Context, params *ImportLinksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportLinksResponse, error)
+
+	// DeleteLinkWithResponse request
+	DeleteLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*DeleteLinkResponse, error)
+
+	// GetLinkWithResponse request
+	GetLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*GetLinkResponse, error)
+
+	// UpdateLinkWithBodyWithResponse request with any body
+	UpdateLinkWithBodyWithResponse(ctx context.Context, shorturlpath string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateLinkResponse, error)
+
+	UpdateLinkWithResponse(ctx context.Context, shorturlpath string, body UpdateLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateLinkResponse, error)
+
+	// RestoreLinkWithResponse request
+	RestoreLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*RestoreLinkResponse, error)
+
+	// GetLinkStatsWithResponse request
+	GetLinkStatsWithResponse(ctx context.Context, shorturlpath string, params *GetLinkStatsParams, reqEditors ...RequestEditorFn) (*GetLinkStatsResponse, error)
+
+	// StoreCacheWithBodyWithResponse request with any body
+	StoreCacheWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StoreCacheResponse, error)
+
+	StoreCacheWithResponse(ctx context.Context, body StoreCacheJSONRequestBody, reqEditors ...RequestEditorFn) (*StoreCacheResponse, error)
+
+	// FlushCacheWithResponse request
+	FlushCacheWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*FlushCacheResponse, error)
+
+	// WarmCacheWithBodyWithResponse request with any body
+	WarmCacheWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*WarmCacheResponse, error)
+
+	WarmCacheWithResponse(ctx context.Context, body WarmCacheJSONRequestBody, reqEditors ...RequestEditorFn) (*WarmCacheResponse, error)
+
+	// EvictCacheWithResponse request
+	EvictCacheWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*EvictCacheResponse, error)
+
+	// RecordClicksWithBodyWithResponse request with any body
+	RecordClicksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RecordClicksResponse, error)
+
+	RecordClicksWithResponse(ctx context.Context, body RecordClicksJSONRequestBody, reqEditors ...RequestEditorFn) (*RecordClicksResponse, error)
+
+	// CheckLivenessWithResponse request
+	CheckLivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*CheckLivenessResponse, error)
+
+	// QueryLinksWithBodyWithResponse request with any body
+	QueryLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*QueryLinksResponse, error)
+
+	QueryLinksWithResponse(ctx context.Context, body QueryLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*QueryLinksResponse, error)
+
+	// StreamLinksWithBodyWithResponse request with any body
+	StreamLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StreamLinksResponse, error)
+
+	StreamLinksWithResponse(ctx context.Context, body StreamLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*StreamLinksResponse, error)
+
+	// InsertLinksWithBodyWithResponse request with any body
+	InsertLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*InsertLinksResponse, error)
+
+	InsertLinksWithResponse(ctx context.Context, body InsertLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*InsertLinksResponse, error)
+
+	// QueryTopLinksWithBodyWithResponse request with any body
+	QueryTopLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*QueryTopLinksResponse, error)
+
+	QueryTopLinksWithResponse(ctx context.Context, body QueryTopLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*QueryTopLinksResponse, error)
+
+	// RemoveLinkWithResponse request
+	RemoveLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*RemoveLinkResponse, error)
+
+	// FindLinkWithResponse request
+	FindLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*FindLinkResponse, error)
+
+	// PatchLinkWithBodyWithResponse request with any body
+	PatchLinkWithBodyWithResponse(ctx context.Context, shorturlpath string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchLinkResponse, error)
+
+	PatchLinkWithResponse(ctx context.Context, shorturlpath string, body PatchLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchLinkResponse, error)
+
+	// ReviveLinkWithResponse request
+	ReviveLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*ReviveLinkResponse, error)
+
+	// FindLinkStatsWithResponse request
+	FindLinkStatsWithResponse(ctx context.Context, shorturlpath string, params *FindLinkStatsParams, reqEditors ...RequestEditorFn) (*FindLinkStatsResponse, error)
+
+	// GetOpenAPISpecWithResponse request
+	GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error)
+
+	// CheckReadinessWithResponse request
+	CheckReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*CheckReadinessResponse, error)
+
+	// LookupRedirectWithBodyWithResponse request with any body
+	LookupRedirectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LookupRedirectResponse, error)
+
+	LookupRedirectWithResponse(ctx context.Context, body LookupRedirectJSONRequestBody, reqEditors ...RequestEditorFn) (*LookupRedirectResponse, error)
+
+	// ShortenWithBodyWithResponse request with any body
+	ShortenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ShortenResponse, error)
+
+	ShortenWithResponse(ctx context.Context, body ShortenJSONRequestBody, reqEditors ...RequestEditorFn) (*ShortenResponse, error)
+
+	// FollowShortUrlWithResponse request
+	FollowShortUrlWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*FollowShortUrlResponse, error)
+}
+
+type ListLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ListResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r ListLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportSummaryModel
+	JSON400      *ImportSummaryModel
+	JSON500      *ImportSummaryModel
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LinkModel
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LinkModel
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RestoreLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LinkModel
+}
+
+// Status returns HTTPResponse.Status
+func (r RestoreLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RestoreLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLinkStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StatsResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLinkStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLinkStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StoreCacheResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r StoreCacheResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StoreCacheResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FlushCacheResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r FlushCacheResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FlushCacheResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type WarmCacheResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r WarmCacheResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r WarmCacheResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EvictCacheResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r EvictCacheResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EvictCacheResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RecordClicksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r RecordClicksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RecordClicksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckLivenessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckLivenessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckLivenessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QueryLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ListResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r QueryLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QueryLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type InsertLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r InsertLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r InsertLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QueryTopLinksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ListResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r QueryTopLinksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QueryTopLinksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FindLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LinkModel
+}
+
+// Status returns HTTPResponse.Status
+func (r FindLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FindLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LinkModel
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReviveLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LinkModel
+}
+
+// Status returns HTTPResponse.Status
+func (r ReviveLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReviveLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FindLinkStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StatsResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r FindLinkStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FindLinkStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPISpecResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPISpecResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPISpecResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckReadinessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponseModel
+	JSON503      *HealthResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckReadinessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckReadinessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LookupRedirectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RedirectResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r LookupRedirectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LookupRedirectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ShortenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ShortenResponseModel
+}
+
+// Status returns HTTPResponse.Status
+func (r ShortenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ShortenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FollowShortUrlResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r FollowShortUrlResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FollowShortUrlResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListLinksWithResponse request returning *ListLinksResponse
+func (c *ClientWithResponses) ListLinksWithResponse(ctx context.Context, params *ListLinksParams, reqEditors ...RequestEditorFn) (*ListLinksResponse, error) {
+	rsp, err := c.ListLinks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListLinksResponse(rsp)
+}
+
+// ExportLinksWithResponse request returning *ExportLinksResponse
+func (c *ClientWithResponses) ExportLinksWithResponse(ctx context.Context, params *ExportLinksParams, reqEditors ...RequestEditorFn) (*ExportLinksResponse, error) {
+	rsp, err := c.ExportLinks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportLinksResponse(rsp)
+}
+
+// ImportLinksWithBodyWithResponse request with arbitrary body returning *ImportLinksResponse
+func (c *ClientWithResponses) ImportLinksWithBodyWithResponse(ctx context.Context, params *ImportLinksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportLinksResponse, error) {
+	rsp, err := c.ImportLinksWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportLinksResponse(rsp)
+}
+
+// DeleteLinkWithResponse request returning *DeleteLinkResponse
+func (c *ClientWithResponses) DeleteLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*DeleteLinkResponse, error) {
+	rsp, err := c.DeleteLink(ctx, shorturlpath, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteLinkResponse(rsp)
+}
+
+// GetLinkWithResponse request returning *GetLinkResponse
+func (c *ClientWithResponses) GetLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*GetLinkResponse, error) {
+	rsp, err := c.GetLink(ctx, shorturlpath, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLinkResponse(rsp)
+}
+
+// UpdateLinkWithBodyWithResponse request with arbitrary body returning *UpdateLinkResponse
+func (c *ClientWithResponses) UpdateLinkWithBodyWithResponse(ctx context.Context, shorturlpath string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateLinkResponse, error) {
+	rsp, err := c.UpdateLinkWithBody(ctx, shorturlpath, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateLinkResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateLinkWithResponse(ctx context.Context, shorturlpath string, body UpdateLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateLinkResponse, error) {
+	rsp, err := c.UpdateLink(ctx, shorturlpath, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateLinkResponse(rsp)
+}
+
+// RestoreLinkWithResponse request returning *RestoreLinkResponse
+func (c *ClientWithResponses) RestoreLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*RestoreLinkResponse, error) {
+	rsp, err := c.RestoreLink(ctx, shorturlpath, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestoreLinkResponse(rsp)
+}
+
+// GetLinkStatsWithResponse request returning *GetLinkStatsResponse
+func (c *ClientWithResponses) GetLinkStatsWithResponse(ctx context.Context, shorturlpath string, params *GetLinkStatsParams, reqEditors ...RequestEditorFn) (*GetLinkStatsResponse, error) {
+	rsp, err := c.GetLinkStats(ctx, shorturlpath, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLinkStatsResponse(rsp)
+}
+
+// StoreCacheWithBodyWithResponse request with arbitrary body returning *StoreCacheResponse
+func (c *ClientWithResponses) StoreCacheWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StoreCacheResponse, error) {
+	rsp, err := c.StoreCacheWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStoreCacheResponse(rsp)
+}
+
+func (c *ClientWithResponses) StoreCacheWithResponse(ctx context.Context, body StoreCacheJSONRequestBody, reqEditors ...RequestEditorFn) (*StoreCacheResponse, error) {
+	rsp, err := c.StoreCache(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStoreCacheResponse(rsp)
+}
+
+// FlushCacheWithResponse request returning *FlushCacheResponse
+func (c *ClientWithResponses) FlushCacheWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*FlushCacheResponse, error) {
+	rsp, err := c.FlushCache(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFlushCacheResponse(rsp)
+}
+
+// WarmCacheWithBodyWithResponse request with arbitrary body returning *WarmCacheResponse
+func (c *ClientWithResponses) WarmCacheWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*WarmCacheResponse, error) {
+	rsp, err := c.WarmCacheWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWarmCacheResponse(rsp)
+}
+
+func (c *ClientWithResponses) WarmCacheWithResponse(ctx context.Context, body WarmCacheJSONRequestBody, reqEditors ...RequestEditorFn) (*WarmCacheResponse, error) {
+	rsp, err := c.WarmCache(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWarmCacheResponse(rsp)
+}
+
+// EvictCacheWithResponse request returning *EvictCacheResponse
+func (c *ClientWithResponses) EvictCacheWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*EvictCacheResponse, error) {
+	rsp, err := c.EvictCache(ctx, shorturlpath, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEvictCacheResponse(rsp)
+}
+
+// RecordClicksWithBodyWithResponse request with arbitrary body returning *RecordClicksResponse
+func (c *ClientWithResponses) RecordClicksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RecordClicksResponse, error) {
+	rsp, err := c.RecordClicksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecordClicksResponse(rsp)
+}
+
+func (c *ClientWithResponses) RecordClicksWithResponse(ctx context.Context, body RecordClicksJSONRequestBody, reqEditors ...RequestEditorFn) (*RecordClicksResponse, error) {
+	rsp, err := c.RecordClicks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecordClicksResponse(rsp)
+}
+
+// CheckLivenessWithResponse request returning *CheckLivenessResponse
+func (c *ClientWithResponses) CheckLivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*CheckLivenessResponse, error) {
+	rsp, err := c.CheckLiveness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckLivenessResponse(rsp)
+}
+
+// QueryLinksWithBodyWithResponse request with arbitrary body returning *QueryLinksResponse
+func (c *ClientWithResponses) QueryLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*QueryLinksResponse, error) {
+	rsp, err := c.QueryLinksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryLinksResponse(rsp)
+}
+
+func (c *ClientWithResponses) QueryLinksWithResponse(ctx context.Context, body QueryLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*QueryLinksResponse, error) {
+	rsp, err := c.QueryLinks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryLinksResponse(rsp)
+}
+
+// StreamLinksWithBodyWithResponse request with arbitrary body returning *StreamLinksResponse
+func (c *ClientWithResponses) StreamLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StreamLinksResponse, error) {
+	rsp, err := c.StreamLinksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamLinksResponse(rsp)
+}
+
+func (c *ClientWithResponses) StreamLinksWithResponse(ctx context.Context, body StreamLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*StreamLinksResponse, error) {
+	rsp, err := c.StreamLinks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamLinksResponse(rsp)
+}
+
+// InsertLinksWithBodyWithResponse request with arbitrary body returning *InsertLinksResponse
+func (c *ClientWithResponses) InsertLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*InsertLinksResponse, error) {
+	rsp, err := c.InsertLinksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInsertLinksResponse(rsp)
+}
+
+func (c *ClientWithResponses) InsertLinksWithResponse(ctx context.Context, body InsertLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*InsertLinksResponse, error) {
+	rsp, err := c.InsertLinks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInsertLinksResponse(rsp)
+}
+
+// QueryTopLinksWithBodyWithResponse request with arbitrary body returning *QueryTopLinksResponse
+func (c *ClientWithResponses) QueryTopLinksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*QueryTopLinksResponse, error) {
+	rsp, err := c.QueryTopLinksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryTopLinksResponse(rsp)
+}
+
+func (c *ClientWithResponses) QueryTopLinksWithResponse(ctx context.Context, body QueryTopLinksJSONRequestBody, reqEditors ...RequestEditorFn) (*QueryTopLinksResponse, error) {
+	rsp, err := c.QueryTopLinks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryTopLinksResponse(rsp)
+}
+
+// RemoveLinkWithResponse request returning *RemoveLinkResponse
+func (c *ClientWithResponses) RemoveLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*RemoveLinkResponse, error) {
+	rsp, err := c.RemoveLink(ctx, shorturlpath, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveLinkResponse(rsp)
+}
+
+// FindLinkWithResponse request returning *FindLinkResponse
+func (c *ClientWithResponses) FindLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*FindLinkResponse, error) {
+	rsp, err := c.FindLink(ctx, shorturlpath, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFindLinkResponse(rsp)
+}
+
+// PatchLinkWithBodyWithResponse request with arbitrary body returning *PatchLinkResponse
+func (c *ClientWithResponses) PatchLinkWithBodyWithResponse(ctx context.Context, shorturlpath string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchLinkResponse, error) {
+	rsp, err := c.PatchLinkWithBody(ctx, shorturlpath, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchLinkResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchLinkWithResponse(ctx context.Context, shorturlpath string, body PatchLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchLinkResponse, error) {
+	rsp, err := c.PatchLink(ctx, shorturlpath, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchLinkResponse(rsp)
+}
+
+// ReviveLinkWithResponse request returning *ReviveLinkResponse
+func (c *ClientWithResponses) ReviveLinkWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*ReviveLinkResponse, error) {
+	rsp, err := c.ReviveLink(ctx, shorturlpath, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReviveLinkResponse(rsp)
+}
+
+// FindLinkStatsWithResponse request returning *FindLinkStatsResponse
+func (c *ClientWithResponses) FindLinkStatsWithResponse(ctx context.Context, shorturlpath string, params *FindLinkStatsParams, reqEditors ...RequestEditorFn) (*FindLinkStatsResponse, error) {
+	rsp, err := c.FindLinkStats(ctx, shorturlpath, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFindLinkStatsResponse(rsp)
+}
+
+// GetOpenAPISpecWithResponse request returning *GetOpenAPISpecResponse
+func (c *ClientWithResponses) GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error) {
+	rsp, err := c.GetOpenAPISpec(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPISpecResponse(rsp)
+}
+
+// CheckReadinessWithResponse request returning *CheckReadinessResponse
+func (c *ClientWithResponses) CheckReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*CheckReadinessResponse, error) {
+	rsp, err := c.CheckReadiness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckReadinessResponse(rsp)
+}
+
+// LookupRedirectWithBodyWithResponse request with arbitrary body returning *LookupRedirectResponse
+func (c *ClientWithResponses) LookupRedirectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LookupRedirectResponse, error) {
+	rsp, err := c.LookupRedirectWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupRedirectResponse(rsp)
+}
+
+func (c *ClientWithResponses) LookupRedirectWithResponse(ctx context.Context, body LookupRedirectJSONRequestBody, reqEditors ...RequestEditorFn) (*LookupRedirectResponse, error) {
+	rsp, err := c.LookupRedirect(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLookupRedirectResponse(rsp)
+}
+
+// ShortenWithBodyWithResponse request with arbitrary body returning *ShortenResponse
+func (c *ClientWithResponses) ShortenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ShortenResponse, error) {
+	rsp, err := c.ShortenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseShortenResponse(rsp)
+}
+
+func (c *ClientWithResponses) ShortenWithResponse(ctx context.Context, body ShortenJSONRequestBody, reqEditors ...RequestEditorFn) (*ShortenResponse, error) {
+	rsp, err := c.Shorten(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseShortenResponse(rsp)
+}
+
+// FollowShortUrlWithResponse request returning *FollowShortUrlResponse
+func (c *ClientWithResponses) FollowShortUrlWithResponse(ctx context.Context, shorturlpath string, reqEditors ...RequestEditorFn) (*FollowShortUrlResponse, error) {
+	rsp, err := c.FollowShortUrl(ctx, shorturlpath, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFollowShortUrlResponse(rsp)
+}
+
+// ParseListLinksResponse parses an HTTP response from a ListLinksWithResponse call
+func ParseListLinksResponse(rsp *http.Response) (*ListLinksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListLinksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ListResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportLinksResponse parses an HTTP response from a ExportLinksWithResponse call
+func ParseExportLinksResponse(rsp *http.Response) (*ExportLinksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportLinksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseImportLinksResponse parses an HTTP response from a ImportLinksWithResponse call
+func ParseImportLinksResponse(rsp *http.Response) (*ImportLinksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportLinksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportSummaryModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ImportSummaryModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ImportSummaryModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteLinkResponse parses an HTTP response from a DeleteLinkWithResponse call
+func ParseDeleteLinkResponse(rsp *http.Response) (*DeleteLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetLinkResponse parses an HTTP response from a GetLinkWithResponse call
+func ParseGetLinkResponse(rsp *http.Response) (*GetLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LinkModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateLinkResponse parses an HTTP response from a UpdateLinkWithResponse call
+func ParseUpdateLinkResponse(rsp *http.Response) (*UpdateLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LinkModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRestoreLinkResponse parses an HTTP response from a RestoreLinkWithResponse call
+func ParseRestoreLinkResponse(rsp *http.Response) (*RestoreLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RestoreLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LinkModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLinkStatsResponse parses an HTTP response from a GetLinkStatsWithResponse call
+func ParseGetLinkStatsResponse(rsp *http.Response) (*GetLinkStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLinkStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StatsResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStoreCacheResponse parses an HTTP response from a StoreCacheWithResponse call
+func ParseStoreCacheResponse(rsp *http.Response) (*StoreCacheResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StoreCacheResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseFlushCacheResponse parses an HTTP response from a FlushCacheWithResponse call
+func ParseFlushCacheResponse(rsp *http.Response) (*FlushCacheResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FlushCacheResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseWarmCacheResponse parses an HTTP response from a WarmCacheWithResponse call
+func ParseWarmCacheResponse(rsp *http.Response) (*WarmCacheResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &WarmCacheResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseEvictCacheResponse parses an HTTP response from a EvictCacheWithResponse call
+func ParseEvictCacheResponse(rsp *http.Response) (*EvictCacheResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EvictCacheResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseRecordClicksResponse parses an HTTP response from a RecordClicksWithResponse call
+func ParseRecordClicksResponse(rsp *http.Response) (*RecordClicksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RecordClicksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseCheckLivenessResponse parses an HTTP response from a CheckLivenessWithResponse call
+func ParseCheckLivenessResponse(rsp *http.Response) (*CheckLivenessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckLivenessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQueryLinksResponse parses an HTTP response from a QueryLinksWithResponse call
+func ParseQueryLinksResponse(rsp *http.Response) (*QueryLinksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryLinksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ListResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamLinksResponse parses an HTTP response from a StreamLinksWithResponse call
+func ParseStreamLinksResponse(rsp *http.Response) (*StreamLinksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamLinksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseInsertLinksResponse parses an HTTP response from a InsertLinksWithResponse call
+func ParseInsertLinksResponse(rsp *http.Response) (*InsertLinksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &InsertLinksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQueryTopLinksResponse parses an HTTP response from a QueryTopLinksWithResponse call
+func ParseQueryTopLinksResponse(rsp *http.Response) (*QueryTopLinksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryTopLinksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ListResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveLinkResponse parses an HTTP response from a RemoveLinkWithResponse call
+func ParseRemoveLinkResponse(rsp *http.Response) (*RemoveLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseFindLinkResponse parses an HTTP response from a FindLinkWithResponse call
+func ParseFindLinkResponse(rsp *http.Response) (*FindLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FindLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LinkModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchLinkResponse parses an HTTP response from a PatchLinkWithResponse call
+func ParsePatchLinkResponse(rsp *http.Response) (*PatchLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LinkModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReviveLinkResponse parses an HTTP response from a ReviveLinkWithResponse call
+func ParseReviveLinkResponse(rsp *http.Response) (*ReviveLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReviveLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LinkModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseFindLinkStatsResponse parses an HTTP response from a FindLinkStatsWithResponse call
+func ParseFindLinkStatsResponse(rsp *http.Response) (*FindLinkStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FindLinkStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StatsResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOpenAPISpecResponse parses an HTTP response from a GetOpenAPISpecWithResponse call
+func ParseGetOpenAPISpecResponse(rsp *http.Response) (*GetOpenAPISpecResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOpenAPISpecResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCheckReadinessResponse parses an HTTP response from a CheckReadinessWithResponse call
+func ParseCheckReadinessResponse(rsp *http.Response) (*CheckReadinessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckReadinessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest HealthResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLookupRedirectResponse parses an HTTP response from a LookupRedirectWithResponse call
+func ParseLookupRedirectResponse(rsp *http.Response) (*LookupRedirectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LookupRedirectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RedirectResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseShortenResponse parses an HTTP response from a ShortenWithResponse call
+func ParseShortenResponse(rsp *http.Response) (*ShortenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ShortenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ShortenResponseModel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseFollowShortUrlResponse parses an HTTP response from a FollowShortUrlWithResponse call
+func ParseFollowShortUrlResponse(rsp *http.Response) (*FollowShortUrlResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FollowShortUrlResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+)
+
+//go:generate oapi-codegen -config oapi-codegen.yaml openapi.json
+
+// Spec is the OpenAPI specification of every service, served by the main
+// service at /openapi.json.
+//
+//go:embed openapi.json
+var Spec []byte
+
+const (
+	ImportStatusCreated  = "created"
+	ImportStatusConflict = "conflict"
+	ImportStatusInvalid  = "invalid"
+	ImportStatusFailed   = "failed"
+)
+
+// WithRequestId forwards the request id of the incoming request, so a call
+// can be followed through the logs of every service.
+func WithRequestId(requestId string) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-request-id", requestId)
+		return nil
+	}
+}
+
+// AddRow records the outcome of an imported row. Only rows that were not
+// created are kept, so the summary stays small for large imports.
+func (s *ImportSummaryModel) AddRow(row int, shortUrlPath, status, err string) {
+	switch status {
+	case ImportStatusCreated:
+		s.Created++
+		return
+	case ImportStatusConflict:
+		s.Conflicts++
+	case ImportStatusInvalid:
+		s.Invalid++
+	default:
+		s.Failed++
+	}
+
+	s.Rows = append(s.Rows, ImportRowModel{
+		Row:          row,
+		ShortUrlPath: shortUrlPath,
+		Status:       status,
+		Error:        err,
+	})
+}
@@ -0,0 +1,35 @@
+package api_test
+
+import (
+	"encoding/json"
+	"testing"
+	"url-shortner-api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpec(t *testing.T) {
+	spec := map[string]interface{}{}
+
+	assert.Nil(t, json.Unmarshal(api.Spec, &spec))
+	assert.Equal(t, "3.0.3", spec["openapi"])
+}
+
+func TestAddRow(t *testing.T) {
+	summary := &api.ImportSummaryModel{}
+
+	summary.AddRow(1, "abc", api.ImportStatusCreated, "")
+	summary.AddRow(2, "def", api.ImportStatusConflict, "exists")
+	summary.AddRow(3, "", api.ImportStatusInvalid, "missing url")
+	summary.AddRow(4, "ghi", api.ImportStatusFailed, "timeout")
+
+	assert.Equal(t, 1, summary.Created)
+	assert.Equal(t, 1, summary.Conflicts)
+	assert.Equal(t, 1, summary.Invalid)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, []api.ImportRowModel{
+		{Row: 2, ShortUrlPath: "def", Status: api.ImportStatusConflict, Error: "exists"},
+		{Row: 3, Status: api.ImportStatusInvalid, Error: "missing url"},
+		{Row: 4, ShortUrlPath: "ghi", Status: api.ImportStatusFailed, Error: "timeout"},
+	}, summary.Rows)
+}
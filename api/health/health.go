@@ -0,0 +1,173 @@
+// Package health serves the liveness and readiness endpoints of the services,
+// over HTTP at /healthz and /readyz and over gRPC as the standard health
+// service.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	api "url-shortner-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	StatusOk          = "ok"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+)
+
+// CheckTimeout bounds every readiness check, so a dependency that hangs makes
+// the service unready instead of the probe timing out.
+const CheckTimeout = 2 * time.Second
+
+// Check reports whether a dependency can be used.
+type Check func(ctx context.Context) error
+
+// Checks are the readiness checks of a service by name, e.g. "redis".
+type Checks map[string]Check
+
+// Run runs every check at the same time and returns the outcome of each.
+func (checks Checks) Run(ctx context.Context) (api.HealthResponseModel, bool) {
+	return run(ctx, checks, nil)
+}
+
+// run runs the required and optional checks at the same time. The service is
+// ready while every required check passes, and degraded when an optional one
+// fails.
+func run(ctx context.Context, required, optional Checks) (api.HealthResponseModel, bool) {
+	ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+	defer cancel()
+
+	response := api.HealthResponseModel{Status: StatusOk, Checks: make(map[string]string, len(required)+len(optional))}
+	ready, degraded := true, false
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	start := func(name string, check Check, isRequired bool) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result := StatusOk
+			if err := check(ctx); err != nil {
+				result = err.Error()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			response.Checks[name] = result
+			if result != StatusOk {
+				if isRequired {
+					ready = false
+				} else {
+					degraded = true
+				}
+			}
+		}()
+	}
+
+	for name, check := range required {
+		start(name, check, true)
+	}
+
+	for name, check := range optional {
+		start(name, check, false)
+	}
+
+	wg.Wait()
+
+	switch {
+	case !ready:
+		response.Status = StatusUnavailable
+	case degraded:
+		response.Status = StatusDegraded
+	}
+
+	return response, ready
+}
+
+// Live answers the liveness probe. It checks nothing, so it only fails when
+// the process can no longer serve requests at all.
+func Live(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, api.HealthResponseModel{Status: StatusOk})
+}
+
+// Ready answers the readiness probe with 200 when every check passes and 503
+// otherwise, listing the outcome of each check.
+func Ready(checks Checks) http.HandlerFunc {
+	return ReadyWithOptional(checks, nil)
+}
+
+// ReadyWithOptional answers the readiness probe like Ready, but a failed
+// optional check only reports the service as degraded, with a 200. It is
+// meant for dependencies the service can do without, such as a cache it
+// falls back from.
+func ReadyWithOptional(checks, optional Checks) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, ok := run(r.Context(), checks, optional)
+
+		if !ok {
+			writeResponse(w, http.StatusServiceUnavailable, response)
+			return
+		}
+
+		writeResponse(w, http.StatusOK, response)
+	}
+}
+
+func writeResponse(w http.ResponseWriter, status int, response api.HealthResponseModel) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
+
+// grpcServer is the gRPC health service. Only the overall health of the
+// server, the empty service name, is known and it is serving when every
+// readiness check passes.
+type grpcServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	checks Checks
+}
+
+func NewGrpcServer(checks Checks) *grpcServer {
+	return &grpcServer{checks: checks}
+}
+
+func (s *grpcServer) Check(ctx context.Context, request *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if request.GetService() != "" {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", request.GetService())
+	}
+
+	if _, ok := s.checks.Run(ctx); !ok {
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+// CheckGrpc asks the health service of another server whether it is serving.
+func CheckGrpc(ctx context.Context, client grpc_health_v1.HealthClient) error {
+	response, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+
+	if err != nil {
+		return err
+	}
+
+	if response.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return errors.New("not serving")
+	}
+
+	return nil
+}
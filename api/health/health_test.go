@@ -0,0 +1,123 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "url-shortner-api"
+	"url-shortner-api/health"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestLive(t *testing.T) {
+	resp := httptest.NewRecorder()
+	health.Live(resp, httptest.NewRequest("GET", "/healthz", nil))
+
+	body := api.HealthResponseModel{}
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, health.StatusOk, body.Status)
+}
+
+func TestReady(t *testing.T) {
+	passing := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+	hanging := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := map[string]struct {
+		checks         health.Checks
+		optional       health.Checks
+		ExpectedStatus int
+		ExpectedBody   api.HealthResponseModel
+	}{
+		"Ready": {
+			checks:         health.Checks{"redis": passing, "database-service": passing},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   api.HealthResponseModel{Status: health.StatusOk, Checks: map[string]string{"redis": "ok", "database-service": "ok"}},
+		},
+		"CheckFailed": {
+			checks:         health.Checks{"redis": passing, "mongo": failing},
+			ExpectedStatus: http.StatusServiceUnavailable,
+			ExpectedBody:   api.HealthResponseModel{Status: health.StatusUnavailable, Checks: map[string]string{"redis": "ok", "mongo": "connection refused"}},
+		},
+		"OptionalCheckFailed": {
+			checks:         health.Checks{"database-service": passing},
+			optional:       health.Checks{"cache-service": failing},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   api.HealthResponseModel{Status: health.StatusDegraded, Checks: map[string]string{"database-service": "ok", "cache-service": "connection refused"}},
+		},
+		"RequiredAndOptionalCheckFailed": {
+			checks:         health.Checks{"database-service": failing},
+			optional:       health.Checks{"cache-service": failing},
+			ExpectedStatus: http.StatusServiceUnavailable,
+			ExpectedBody:   api.HealthResponseModel{Status: health.StatusUnavailable, Checks: map[string]string{"database-service": "connection refused", "cache-service": "connection refused"}},
+		},
+		"CheckTimedOut": {
+			checks:         health.Checks{"mongo": hanging},
+			ExpectedStatus: http.StatusServiceUnavailable,
+			ExpectedBody:   api.HealthResponseModel{Status: health.StatusUnavailable, Checks: map[string]string{"mongo": context.DeadlineExceeded.Error()}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			start := time.Now()
+			health.ReadyWithOptional(test.checks, test.optional)(resp, httptest.NewRequest("GET", "/readyz", nil))
+
+			body := api.HealthResponseModel{}
+
+			assert.Equal(t, test.ExpectedStatus, resp.Code)
+			assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, test.ExpectedBody, body)
+			assert.Less(t, time.Since(start), health.CheckTimeout+time.Second)
+		})
+	}
+}
+
+func TestGrpcServer(t *testing.T) {
+	ready := true
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, health.NewGrpcServer(health.Checks{
+		"redis": func(ctx context.Context) error {
+			if !ready {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	}))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	assert.Nil(t, health.CheckGrpc(context.Background(), client))
+
+	ready = false
+	assert.NotNil(t, health.CheckGrpc(context.Background(), client))
+
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "shortener.CacheService"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,183 @@
+// Package httpmiddleware holds the HTTP middlewares shared by the services:
+// request timeouts, tracing and metrics. Routes are identified by their mux
+// path template, so every short url path does not get its own timeout, span
+// name or series.
+package httpmiddleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"url-shortner-api/tracing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Timeouts is how long a request may take before its context is cancelled.
+// Routes are keyed by their mux path template, e.g. "/api/links/{url}", and a
+// zero timeout leaves the route unbounded.
+type Timeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// ParseTimeouts reads ROUTE_TIMEOUTS, a comma separated list of
+// template=duration entries, e.g. "/{url}=1s,/api/links/export=0"; a later
+// entry for the same route wins. Other routes are bounded by requestTimeout.
+func ParseTimeouts(requestTimeout time.Duration, routeTimeouts string) (Timeouts, error) {
+	if requestTimeout < 0 {
+		return Timeouts{}, fmt.Errorf("request timeout: negative duration %s", requestTimeout)
+	}
+
+	timeouts := Timeouts{
+		Default: requestTimeout,
+		Routes:  map[string]time.Duration{},
+	}
+
+	for _, entry := range strings.Split(routeTimeouts, ",") {
+		entry = strings.TrimSpace(entry)
+
+		if entry == "" {
+			continue
+		}
+
+		route, value, found := strings.Cut(entry, "=")
+
+		if !found || route == "" {
+			return Timeouts{}, fmt.Errorf("route timeout %q: expected template=duration", entry)
+		}
+
+		timeout, err := parseTimeout(value)
+
+		if err != nil {
+			return Timeouts{}, fmt.Errorf("route timeout %q: %w", entry, err)
+		}
+
+		timeouts.Routes[route] = timeout
+	}
+
+	return timeouts, nil
+}
+
+func parseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(strings.TrimSpace(value))
+
+	if err != nil {
+		return 0, err
+	}
+
+	if timeout < 0 {
+		return 0, fmt.Errorf("negative duration %s", timeout)
+	}
+
+	return timeout, nil
+}
+
+// TimeoutMiddleware puts a deadline on the request context of the matched
+// route, so the calls made for a slow request are cancelled together with it.
+// The timeouts are loaded for every request, so they can be replaced when the
+// config is reloaded.
+func TimeoutMiddleware(timeouts *atomic.Pointer[Timeouts]) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := timeouts.Load()
+			timeout := current.Default
+
+			if routeTimeout, ok := current.Routes[routeTemplate(r)]; ok {
+				timeout = routeTimeout
+			}
+
+			if timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), timeout)
+				defer cancel()
+
+				r = r.WithContext(ctx)
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TracingMiddleware starts a span for every request, continuing the trace of
+// the caller when it sent a traceparent header. The span is named after the
+// route and carries the request id.
+func TracingMiddleware(h http.Handler) http.Handler {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tracing.SetRequestId(r.Context(), r.Header.Get("X-request-id"))
+		h.ServeHTTP(w, r)
+	})
+
+	return otelhttp.NewHandler(handler, "", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method + " " + routeTemplate(r)
+	}))
+}
+
+// MetricsMiddleware records the count and latency of every request in the
+// collectors of the service, which are labelled by route, method and status.
+func MetricsMiddleware(requests *prometheus.CounterVec, duration *prometheus.HistogramVec) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := routeTemplate(r)
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+
+			h.ServeHTTP(recorder, r)
+
+			status := strconv.Itoa(recorder.status)
+			requests.WithLabelValues(route, r.Method, status).Inc()
+			duration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// statusRecorder keeps the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.wroteHeader {
+		s.status = status
+		s.wroteHeader = true
+	}
+
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(body []byte) (int, error) {
+	s.wroteHeader = true
+	return s.ResponseWriter.Write(body)
+}
+
+// Flush keeps streamed responses, such as link exports, flushing.
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// routeTemplate returns the template of the matched route, e.g. "/{url}", or
+// "unknown" outside of the router.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return "unknown"
+}
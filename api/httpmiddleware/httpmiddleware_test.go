@@ -0,0 +1,165 @@
+package httpmiddleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"url-shortner-api/httpmiddleware"
+	"url-shortner-api/tracing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestParseTimeouts(t *testing.T) {
+	tests := map[string]struct {
+		requestTimeout   time.Duration
+		routeTimeouts    string
+		ExpectedTimeouts httpmiddleware.Timeouts
+		ExpectedError    bool
+	}{
+		"Defaults": {
+			requestTimeout:   10 * time.Second,
+			ExpectedTimeouts: httpmiddleware.Timeouts{Default: 10 * time.Second, Routes: map[string]time.Duration{}},
+		},
+		"Routes": {
+			requestTimeout: 5 * time.Second,
+			routeTimeouts:  "/{url}=1s, /api/links/export=0,,/{url}=2s",
+			ExpectedTimeouts: httpmiddleware.Timeouts{Default: 5 * time.Second, Routes: map[string]time.Duration{
+				"/{url}":            2 * time.Second,
+				"/api/links/export": 0,
+			}},
+		},
+		"NegativeRequestTimeout": {
+			requestTimeout: -time.Second,
+			ExpectedError:  true,
+		},
+		"MissingDuration": {
+			routeTimeouts: "/{url}",
+			ExpectedError: true,
+		},
+		"NegativeDuration": {
+			routeTimeouts: "/{url}=-1s",
+			ExpectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeouts, err := httpmiddleware.ParseTimeouts(test.requestTimeout, test.routeTimeouts)
+
+			if test.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedTimeouts, timeouts)
+		})
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	timeouts := httpmiddleware.Timeouts{
+		Default: time.Minute,
+		Routes:  map[string]time.Duration{"/api/links/export": 0, "/{url}": time.Second},
+	}
+
+	var current atomic.Pointer[httpmiddleware.Timeouts]
+	current.Store(&timeouts)
+
+	tests := map[string]struct {
+		reqUrl           string
+		ExpectedDeadline time.Duration
+	}{
+		"Default":   {reqUrl: "/shorten", ExpectedDeadline: time.Minute},
+		"Route":     {reqUrl: "/abc1234", ExpectedDeadline: time.Second},
+		"Unbounded": {reqUrl: "/api/links/export", ExpectedDeadline: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var deadline time.Time
+			var hasDeadline bool
+
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				deadline, hasDeadline = r.Context().Deadline()
+			}
+
+			r := mux.NewRouter()
+			r.HandleFunc("/shorten", handler)
+			r.HandleFunc("/api/links/export", handler)
+			r.HandleFunc("/{url}", handler)
+			r.Use(httpmiddleware.TimeoutMiddleware(&current))
+
+			start := time.Now()
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", test.reqUrl, nil))
+
+			if test.ExpectedDeadline == 0 {
+				assert.False(t, hasDeadline)
+				return
+			}
+
+			assert.True(t, hasDeadline)
+			assert.WithinDuration(t, start.Add(test.ExpectedDeadline), deadline, time.Second)
+		})
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "http_requests_total"}, []string{"route", "method", "status"})
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "http_request_duration_seconds"}, []string{"route", "method", "status"})
+
+	r := mux.NewRouter()
+	r.HandleFunc("/api/links/export", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		assert.True(t, ok)
+
+		w.Write([]byte("{}\n"))
+		flusher.Flush()
+	})
+	r.HandleFunc("/{url}", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://google.com", http.StatusMovedPermanently)
+	})
+	r.Use(httpmiddleware.MetricsMiddleware(requests, duration))
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/abc1234", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/def5678", nil))
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest("GET", "/api/links/export", nil))
+
+	assert.True(t, resp.Flushed)
+	assert.Equal(t, float64(2), testutil.ToFloat64(requests.WithLabelValues("/{url}", "GET", "301")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(requests.WithLabelValues("/api/links/export", "GET", "200")))
+	assert.Equal(t, 2, testutil.CollectAndCount(duration))
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracing.Install("test", sdktrace.WithSyncer(exporter))
+
+	r := mux.NewRouter()
+	r.HandleFunc("/{url}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Use(httpmiddleware.TracingMiddleware)
+
+	req := httptest.NewRequest("GET", "/abc1234", nil)
+	req.Header.Set("X-request-id", "1234")
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "GET /{url}", spans[0].Name)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	assert.Contains(t, spans[0].Attributes, tracing.RequestIdKey.String("1234"))
+}
@@ -0,0 +1,44 @@
+// Package kafkalog sends the logs of a service to its Kafka topic, where the
+// kafka service picks them up.
+package kafkalog
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Writer is the io.Writer behind the production logger. Writes are batched
+// and sent in the background, so Close must be called before the service
+// exits or the last logs are lost.
+type Writer struct {
+	writer *kafka.Writer
+}
+
+func NewWriter(brokers []string, topic string) *Writer {
+	return &Writer{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.LeastBytes{},
+			Async:    true,
+		},
+	}
+}
+
+func (w *Writer) Write(entry []byte) (int, error) {
+	// The logger reuses its buffer once Write returns, while the message
+	// is only sent with the next batch.
+	value := make([]byte, len(entry))
+	copy(value, entry)
+
+	return len(entry), w.writer.WriteMessages(context.Background(), kafka.Message{
+		Key:   []byte("logs"),
+		Value: value,
+	})
+}
+
+// Close sends the pending logs and closes the connections to the brokers.
+func (w *Writer) Close() error {
+	return w.writer.Close()
+}
@@ -0,0 +1,37 @@
+// Package pb holds the gRPC API of the internal services, generated from
+// proto/shortener.proto.
+package pb
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+//go:generate protoc -I ../proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative shortener.proto
+
+// RequestIdKey is the metadata key carrying the request id, the gRPC
+// counterpart of the X-request-id header.
+const RequestIdKey = "x-request-id"
+
+// CodeGone is the status code Resolve fails with for a deleted link, the
+// gRPC counterpart of 410 Gone, which gRPC has no code of its own for.
+const CodeGone = codes.FailedPrecondition
+
+// WithRequestId forwards the request id of the incoming request, so a call
+// can be followed through the logs of every service.
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RequestIdKey, requestId)
+}
+
+// RequestId returns the request id sent by the caller, or an empty string.
+func RequestId(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, RequestIdKey)
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,21 @@
+package pb_test
+
+import (
+	"context"
+	"testing"
+
+	"url-shortner-api/pb"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestId(t *testing.T) {
+	ctx := pb.WithRequestId(context.Background(), "abc")
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	assert.True(t, ok)
+
+	assert.Equal(t, "abc", pb.RequestId(metadata.NewIncomingContext(context.Background(), md)))
+	assert.Equal(t, "", pb.RequestId(context.Background()))
+}
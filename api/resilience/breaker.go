@@ -0,0 +1,182 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrCircuitOpen is returned without calling the downstream service while
+// its circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// breakers publishes the state of every breaker with the Prometheus metrics
+// of the service.
+var breakers = newCollector()
+
+func init() {
+	prometheus.MustRegister(breakers)
+}
+
+// Breaker stops calls to a downstream service after a run of consecutive
+// failures. Once openTimeout has passed a single trial call is let through:
+// its success closes the breaker again, its failure keeps it open.
+type Breaker struct {
+	name        string
+	threshold   int
+	openTimeout time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	opens    int64
+	rejected int64
+	now      func() time.Time
+}
+
+func NewBreaker(name string, threshold int, openTimeout time.Duration) *Breaker {
+	b := &Breaker{
+		name:        name,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		now:         time.Now,
+	}
+
+	breakers.add(b)
+
+	return b
+}
+
+// Allow reports whether a call may be made. Every allowed call must be
+// followed by Success or Failure.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			b.rejected++
+			return false
+		}
+
+		b.state = StateHalfOpen
+		return true
+	case StateHalfOpen:
+		// A trial call is in flight.
+		b.rejected++
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+		b.opens++
+	}
+}
+
+// release ends an allowed call without an outcome, e.g. when the caller gave
+// up. A trial call is allowed again right away.
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.state = StateOpen
+	}
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// collector exports the state, opens and rejected calls of every breaker,
+// labelled with its name. A breaker created again under the same name
+// replaces the old one.
+type collector struct {
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+
+	state    *prometheus.Desc
+	opens    *prometheus.Desc
+	rejected *prometheus.Desc
+}
+
+func newCollector() *collector {
+	return &collector{
+		breakers: map[string]*Breaker{},
+		state:    prometheus.NewDesc("circuit_breaker_state", "State of the circuit breaker: 0 closed, 1 open, 2 half-open.", []string{"name"}, nil),
+		opens:    prometheus.NewDesc("circuit_breaker_opens_total", "Times the circuit breaker opened.", []string{"name"}, nil),
+		rejected: prometheus.NewDesc("circuit_breaker_rejected_total", "Calls rejected while the circuit breaker was open.", []string{"name"}, nil),
+	}
+}
+
+func (c *collector) add(b *Breaker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.breakers[b.name] = b
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.state
+	ch <- c.opens
+	ch <- c.rejected
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, b := range c.breakers {
+		b.mu.Lock()
+		state, opens, rejected := b.state, b.opens, b.rejected
+		b.mu.Unlock()
+
+		ch <- prometheus.MustNewConstMetric(c.state, prometheus.GaugeValue, float64(state), name)
+		ch <- prometheus.MustNewConstMetric(c.opens, prometheus.CounterValue, float64(opens), name)
+		ch <- prometheus.MustNewConstMetric(c.rejected, prometheus.CounterValue, float64(rejected), name)
+	}
+}
@@ -0,0 +1,78 @@
+package resilience_test
+
+import (
+	"testing"
+	"time"
+
+	"url-shortner-api/resilience"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	breaker := resilience.NewBreaker(t.Name(), 2, 20*time.Millisecond)
+
+	assert.True(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, resilience.StateClosed, breaker.State())
+
+	assert.True(t, breaker.Allow())
+	breaker.Success()
+	assert.True(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, resilience.StateClosed, breaker.State(), "a success resets the failure count")
+
+	assert.True(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, resilience.StateOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+
+	time.Sleep(30 * time.Millisecond)
+
+	assert.True(t, breaker.Allow())
+	assert.Equal(t, resilience.StateHalfOpen, breaker.State())
+	assert.False(t, breaker.Allow(), "only one trial call at a time")
+	breaker.Failure()
+	assert.Equal(t, resilience.StateOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+
+	time.Sleep(30 * time.Millisecond)
+
+	assert.True(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, resilience.StateClosed, breaker.State())
+	assert.True(t, breaker.Allow())
+}
+
+func TestBreakerMetrics(t *testing.T) {
+	breaker := resilience.NewBreaker(t.Name(), 1, time.Minute)
+
+	assert.True(t, breaker.Allow())
+	breaker.Failure()
+	assert.False(t, breaker.Allow())
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.Nil(t, err)
+
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if len(metric.GetLabel()) != 1 || metric.GetLabel()[0].GetValue() != t.Name() {
+				continue
+			}
+
+			if metric.GetGauge() != nil {
+				values[family.GetName()] = metric.GetGauge().GetValue()
+			} else {
+				values[family.GetName()] = metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]float64{
+		"circuit_breaker_state":          float64(resilience.StateOpen),
+		"circuit_breaker_opens_total":    1,
+		"circuit_breaker_rejected_total": 1,
+	}, values)
+}
@@ -0,0 +1,249 @@
+// Package resilience holds the client side protections shared by the
+// services: connection pooling, per-call deadlines, retries with jittered
+// backoff for idempotent calls and a circuit breaker per downstream service.
+// Every attempt is traced and carries the trace context of its caller.
+package resilience
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+type Config struct {
+	// Name identifies the downstream service in the circuit breaker metrics.
+	Name string
+	// Timeout bounds every attempt of a call.
+	Timeout time.Duration
+	// MaxRetries is the number of retries of a failed idempotent call.
+	MaxRetries  int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// FailureThreshold consecutive failed calls open the breaker for
+	// OpenTimeout.
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	// TLS configures calls to https urls, e.g. with a client certificate.
+	// Nil uses the system roots.
+	TLS *tls.Config
+}
+
+func DefaultConfig(name string) Config {
+	return Config{
+		Name:             name,
+		Timeout:          2 * time.Second,
+		MaxRetries:       2,
+		BaseBackoff:      50 * time.Millisecond,
+		MaxBackoff:       500 * time.Millisecond,
+		FailureThreshold: 5,
+		OpenTimeout:      10 * time.Second,
+	}
+}
+
+type contextKey int
+
+const (
+	idempotentKey contextKey = iota
+	timeoutKey
+)
+
+// Idempotent marks a call that is safe to retry although its method is not,
+// such as a lookup sent as a POST.
+func Idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey, true)
+}
+
+// WithTimeout overrides the per attempt timeout of a call. Zero disables it,
+// which is meant for streamed responses.
+func WithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey, timeout)
+}
+
+// Client is an http.Client that retries and breaks the circuit. It satisfies
+// the HttpRequestDoer of the generated API client.
+type Client struct {
+	client  *http.Client
+	config  Config
+	breaker *Breaker
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func NewClient(config Config) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 100
+
+	if config.TLS != nil {
+		transport.TLSClientConfig = config.TLS
+	}
+
+	return &Client{
+		client:  &http.Client{Transport: otelhttp.NewTransport(transport)},
+		config:  config,
+		breaker: NewBreaker(config.Name, config.FailureThreshold, config.OpenTimeout),
+		sleep:   sleep,
+	}
+}
+
+// NewProbeClient returns a plain http.Client with the TLS settings and the
+// per call timeout of config, but without retries or a breaker. Readiness
+// probes use it, so a failing probe neither waits for retries nor trips the
+// breaker of the calls that serve requests.
+func NewProbeClient(config Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.TLS != nil {
+		transport.TLSClientConfig = config.TLS
+	}
+
+	return &http.Client{Transport: transport, Timeout: config.Timeout}
+}
+
+func (c *Client) Breaker() *Breaker {
+	return c.breaker
+}
+
+// Do sends the request. A call fails when the service can't be reached or
+// answers with a 5xx status; a failed idempotent call is retried, and the
+// final outcome is reported to the breaker. The returned response body must
+// be closed as usual.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if !c.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	retryable := isIdempotent(req)
+
+	for attempt := 0; ; attempt++ {
+		resp, cancel, err := c.attempt(req, attempt)
+
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			c.breaker.Success()
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		// The caller gave up, which says nothing about the service.
+		if req.Context().Err() != nil {
+			c.breaker.release()
+			cancel()
+			return nil, req.Context().Err()
+		}
+
+		if !retryable || attempt >= c.config.MaxRetries {
+			c.breaker.Failure()
+
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		cancel()
+
+		if err := c.sleep(req.Context(), c.backoff(attempt)); err != nil {
+			c.breaker.release()
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) attempt(req *http.Request, attempt int) (*http.Response, context.CancelFunc, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+
+	timeout := c.config.Timeout
+	if override, ok := req.Context().Value(timeoutKey).(time.Duration); ok {
+		timeout = override
+	}
+
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	attemptReq := req.Clone(ctx)
+
+	if attempt > 0 && req.Body != nil {
+		body, err := req.GetBody()
+
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+
+		attemptReq.Body = body
+	}
+
+	resp, err := c.client.Do(attemptReq)
+
+	if err != nil {
+		cancel()
+		return nil, func() {}, err
+	}
+
+	return resp, cancel, nil
+}
+
+// backoff returns a random duration up to the exponential backoff of the
+// attempt, so retrying clients spread out.
+func (c *Client) backoff(attempt int) time.Duration {
+	backoff := c.config.BaseBackoff << attempt
+
+	if backoff <= 0 || backoff > c.config.MaxBackoff {
+		backoff = c.config.MaxBackoff
+	}
+
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+func isIdempotent(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if idempotent, ok := req.Context().Value(idempotentKey).(bool); ok && idempotent {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelBody releases the deadline of an attempt once its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
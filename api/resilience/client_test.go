@@ -0,0 +1,224 @@
+package resilience_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"url-shortner-api/resilience"
+	"url-shortner-api/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func testConfig(name string) resilience.Config {
+	config := resilience.DefaultConfig(name)
+	config.Timeout = 50 * time.Millisecond
+	config.BaseBackoff = time.Millisecond
+	config.MaxBackoff = 2 * time.Millisecond
+	config.FailureThreshold = 2
+	config.OpenTimeout = time.Minute
+	return config
+}
+
+func TestClientDo(t *testing.T) {
+	tests := map[string]struct {
+		method           string
+		idempotent       bool
+		failures         int32
+		ExpectedStatus   int
+		ExpectedAttempts int32
+		ExpectedBody     string
+	}{
+		"Success": {
+			method:           http.MethodGet,
+			ExpectedStatus:   http.StatusOK,
+			ExpectedAttempts: 1,
+			ExpectedBody:     "body",
+		},
+		"Retried Get": {
+			method:           http.MethodGet,
+			failures:         2,
+			ExpectedStatus:   http.StatusOK,
+			ExpectedAttempts: 3,
+			ExpectedBody:     "body",
+		},
+		"Retries Exhausted": {
+			method:           http.MethodGet,
+			failures:         5,
+			ExpectedStatus:   http.StatusServiceUnavailable,
+			ExpectedAttempts: 3,
+		},
+		"Post Not Retried": {
+			method:           http.MethodPost,
+			failures:         1,
+			ExpectedStatus:   http.StatusServiceUnavailable,
+			ExpectedAttempts: 1,
+		},
+		"Idempotent Post Retried": {
+			method:           http.MethodPost,
+			idempotent:       true,
+			failures:         1,
+			ExpectedStatus:   http.StatusOK,
+			ExpectedAttempts: 2,
+			ExpectedBody:     "body",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, "body", string(body))
+
+				if attempt <= test.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				w.Write(body)
+			}))
+			defer server.Close()
+
+			client := resilience.NewClient(testConfig(t.Name()))
+
+			ctx := context.Background()
+			if test.idempotent {
+				ctx = resilience.Idempotent(ctx)
+			}
+
+			req, err := http.NewRequestWithContext(ctx, test.method, server.URL, bytes.NewBufferString("body"))
+			assert.NoError(t, err)
+
+			resp, err := client.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.ExpectedStatus, resp.StatusCode)
+			assert.Equal(t, test.ExpectedAttempts, atomic.LoadInt32(&attempts))
+
+			if test.ExpectedBody != "" {
+				assert.Equal(t, test.ExpectedBody, string(body))
+			}
+		})
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig(t.Name())
+	config.MaxRetries = 0
+	client := resilience.NewClient(config)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+
+	req, err = http.NewRequestWithContext(resilience.WithTimeout(context.Background(), 0), http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	resp, err := client.Do(req)
+	assert.NoError(t, err, "a zero timeout disables the deadline")
+	resp.Body.Close()
+}
+
+func TestClientBreaker(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	config := testConfig(t.Name())
+	config.MaxRetries = 0
+	client := resilience.NewClient(config)
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, resilience.StateOpen, client.Breaker().State())
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := client.Do(req)
+	assert.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestClientCallerCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	config := testConfig(t.Name())
+	config.FailureThreshold = 1
+	client := resilience.NewClient(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	_, err := client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, resilience.StateClosed, client.Breaker().State())
+}
+
+func TestClientPropagatesTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.Install("test", sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+	}))
+	defer server.Close()
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "caller")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	resp, err := resilience.NewClient(testConfig("trace")).Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	span.End()
+
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, span.SpanContext().SpanID(), spans[0].Parent.SpanID())
+}
@@ -0,0 +1,50 @@
+package resilience
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor applies the per call timeout and the circuit breaker
+// of the HTTP Client to gRPC calls. Retries are left to the gRPC service
+// config. Health checks bypass the breaker, like the probes of the HTTP
+// clients.
+func UnaryClientInterceptor(config Config) grpc.UnaryClientInterceptor {
+	breaker := NewBreaker(config.Name, config.FailureThreshold, config.OpenTimeout)
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if method == grpc_health_v1.Health_Check_FullMethodName {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		if !breaker.Allow() {
+			return status.Error(codes.Unavailable, ErrCircuitOpen.Error())
+		}
+
+		callCtx := ctx
+		if config.Timeout > 0 {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithTimeout(ctx, config.Timeout)
+			defer cancel()
+		}
+
+		err := invoker(callCtx, method, req, reply, cc, opts...)
+
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+			if ctx.Err() != nil {
+				breaker.release()
+			} else {
+				breaker.Failure()
+			}
+		default:
+			breaker.Success()
+		}
+
+		return err
+	}
+}
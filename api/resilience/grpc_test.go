@@ -0,0 +1,119 @@
+package resilience_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"url-shortner-api/pb"
+	"url-shortner-api/resilience"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type failingCacheServer struct {
+	pb.UnimplementedCacheServiceServer
+	calls int
+	err   error
+}
+
+func (f *failingCacheServer) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveResponse, error) {
+	f.calls++
+	return nil, f.err
+}
+
+type failingHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (f *failingHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, status.Error(codes.Unavailable, "down")
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tests := map[string]struct {
+		err           error
+		ExpectedCalls int
+		ExpectedCode  codes.Code
+	}{
+		"Not Found Keeps Circuit Closed": {
+			err:           status.Error(codes.NotFound, "url not found"),
+			ExpectedCalls: 3,
+			ExpectedCode:  codes.NotFound,
+		},
+		"Unavailable Opens Circuit": {
+			err:           status.Error(codes.Unavailable, "down"),
+			ExpectedCalls: 2,
+			ExpectedCode:  codes.Unavailable,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			listener := bufconn.Listen(1024 * 1024)
+			server := &failingCacheServer{err: test.err}
+
+			grpcServer := grpc.NewServer()
+			pb.RegisterCacheServiceServer(grpcServer, server)
+			go grpcServer.Serve(listener)
+			defer grpcServer.Stop()
+
+			conn, err := grpc.NewClient("passthrough:///bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return listener.DialContext(ctx)
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(testConfig(t.Name()))),
+			)
+			assert.NoError(t, err)
+			defer conn.Close()
+
+			client := pb.NewCacheServiceClient(conn)
+
+			for i := 0; i < 3; i++ {
+				_, err := client.Resolve(context.Background(), &pb.ResolveRequest{ShortUrlPath: "abc1234"})
+				assert.Equal(t, test.ExpectedCode, status.Code(err))
+			}
+
+			assert.Equal(t, test.ExpectedCalls, server.calls)
+		})
+	}
+}
+
+func TestUnaryClientInterceptorSkipsHealthChecks(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := &failingCacheServer{err: status.Error(codes.NotFound, "url not found")}
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterCacheServiceServer(grpcServer, server)
+	grpc_health_v1.RegisterHealthServer(grpcServer, &failingHealthServer{})
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(testConfig(t.Name()))),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	healthClient := grpc_health_v1.NewHealthClient(conn)
+
+	for i := 0; i < 3; i++ {
+		_, err := healthClient.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	}
+
+	_, err = pb.NewCacheServiceClient(conn).Resolve(context.Background(), &pb.ResolveRequest{ShortUrlPath: "abc1234"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "failed health checks do not open the circuit")
+	assert.Equal(t, 1, server.calls)
+}
@@ -0,0 +1,237 @@
+// Package settings loads the typed configuration of a service. A config is a
+// struct whose fields name their key in a config tag:
+//
+//	type Config struct {
+//		RedisAddr     string        `config:"REDIS_ADDR" default:"localhost:6379"`
+//		RedisPassword string        `config:"REDIS_PASSWORD" secret:"true"`
+//		MongoUri      string        `config:"MONGO_URI" required:"true"`
+//		Timeout       time.Duration `config:"REQUEST_TIMEOUT" default:"10s" reload:"true"`
+//	}
+//
+// Every key is read from its default, config/app.env, the environment and a
+// command line flag, e.g. --redis-addr, each overriding the one before.
+// Secret settings are hidden by Redacted, and only reload settings may change
+// while the service runs.
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Validator is implemented by configs with rules beyond the required
+// settings, e.g. a setting that only takes a few values.
+type Validator interface {
+	Validate() error
+}
+
+// Source is where a config was loaded from.
+type Source struct {
+	viper   *viper.Viper
+	current reflect.Value
+}
+
+// Load fills cfg, a pointer to a config struct, and validates it. args are
+// the command line arguments without the program name; an invalid flag or
+// setting is returned as an error, listing every setting that is wrong.
+func Load(cfg interface{}, args []string) (*Source, error) {
+	value := reflect.ValueOf(cfg)
+
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("settings: expected a pointer to a struct, got %T", cfg)
+	}
+
+	v := viper.New()
+	v.AddConfigPath("./config")
+	v.AddConfigPath("../../config")
+	v.SetConfigType("env")
+	v.SetConfigName("app")
+
+	flags := pflag.NewFlagSet("settings", pflag.ContinueOnError)
+
+	for _, field := range fields(value.Elem().Type()) {
+		key := field.Tag.Get("config")
+
+		if fallback, ok := field.Tag.Lookup("default"); ok {
+			v.SetDefault(key, fallback)
+		}
+
+		v.BindEnv(key)
+		flags.String(FlagName(key), "", "overrides "+key)
+		v.BindPFlag(key, flags.Lookup(FlagName(key)))
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := v.ReadInConfig(); err != nil && !errors.As(err, &viper.ConfigFileNotFoundError{}) {
+		return nil, err
+	}
+
+	source := &Source{viper: v, current: reflect.New(value.Elem().Type())}
+
+	if err := source.decode(value); err != nil {
+		return nil, err
+	}
+
+	source.current.Elem().Set(value.Elem())
+
+	return source, nil
+}
+
+// FlagName returns the command line flag of a key, e.g. --redis-addr for
+// REDIS_ADDR.
+func FlagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+// Watch reloads the config when config/app.env changes and passes the new
+// config to onChange, which applies the reload settings. An invalid file is
+// ignored and a change to a setting that needs a restart is only logged.
+func (s *Source) Watch(logger *zap.SugaredLogger, onChange func(cfg interface{})) {
+	if s.viper.ConfigFileUsed() == "" {
+		return
+	}
+
+	s.viper.OnConfigChange(func(fsnotify.Event) {
+		reloaded := reflect.New(s.current.Elem().Type())
+
+		if err := s.decode(reloaded); err != nil {
+			logger.Errorw("Ignoring invalid config change", zap.Error(err))
+			return
+		}
+
+		for _, field := range fields(reloaded.Elem().Type()) {
+			changed := !reflect.DeepEqual(reloaded.Elem().FieldByIndex(field.Index).Interface(), s.current.Elem().FieldByIndex(field.Index).Interface())
+
+			if changed && field.Tag.Get("reload") != "true" {
+				logger.Warnw("Config setting changed, restart the service to apply it", zap.String("key", field.Tag.Get("config")))
+			}
+		}
+
+		s.current = reloaded
+		logger.Infow("Reloaded config", zap.Any("config", Redacted(reloaded.Interface())))
+
+		onChange(reloaded.Interface())
+	})
+
+	s.viper.WatchConfig()
+}
+
+// Redacted returns the settings of cfg by key, with the value of every
+// secret setting hidden, so the config can be logged.
+func Redacted(cfg interface{}) map[string]string {
+	value := reflect.Indirect(reflect.ValueOf(cfg))
+	settings := map[string]string{}
+
+	for _, field := range fields(value.Type()) {
+		setting := value.FieldByIndex(field.Index)
+		formatted := fmt.Sprint(setting.Interface())
+
+		if setting.Kind() == reflect.Slice {
+			formatted = strings.Join(setting.Interface().([]string), ",")
+		}
+
+		if field.Tag.Get("secret") == "true" && formatted != "" {
+			formatted = "[redacted]"
+		}
+
+		settings[field.Tag.Get("config")] = formatted
+	}
+
+	return settings
+}
+
+func (s *Source) decode(cfg reflect.Value) error {
+	var errs []error
+
+	for _, field := range fields(cfg.Elem().Type()) {
+		key := field.Tag.Get("config")
+		raw := strings.TrimSpace(s.viper.GetString(key))
+
+		if raw == "" && field.Tag.Get("required") == "true" {
+			errs = append(errs, fmt.Errorf("%s is required", key))
+			continue
+		}
+
+		if err := set(cfg.Elem().FieldByIndex(field.Index), raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if validator, ok := cfg.Interface().(Validator); ok {
+		return validator.Validate()
+	}
+
+	return nil
+}
+
+// set parses raw into a setting. Empty values leave the zero value.
+func set(setting reflect.Value, raw string) error {
+	if raw == "" {
+		setting.Set(reflect.Zero(setting.Type()))
+		return nil
+	}
+
+	switch {
+	case setting.Type() == reflect.TypeOf(time.Duration(0)):
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		setting.SetInt(int64(duration))
+	case setting.Kind() == reflect.String:
+		setting.SetString(raw)
+	case setting.Kind() == reflect.Int:
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		setting.SetInt(int64(number))
+	case setting.Kind() == reflect.Bool:
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		setting.SetBool(enabled)
+	case setting.Type() == reflect.TypeOf([]string(nil)):
+		values := []string{}
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		setting.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported type %s", setting.Type())
+	}
+
+	return nil
+}
+
+// fields returns the fields of a config struct that have a config tag.
+func fields(config reflect.Type) []reflect.StructField {
+	var tagged []reflect.StructField
+
+	for i := 0; i < config.NumField(); i++ {
+		if field := config.Field(i); field.Tag.Get("config") != "" {
+			tagged = append(tagged, field)
+		}
+	}
+
+	return tagged
+}
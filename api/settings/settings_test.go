@@ -0,0 +1,167 @@
+package settings_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"url-shortner-api/settings"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type testConfig struct {
+	Addr     string        `config:"TEST_ADDR" default:"localhost:6379"`
+	Password string        `config:"TEST_PASSWORD" secret:"true"`
+	Db       int           `config:"TEST_DB" default:"0"`
+	Brokers  []string      `config:"TEST_BROKERS"`
+	Debug    bool          `config:"TEST_DEBUG"`
+	Timeout  time.Duration `config:"TEST_TIMEOUT" default:"10s" reload:"true"`
+	Uri      string        `config:"TEST_URI" required:"true"`
+	internal string
+}
+
+func (c *testConfig) Validate() error {
+	if c.Db > 15 {
+		return errors.New("TEST_DB must be between 0 and 15")
+	}
+
+	return nil
+}
+
+// inConfigDir runs the test in a directory whose config/app.env holds file.
+func inConfigDir(t *testing.T, file string) string {
+	dir := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "config"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "config", "app.env"), []byte(file), 0o644))
+
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	return filepath.Join(dir, "config", "app.env")
+}
+
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		file           string
+		env            map[string]string
+		args           []string
+		ExpectedConfig testConfig
+		ExpectedError  string
+	}{
+		"Defaults": {
+			file:           "TEST_URI=mongodb://localhost\n",
+			ExpectedConfig: testConfig{Addr: "localhost:6379", Timeout: 10 * time.Second, Uri: "mongodb://localhost"},
+		},
+		"File": {
+			file:           "TEST_URI=mongodb://localhost\nTEST_DB=3\nTEST_BROKERS=a:9092, b:9092\nTEST_DEBUG=true\nTEST_TIMEOUT=1m\n",
+			ExpectedConfig: testConfig{Addr: "localhost:6379", Db: 3, Brokers: []string{"a:9092", "b:9092"}, Debug: true, Timeout: time.Minute, Uri: "mongodb://localhost"},
+		},
+		"Environment Over File": {
+			file:           "TEST_URI=mongodb://localhost\nTEST_ADDR=file:6379\n",
+			env:            map[string]string{"TEST_ADDR": "env:6379"},
+			ExpectedConfig: testConfig{Addr: "env:6379", Timeout: 10 * time.Second, Uri: "mongodb://localhost"},
+		},
+		"Flags Over Environment": {
+			file:           "TEST_URI=mongodb://localhost\n",
+			env:            map[string]string{"TEST_ADDR": "env:6379"},
+			args:           []string{"--test-addr", "flag:6379"},
+			ExpectedConfig: testConfig{Addr: "flag:6379", Timeout: 10 * time.Second, Uri: "mongodb://localhost"},
+		},
+		"Missing Required": {
+			file:          "TEST_ADDR=localhost:6379\n",
+			ExpectedError: "TEST_URI is required",
+		},
+		"Invalid Values": {
+			file:          "TEST_URI=mongodb://localhost\nTEST_DB=zero\nTEST_TIMEOUT=soon\n",
+			ExpectedError: "TEST_DB: invalid number \"zero\"\nTEST_TIMEOUT: time: invalid duration \"soon\"",
+		},
+		"Validation": {
+			file:          "TEST_URI=mongodb://localhost\nTEST_DB=16\n",
+			ExpectedError: "TEST_DB must be between 0 and 15",
+		},
+		"Unknown Flag": {
+			file:          "TEST_URI=mongodb://localhost\n",
+			args:          []string{"--test-port", "8080"},
+			ExpectedError: "unknown flag: --test-port",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			inConfigDir(t, test.file)
+
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+
+			config := testConfig{}
+			_, err := settings.Load(&config, test.args)
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.ExpectedConfig, config)
+		})
+	}
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	t.Setenv("TEST_URI", "mongodb://localhost")
+
+	config := testConfig{}
+	_, err = settings.Load(&config, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "mongodb://localhost", config.Uri)
+}
+
+func TestRedacted(t *testing.T) {
+	config := testConfig{Addr: "localhost:6379", Password: "12345678", Brokers: []string{"a:9092", "b:9092"}, Timeout: time.Second}
+
+	assert.Equal(t, map[string]string{
+		"TEST_ADDR":     "localhost:6379",
+		"TEST_PASSWORD": "[redacted]",
+		"TEST_DB":       "0",
+		"TEST_BROKERS":  "a:9092,b:9092",
+		"TEST_DEBUG":    "false",
+		"TEST_TIMEOUT":  "1s",
+		"TEST_URI":      "",
+	}, settings.Redacted(&config))
+}
+
+func TestWatch(t *testing.T) {
+	file := inConfigDir(t, "TEST_URI=mongodb://localhost\n")
+
+	config := testConfig{}
+	source, err := settings.Load(&config, nil)
+	assert.Nil(t, err)
+
+	reloaded := make(chan *testConfig, 1)
+	source.Watch(zap.NewNop().Sugar(), func(cfg interface{}) {
+		reloaded <- cfg.(*testConfig)
+	})
+
+	assert.Nil(t, os.WriteFile(file, []byte("TEST_URI=mongodb://localhost\nTEST_TIMEOUT=1s\n"), 0o644))
+
+	select {
+	case cfg := <-reloaded:
+		assert.Equal(t, time.Second, cfg.Timeout)
+		assert.Equal(t, 10*time.Second, config.Timeout, "the loaded config is not changed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("config was not reloaded")
+	}
+}
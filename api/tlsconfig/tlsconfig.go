@@ -0,0 +1,259 @@
+// Package tlsconfig builds the TLS configs of the services from PEM files.
+// Every service presents the same certificate as a server and as a client of
+// the other services, and trusts the internal CA to verify its peers. The
+// certificate is reloaded when its files change, so it can be rotated without
+// a restart.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Files are the TLS settings of a service. TLS is off without a certificate.
+type Files struct {
+	CertFile string
+	KeyFile  string
+	// CAFile is the CA of the internal services. Clients verify the servers
+	// with it, and servers that verify clients require a certificate it
+	// signed.
+	CAFile string
+}
+
+func (files Files) Validate() error {
+	if (files.CertFile == "") != (files.KeyFile == "") {
+		return errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+
+	if files.CAFile != "" && files.CertFile == "" {
+		return errors.New("TLS_CA_FILE needs TLS_CERT_FILE and TLS_KEY_FILE")
+	}
+
+	return nil
+}
+
+// Config holds the server and client TLS configs of a service, which are nil
+// when TLS is off.
+type Config struct {
+	Server        *tls.Config
+	Client        *tls.Config
+	pair          *KeyPair
+	verifyClients bool
+}
+
+// Load reads the certificate and the CA of files. With verifyClients, the
+// server requires the clients to present a certificate signed by the CA: the
+// gRPC server during the handshake, and the HTTP server on the handlers
+// wrapped with RequireClientCert, so probes and Prometheus can still reach
+// the others without one.
+func Load(files Files, verifyClients bool, logger *zap.SugaredLogger) (*Config, error) {
+	if files.CertFile == "" {
+		return &Config{}, nil
+	}
+
+	var roots *x509.CertPool
+
+	if files.CAFile != "" {
+		pem, err := os.ReadFile(files.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", files.CAFile)
+		}
+	}
+
+	pair, err := NewKeyPair(files.CertFile, files.KeyFile, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{
+		Server: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: pair.GetCertificate,
+		},
+		Client: &tls.Config{
+			MinVersion:           tls.VersionTLS12,
+			RootCAs:              roots,
+			GetClientCertificate: pair.GetClientCertificate,
+		},
+		pair: pair,
+	}
+
+	if verifyClients && roots != nil {
+		config.Server.ClientAuth = tls.VerifyClientCertIfGiven
+		config.Server.ClientCAs = roots
+		config.verifyClients = true
+	}
+
+	return config, nil
+}
+
+// ListenAndServe serves server over HTTPS, or HTTP when TLS is off.
+func (c *Config) ListenAndServe(server *http.Server) error {
+	if c.Server == nil {
+		return server.ListenAndServe()
+	}
+
+	server.TLSConfig = c.Server
+	return server.ListenAndServeTLS("", "")
+}
+
+// ServerOption sets the transport credentials of a gRPC server.
+func (c *Config) ServerOption() grpc.ServerOption {
+	if c.Server == nil {
+		return grpc.EmptyServerOption{}
+	}
+
+	server := c.Server
+
+	if c.verifyClients {
+		server = server.Clone()
+		server.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return grpc.Creds(credentials.NewTLS(server))
+}
+
+// RequireClientCert rejects the requests of clients that did not present a
+// certificate signed by the CA, when the server verifies its clients.
+func (c *Config) RequireClientCert(h http.Handler) http.Handler {
+	if !c.verifyClients {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+			http.Error(w, "Client certificate required", http.StatusUnauthorized)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// DialOption sets the transport credentials of a gRPC client.
+func (c *Config) DialOption() grpc.DialOption {
+	if c.Client == nil {
+		return grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(c.Client))
+}
+
+// Close stops watching the certificate files.
+func (c *Config) Close() error {
+	if c.pair == nil {
+		return nil
+	}
+
+	return c.pair.Close()
+}
+
+// KeyPair is a certificate that is reloaded when its files change. A file
+// that can't be loaded, e.g. a certificate whose key is not written yet,
+// leaves the current certificate in use.
+type KeyPair struct {
+	certFile string
+	keyFile  string
+	logger   *zap.SugaredLogger
+	watcher  *fsnotify.Watcher
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+}
+
+func NewKeyPair(certFile, keyFile string, logger *zap.SugaredLogger) (*KeyPair, error) {
+	pair := &KeyPair{certFile: certFile, keyFile: keyFile, logger: logger}
+
+	if err := pair.load(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// The directories are watched rather than the files, since rotated
+	// files are usually swapped in by a rename, e.g. by Kubernetes.
+	for _, dir := range []string{filepath.Dir(certFile), filepath.Dir(keyFile)} {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+
+	pair.watcher = watcher
+	go pair.watch()
+
+	return pair, nil
+}
+
+func (k *KeyPair) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.certificate, nil
+}
+
+func (k *KeyPair) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return k.GetCertificate(nil)
+}
+
+func (k *KeyPair) Close() error {
+	return k.watcher.Close()
+}
+
+func (k *KeyPair) load() error {
+	certificate, err := tls.LoadX509KeyPair(k.certFile, k.keyFile)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.certificate = &certificate
+
+	return nil
+}
+
+func (k *KeyPair) watch() {
+	for {
+		select {
+		case _, ok := <-k.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if err := k.load(); err != nil {
+				k.logger.Warnw("Keeping the current certificate", zap.String("certFile", k.certFile), zap.Error(err))
+				continue
+			}
+
+			k.logger.Infow("Reloaded certificate", zap.String("certFile", k.certFile))
+		case err, ok := <-k.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			k.logger.Errorw("Error watching certificate", zap.String("certFile", k.certFile), zap.Error(err))
+		}
+	}
+}
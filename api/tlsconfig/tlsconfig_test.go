@@ -0,0 +1,231 @@
+package tlsconfig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"url-shortner-api/tlsconfig"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type authority struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+func newAuthority(t *testing.T) *authority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "url-shortener test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+
+	return &authority{certificate: certificate, key: key}
+}
+
+// issue writes a certificate for localhost signed by the authority, valid
+// for server and client auth, and returns its files.
+func (a *authority) issue(t *testing.T, dir string, serial int64) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, a.certificate, &key.PublicKey, a.key)
+	assert.Nil(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	writePem(t, certFile, "CERTIFICATE", der)
+	writePem(t, keyFile, "EC PRIVATE KEY", keyDer)
+
+	return certFile, keyFile
+}
+
+func (a *authority) write(t *testing.T, dir string) string {
+	file := filepath.Join(dir, "ca.crt")
+	writePem(t, file, "CERTIFICATE", a.certificate.Raw)
+	return file
+}
+
+func writePem(t *testing.T, file, kind string, der []byte) {
+	assert.Nil(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0o600))
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		files         tlsconfig.Files
+		ExpectedError string
+	}{
+		"Off":     {},
+		"Tls":     {files: tlsconfig.Files{CertFile: "tls.crt", KeyFile: "tls.key"}},
+		"Mutual":  {files: tlsconfig.Files{CertFile: "tls.crt", KeyFile: "tls.key", CAFile: "ca.crt"}},
+		"No Key":  {files: tlsconfig.Files{CertFile: "tls.crt"}, ExpectedError: "TLS_CERT_FILE and TLS_KEY_FILE must be set together"},
+		"CA Only": {files: tlsconfig.Files{CAFile: "ca.crt"}, ExpectedError: "TLS_CA_FILE needs TLS_CERT_FILE and TLS_KEY_FILE"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.files.Validate()
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestMutualTls(t *testing.T) {
+	ca := newAuthority(t)
+	dir := t.TempDir()
+	certFile, keyFile := ca.issue(t, dir, 2)
+	files := tlsconfig.Files{CertFile: certFile, KeyFile: keyFile, CAFile: ca.write(t, dir)}
+
+	config, err := tlsconfig.Load(files, true, zap.NewNop().Sugar())
+	assert.Nil(t, err)
+	t.Cleanup(func() { config.Close() })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/", config.RequireClientCert(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	})))
+
+	server := &http.Server{Handler: mux}
+	server.TLSConfig = config.Server
+	go server.ServeTLS(listener, "", "")
+	t.Cleanup(func() { server.Close() })
+
+	url := "https://" + listener.Addr().String()
+
+	t.Run("Client Certificate", func(t *testing.T) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: config.Client}}
+
+		resp, err := client.Get(url)
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("No Client Certificate", func(t *testing.T) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: config.Client.RootCAs}}}
+
+		resp, err := client.Get(url)
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Probe Without Client Certificate", func(t *testing.T) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: config.Client.RootCAs}}}
+
+		resp, err := client.Get(url + "/healthz")
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Untrusted Client Certificate", func(t *testing.T) {
+		other := newAuthority(t)
+		otherCert, otherKey := other.issue(t, t.TempDir(), 2)
+		pair, err := tls.LoadX509KeyPair(otherCert, otherKey)
+		assert.Nil(t, err)
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: config.Client.RootCAs, Certificates: []tls.Certificate{pair}}}}
+
+		_, err = client.Get(url + "/healthz")
+		assert.Error(t, err, "a certificate the CA did not sign fails the handshake")
+	})
+
+	t.Run("Unknown Server", func(t *testing.T) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{GetClientCertificate: config.Client.GetClientCertificate}}}
+
+		_, err := client.Get(url)
+		assert.Error(t, err, "the server certificate is not signed by a system root")
+	})
+}
+
+func TestLoadWithoutCertificate(t *testing.T) {
+	config, err := tlsconfig.Load(tlsconfig.Files{}, true, zap.NewNop().Sugar())
+
+	assert.Nil(t, err)
+	assert.Nil(t, config.Server)
+	assert.Nil(t, config.Client)
+	assert.Nil(t, config.Close())
+}
+
+func TestKeyPairReload(t *testing.T) {
+	ca := newAuthority(t)
+	dir := t.TempDir()
+	certFile, keyFile := ca.issue(t, dir, 2)
+
+	pair, err := tlsconfig.NewKeyPair(certFile, keyFile, zap.NewNop().Sugar())
+	assert.Nil(t, err)
+	t.Cleanup(func() { pair.Close() })
+
+	serial := func() int64 {
+		certificate, err := pair.GetCertificate(nil)
+		assert.Nil(t, err)
+
+		leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+		assert.Nil(t, err)
+
+		return leaf.SerialNumber.Int64()
+	}
+
+	assert.Equal(t, int64(2), serial())
+
+	ca.issue(t, dir, 3)
+
+	assert.Eventually(t, func() bool { return serial() == 3 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,62 @@
+// Package tracing sets up OpenTelemetry for the services: W3C trace context
+// propagation between them and, when configured, an OTLP exporter.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIdKey is the span attribute holding the X-request-id of a request,
+// so a trace can be found from the request id in the logs.
+const RequestIdKey = attribute.Key("request.id")
+
+// Setup installs the tracer provider of a service. Spans are sent to the
+// OTLP gRPC endpoint, e.g. http://localhost:4317; without an endpoint trace
+// context is still propagated but no spans are recorded. The returned
+// function flushes the pending spans.
+func Setup(ctx context.Context, serviceName string, endpoint string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
+
+	if err != nil {
+		return nil, err
+	}
+
+	provider := Install(serviceName, sdktrace.WithBatcher(exporter))
+
+	return provider.Shutdown, nil
+}
+
+// Install sets the global tracer provider and propagator. Tests use it with
+// an in-memory exporter.
+func Install(serviceName string, options ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	options = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	}, options...)
+
+	provider := sdktrace.NewTracerProvider(options...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider
+}
+
+// SetRequestId records the request id on the span of ctx.
+func SetRequestId(ctx context.Context, requestId string) {
+	trace.SpanFromContext(ctx).SetAttributes(RequestIdKey.String(requestId))
+}
@@ -0,0 +1,47 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"url-shortner-api/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+)
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), "test", "")
+
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	header := http.Header{"Traceparent": []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+
+	injected := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(injected))
+
+	assert.Equal(t, header.Get("Traceparent"), injected.Get("Traceparent"))
+}
+
+func TestSetRequestId(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.Install("test", sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "operation")
+	tracing.SetRequestId(ctx, "abc")
+	span.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes, tracing.RequestIdKey.String("abc"))
+	serviceName, _ := spans[0].Resource.Set().Value(semconv.ServiceNameKey)
+	assert.Equal(t, "test", serviceName.AsString())
+}
@@ -2,77 +2,132 @@ package databaseservice
 
 import (
 	"cache-server/internal/config"
+	"cache-server/internal/models"
+	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"net/http"
 
+	api "url-shortner-api"
+	"url-shortner-api/resilience"
+
 	"go.uber.org/zap"
 )
 
+// maxListPage is the largest page of links the database service returns.
+const maxListPage = 100
+
 type DatabaseServiceInterface interface {
-	HandleRedirect(body io.Reader, requestId string) (string, error)
+	HandleRedirect(ctx context.Context, body io.Reader, requestId string) (string, error)
+	HandleTopLinks(ctx context.Context, limit, days int, requestId string) ([]models.LinkModel, error)
+	HandleRecentLinks(ctx context.Context, limit int, requestId string) ([]models.LinkModel, error)
 }
 
 type databaseService struct {
-	config config.ConfigInterface
+	client *api.ClientWithResponses
 	logger *zap.SugaredLogger
 }
 
-func NewDatabaseService(config config.ConfigInterface, logger *zap.SugaredLogger) *databaseService {
-	return &databaseService{
-		config: config,
-		logger: logger,
-	}
-}
-
-func (d *databaseService) HandleRedirect(body io.Reader, requestId string) (string, error) {
-	reqUrl := d.config.Get("DATABASE_SERVICE_BASE_URL") + "/redirect"
+func NewDatabaseService(config *config.Config, clientTLS *tls.Config, logger *zap.SugaredLogger) (*databaseService, error) {
+	resilienceConfig := resilience.DefaultConfig("database-service")
+	resilienceConfig.TLS = clientTLS
 
-	d.logger.Infow("Sending request to database service", zap.String("Request Id", requestId), zap.String("url", reqUrl))
-
-	req, err := http.NewRequest(http.MethodPost, reqUrl, body)
+	client, err := api.NewClientWithResponses(config.DatabaseServiceBaseUrl, api.WithHTTPClient(resilience.NewClient(resilienceConfig)))
 
 	if err != nil {
-		d.logger.Errorw("Error creating request at database service", zap.String("Request Id", requestId), zap.Error(err))
-		return "", err
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-request-id", requestId)
+	return &databaseService{
+		client: client,
+		logger: logger,
+	}, nil
+}
+
+// HandleRedirect returns the raw redirect response of the database service,
+// which is cached as is.
+func (d *databaseService) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (string, error) {
+	d.logger.Infow("Sending redirect request to database service", zap.String("Request Id", requestId))
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := d.client.LookupRedirectWithBodyWithResponse(resilience.Idempotent(ctx), "application/json", body, api.WithRequestId(requestId))
 
 	if err != nil {
 		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
 		return "", err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode), zap.String("status", resp.Status))
-		return "", errors.New(http.StatusText(http.StatusNotFound))
+	if resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusGone {
+		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode()), zap.String("status", resp.Status()))
+		return "", errors.New(http.StatusText(resp.StatusCode()))
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode), zap.String("status", resp.Status))
+	if resp.StatusCode() != http.StatusOK {
+		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode()), zap.String("status", resp.Status()))
 		return "", errors.New("request failed at database service")
 	}
 
-	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("status", resp.Status))
-
-	if resp.Body == nil {
+	if len(resp.Body) == 0 {
 		d.logger.Errorw("Empty response body from database service", zap.String("Request Id", requestId))
 		return "", errors.New("empty response body")
 	}
 
-	httpBody, err := io.ReadAll(resp.Body)
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Any("response", string(resp.Body)))
+
+	return string(resp.Body), nil
+}
+
+// HandleTopLinks returns up to limit links, most clicked over the last days
+// first.
+func (d *databaseService) HandleTopLinks(ctx context.Context, limit, days int, requestId string) ([]models.LinkModel, error) {
+	d.logger.Infow("Sending top links request to database service", zap.String("Request Id", requestId), zap.Int("limit", limit), zap.Int("days", days))
+
+	resp, err := d.client.QueryTopLinksWithResponse(resilience.Idempotent(ctx), api.TopLinksRequestModel{Limit: limit, Days: days}, api.WithRequestId(requestId))
 
 	if err != nil {
-		d.logger.Errorw("Error reading response body at database service", zap.String("Request Id", requestId), zap.Error(err))
-		return "", err
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode()))
+		return nil, errors.New("request failed at database service")
 	}
 
-	d.logger.Infow("Successfully read response body at database service", zap.String("Request Id", requestId), zap.Any("response", string(httpBody)))
+	return resp.JSON200.Links, nil
+}
+
+// HandleRecentLinks returns up to limit links, most recently created first,
+// reading as many pages of the link list as it takes.
+func (d *databaseService) HandleRecentLinks(ctx context.Context, limit int, requestId string) ([]models.LinkModel, error) {
+	d.logger.Infow("Sending recent links requests to database service", zap.String("Request Id", requestId), zap.Int("limit", limit))
+
+	links := []models.LinkModel{}
+	request := api.ListRequestModel{Sort: "-created_at"}
+
+	for len(links) < limit {
+		request.Limit = min(limit-len(links), maxListPage)
+
+		resp, err := d.client.QueryLinksWithResponse(resilience.Idempotent(ctx), request, api.WithRequestId(requestId))
+
+		if err != nil {
+			d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
+			return nil, err
+		}
+
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode()))
+			return nil, errors.New("request failed at database service")
+		}
+
+		links = append(links, resp.JSON200.Links...)
+
+		if resp.JSON200.NextCursor == "" {
+			break
+		}
+
+		request.Cursor = resp.JSON200.NextCursor
+	}
 
-	return string(httpBody), nil
+	return links, nil
 }
@@ -0,0 +1,118 @@
+package databaseservice_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	databaseservice "cache-server/external/database-service"
+	"cache-server/internal/config"
+
+	api "url-shortner-api"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newDatabaseService(t *testing.T, handler http.HandlerFunc) databaseservice.DatabaseServiceInterface {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	dbService, err := databaseservice.NewDatabaseService(&config.Config{DatabaseServiceBaseUrl: server.URL}, nil, zap.NewNop().Sugar())
+	assert.NoError(t, err)
+
+	return dbService
+}
+
+func TestHandleRecentLinks(t *testing.T) {
+	var requests []api.ListRequestModel
+
+	dbService := newDatabaseService(t, func(w http.ResponseWriter, r *http.Request) {
+		request := api.ListRequestModel{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request)
+
+		// Serves 250 links, newest first, in pages of the requested size.
+		offset := 0
+		if request.Cursor != "" {
+			offset, _ = strconv.Atoi(request.Cursor)
+		}
+
+		response := api.ListResponseModel{}
+		for i := offset; i < min(offset+request.Limit, 250); i++ {
+			response.Links = append(response.Links, api.LinkModel{ShortUrlPath: strconv.Itoa(i)})
+		}
+		if offset+request.Limit < 250 {
+			response.NextCursor = strconv.Itoa(offset + request.Limit)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	tests := map[string]struct {
+		limit            int
+		ExpectedLinks    int
+		ExpectedRequests int
+	}{
+		"One Page":      {limit: 20, ExpectedLinks: 20, ExpectedRequests: 1},
+		"Several Pages": {limit: 150, ExpectedLinks: 150, ExpectedRequests: 2},
+		"All Links":     {limit: 1000, ExpectedLinks: 250, ExpectedRequests: 3},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests = nil
+
+			links, err := dbService.HandleRecentLinks(context.Background(), test.limit, "abc")
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedLinks, len(links))
+			assert.Equal(t, test.ExpectedRequests, len(requests))
+			assert.Equal(t, "-created_at", requests[0].Sort)
+		})
+	}
+}
+
+func TestHandleTopLinks(t *testing.T) {
+	tests := map[string]struct {
+		status        int
+		ExpectedError string
+	}{
+		"Success":                {status: http.StatusOK},
+		"Database Service Error": {status: http.StatusInternalServerError, ExpectedError: "request failed at database service"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dbService := newDatabaseService(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/links/top", r.URL.Path)
+
+				request := api.TopLinksRequestModel{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				assert.Equal(t, api.TopLinksRequestModel{Limit: 2, Days: 7}, request)
+
+				if test.status != http.StatusOK {
+					http.Error(w, "boom", test.status)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"links":[{"shorturlpath":"abc1234","url":"https://google.com"}]}`))
+			})
+
+			links, err := dbService.HandleTopLinks(context.Background(), 2, 7, "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, []api.LinkModel{{ShortUrlPath: "abc1234", Url: "https://google.com"}}, links)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package databaseservice
+
+import (
+	"cache-server/internal/config"
+	"cache-server/internal/models"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"url-shortner-api/pb"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcDatabaseService talks to the database service over gRPC instead of
+// JSON over HTTP. It is selected with SERVICE_TRANSPORT=grpc; the link list
+// requests used to warm the cache, which have no gRPC counterpart, still go
+// over HTTP.
+type grpcDatabaseService struct {
+	*databaseService
+	client pb.DatabaseServiceClient
+}
+
+func NewGrpcDatabaseService(config *config.Config, clientTLS *tls.Config, logger *zap.SugaredLogger, conn grpc.ClientConnInterface) (*grpcDatabaseService, error) {
+	databaseService, err := NewDatabaseService(config, clientTLS, logger)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcDatabaseService{
+		databaseService: databaseService,
+		client:          pb.NewDatabaseServiceClient(conn),
+	}, nil
+}
+
+// HandleRedirect resolves the short url path over gRPC and returns the same
+// JSON the /redirect route does, so cached values do not depend on the
+// transport.
+func (d *grpcDatabaseService) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (string, error) {
+	d.logger.Infow("Sending resolve rpc to database service", zap.String("Request Id", requestId))
+
+	request := &models.RedirectRequestModel{}
+
+	if err := json.NewDecoder(body).Decode(request); err != nil {
+		d.logger.Errorw("Error decoding redirect request", zap.String("Request Id", requestId), zap.Error(err))
+		return "", err
+	}
+
+	resp, err := d.client.Resolve(pb.WithRequestId(ctx, requestId), &pb.ResolveRequest{ShortUrlPath: request.ShortUrlPath})
+
+	if err != nil {
+		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Error(err))
+
+		if status.Code(err) == codes.NotFound {
+			return "", errors.New(http.StatusText(http.StatusNotFound))
+		}
+
+		if status.Code(err) == pb.CodeGone {
+			return "", errors.New(http.StatusText(http.StatusGone))
+		}
+
+		return "", errors.New("request failed at database service")
+	}
+
+	jsonBody, err := json.Marshal(models.RedirectResponseModel{Url: resp.GetUrl()})
+
+	if err != nil {
+		d.logger.Errorw("Error marshalling redirect response", zap.String("Request Id", requestId), zap.Error(err))
+		return "", err
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("response", string(jsonBody)))
+
+	return string(jsonBody), nil
+}
@@ -0,0 +1,115 @@
+package databaseservice_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	databaseservice "cache-server/external/database-service"
+	"cache-server/internal/config"
+
+	"url-shortner-api/pb"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeDatabaseServer struct {
+	pb.UnimplementedDatabaseServiceServer
+	requestId string
+	url       string
+	err       error
+}
+
+func (f *fakeDatabaseServer) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveResponse, error) {
+	f.requestId = pb.RequestId(ctx)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &pb.ResolveResponse{Url: f.url}, nil
+}
+
+func newConn(t *testing.T, server pb.DatabaseServiceServer) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterDatabaseServiceServer(grpcServer, server)
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestGrpcHandleRedirect(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		server        *fakeDatabaseServer
+		requestBody   string
+		ExpectedVal   string
+		ExpectedError string
+	}{
+		"Invalid Body": {
+			server:        &fakeDatabaseServer{},
+			requestBody:   "{",
+			ExpectedError: "unexpected EOF",
+		},
+		"Not Found": {
+			server:        &fakeDatabaseServer{err: status.Error(codes.NotFound, "not found")},
+			requestBody:   `{"shorturlpath":"abc1234"}`,
+			ExpectedError: http.StatusText(http.StatusNotFound),
+		},
+		"Deleted": {
+			server:        &fakeDatabaseServer{err: status.Error(pb.CodeGone, "link was deleted")},
+			requestBody:   `{"shorturlpath":"abc1234"}`,
+			ExpectedError: http.StatusText(http.StatusGone),
+		},
+		"Database Service Fail": {
+			server:        &fakeDatabaseServer{err: status.Error(codes.Internal, "boom")},
+			requestBody:   `{"shorturlpath":"abc1234"}`,
+			ExpectedError: "request failed at database service",
+		},
+		"Success": {
+			server:      &fakeDatabaseServer{url: "https://google.com"},
+			requestBody: `{"shorturlpath":"abc1234"}`,
+			ExpectedVal: `{"redirecturl":"https://google.com"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dbService, err := databaseservice.NewGrpcDatabaseService(&config.Config{DatabaseServiceBaseUrl: "http://localhost:8081"}, nil, logger, newConn(t, test.server))
+			assert.NoError(t, err)
+
+			val, err := dbService.HandleRedirect(context.Background(), bytes.NewBufferString(test.requestBody), "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedVal, val)
+			assert.Equal(t, "abc", test.server.requestId)
+		})
+	}
+}
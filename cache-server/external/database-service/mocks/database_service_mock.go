@@ -5,6 +5,8 @@
 package mock_databaseservice
 
 import (
+	models "cache-server/internal/models"
+	context "context"
 	io "io"
 	reflect "reflect"
 
@@ -34,17 +36,47 @@ func (m *MockDatabaseServiceInterface) EXPECT() *MockDatabaseServiceInterfaceMoc
 	return m.recorder
 }
 
+// HandleRecentLinks mocks base method.
+func (m *MockDatabaseServiceInterface) HandleRecentLinks(ctx context.Context, limit int, requestId string) ([]models.LinkModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRecentLinks", ctx, limit, requestId)
+	ret0, _ := ret[0].([]models.LinkModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleRecentLinks indicates an expected call of HandleRecentLinks.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleRecentLinks(ctx, limit, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRecentLinks", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleRecentLinks), ctx, limit, requestId)
+}
+
 // HandleRedirect mocks base method.
-func (m *MockDatabaseServiceInterface) HandleRedirect(body io.Reader, requestId string) (string, error) {
+func (m *MockDatabaseServiceInterface) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleRedirect", body, requestId)
+	ret := m.ctrl.Call(m, "HandleRedirect", ctx, body, requestId)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HandleRedirect indicates an expected call of HandleRedirect.
-func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleRedirect(body, requestId interface{}) *gomock.Call {
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleRedirect(ctx, body, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRedirect", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleRedirect), ctx, body, requestId)
+}
+
+// HandleTopLinks mocks base method.
+func (m *MockDatabaseServiceInterface) HandleTopLinks(ctx context.Context, limit, days int, requestId string) ([]models.LinkModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleTopLinks", ctx, limit, days, requestId)
+	ret0, _ := ret[0].([]models.LinkModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleTopLinks indicates an expected call of HandleTopLinks.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleTopLinks(ctx, limit, days, requestId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRedirect", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleRedirect), body, requestId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTopLinks", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleTopLinks), ctx, limit, days, requestId)
 }
@@ -3,42 +3,65 @@ package cache
 import (
 	"cache-server/internal/config"
 	"context"
-	"strconv"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
+// ErrNotFound is returned by every backend for a key that is not cached.
+var ErrNotFound = errors.New("key not found in cache")
+
 type CacheInterface interface {
-	GetValue(key, requestId string) (string, error)
-	SetValue(key, value, requestId string, expiryTime time.Duration) error
+	GetValue(ctx context.Context, key, requestId string) (string, error)
+	GetValueWithTTL(ctx context.Context, key, requestId string) (string, time.Duration, error)
+	SetValue(ctx context.Context, key, value, requestId string, expiryTime time.Duration) error
+	DeleteValue(ctx context.Context, key, requestId string) error
+	Flush(ctx context.Context) error
+	Ping(ctx context.Context) error
+	Close() error
 }
 
+// New returns the backend selected by CACHE_BACKEND.
+func New(config *config.Config, logger *zap.SugaredLogger) (CacheInterface, error) {
+	switch config.CacheBackend {
+	case "memory":
+		return NewMemoryCache(logger), nil
+	case "memcached":
+		return NewMemcachedCache(config, logger)
+	default:
+		return NewCache(config, logger)
+	}
+}
+
+// cache is the Redis backend, on a single server, a cluster or a server
+// found through Sentinel.
 type cache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	logger *zap.SugaredLogger
 }
 
-func NewCache(config config.ConfigInterface, logger *zap.SugaredLogger) (*cache, error) {
-	REDIS_ADDR := config.Get("REDIS_ADDR")
-	REDIS_PASSWORD := config.Get("REDIS_PASSWORD")
-	REDIS_DB, err := strconv.Atoi(config.Get("REDIS_DB"))
+func NewCache(config *config.Config, logger *zap.SugaredLogger) (*cache, error) {
+	client, err := newRedisClient(config)
 
 	if err != nil {
-		logger.Errorw("Error converting RedisDb to int", zap.Error(err))
 		return nil, err
 	}
 
 	cache := &cache{
-		client: redis.NewClient(&redis.Options{
-			Addr:     REDIS_ADDR,
-			Password: REDIS_PASSWORD,
-			DB:       REDIS_DB,
-		}),
+		client: client,
 		logger: logger,
 	}
 
+	if err := redisotel.InstrumentTracing(cache.client); err != nil {
+		logger.Errorw("Error instrumenting Redis client", zap.Error(err))
+		cache.client.Close()
+		return nil, err
+	}
+
 	_, err = cache.client.Ping(context.Background()).Result()
 
 	if err != nil {
@@ -47,22 +70,47 @@ func NewCache(config config.ConfigInterface, logger *zap.SugaredLogger) (*cache,
 		return nil, err
 	}
 
-	logger.Infow("Successfully connected to Redis")
+	logger.Infow("Successfully connected to Redis", zap.String("backend", config.CacheBackend))
 	return cache, nil
 }
 
-func (cache *cache) GetValue(key, requestId string) (string, error) {
+func newRedisClient(config *config.Config) (redis.UniversalClient, error) {
+	switch config.CacheBackend {
+	case "", "redis":
+		return redis.NewClient(&redis.Options{
+			Addr:     config.RedisAddr,
+			Password: config.RedisPassword,
+			DB:       config.RedisDb,
+		}), nil
+	case "redis-cluster":
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    config.RedisAddrs,
+			Password: config.RedisPassword,
+		}), nil
+	case "redis-sentinel":
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    config.RedisSentinelMaster,
+			SentinelAddrs: config.RedisAddrs,
+			Password:      config.RedisPassword,
+			DB:            config.RedisDb,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown redis cache backend %q", config.CacheBackend)
+	}
+}
+
+func (cache *cache) GetValue(ctx context.Context, key, requestId string) (string, error) {
 	cache.logger.Infow("Retrieve from cache", zap.String("Request Id", requestId), zap.String("key", key))
 
-	val, err := cache.client.Get(context.Background(), key).Result()
+	val, err := cache.client.Get(ctx, key).Result()
 
 	if err != nil {
 		if err == redis.Nil {
 			cache.logger.Infow("Key not found in cache", zap.String("Request Id", requestId), zap.String("key", key))
-		} else {
-			cache.logger.Errorw("Error retrieving key", zap.String("Request Id", requestId), zap.Error(err))
+			return "", ErrNotFound
 		}
 
+		cache.logger.Errorw("Error retrieving key", zap.String("Request Id", requestId), zap.Error(err))
 		return "", err
 	}
 
@@ -71,10 +119,38 @@ func (cache *cache) GetValue(key, requestId string) (string, error) {
 	return val, nil
 }
 
-func (cache *cache) SetValue(key, value, requestId string, expiryTime time.Duration) error {
+// GetValueWithTTL also returns how long the key has left before it expires.
+func (cache *cache) GetValueWithTTL(ctx context.Context, key, requestId string) (string, time.Duration, error) {
+	cache.logger.Infow("Retrieve from cache with ttl", zap.String("Request Id", requestId), zap.String("key", key))
+
+	var get *redis.StringCmd
+	var ttl *redis.DurationCmd
+
+	_, err := cache.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		ttl = pipe.PTTL(ctx, key)
+		return nil
+	})
+
+	if err != nil {
+		if err == redis.Nil {
+			cache.logger.Infow("Key not found in cache", zap.String("Request Id", requestId), zap.String("key", key))
+			return "", 0, ErrNotFound
+		}
+
+		cache.logger.Errorw("Error retrieving key", zap.String("Request Id", requestId), zap.Error(err))
+		return "", 0, err
+	}
+
+	cache.logger.Infow("Successfully retrieved value", zap.String("Request Id", requestId), zap.String("key", key), zap.String("value", get.Val()), zap.Duration("ttl", ttl.Val()))
+
+	return get.Val(), ttl.Val(), nil
+}
+
+func (cache *cache) SetValue(ctx context.Context, key, value, requestId string, expiryTime time.Duration) error {
 	cache.logger.Infow("Set value in cache", zap.String("Request Id", requestId), zap.String("key", key), zap.String("value", value), zap.Any("expiryTime", expiryTime))
 
-	err := cache.client.Set(context.Background(), key, value, expiryTime).Err()
+	err := cache.client.Set(ctx, key, value, expiryTime).Err()
 
 	if err != nil {
 		cache.logger.Errorw("Error setting value", zap.String("Request Id", requestId), zap.Error(err))
@@ -83,10 +159,40 @@ func (cache *cache) SetValue(key, value, requestId string, expiryTime time.Durat
 	return err
 }
 
-func (cache *cache) Flush() error {
-	return cache.client.FlushAll(context.Background()).Err()
+func (cache *cache) DeleteValue(ctx context.Context, key, requestId string) error {
+	cache.logger.Infow("Delete value from cache", zap.String("Request Id", requestId), zap.String("key", key))
+
+	err := cache.client.Del(ctx, key).Err()
+
+	if err != nil {
+		cache.logger.Errorw("Error deleting value", zap.String("Request Id", requestId), zap.Error(err))
+	}
+
+	return err
+}
+
+// Flush removes every key of the configured Redis database, on every
+// master of a cluster.
+func (cache *cache) Flush(ctx context.Context) error {
+	if cluster, ok := cache.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+			return master.FlushDB(ctx).Err()
+		})
+	}
+
+	return cache.client.FlushDB(ctx).Err()
+}
+
+// Ping checks that Redis can be reached.
+func (cache *cache) Ping(ctx context.Context) error {
+	return cache.client.Ping(ctx).Err()
 }
 
 func (cache *cache) Close() error {
 	return cache.client.Close()
 }
+
+// Key returns the cache key of a short url path.
+func Key(shortUrlPath string) string {
+	return "url:" + shortUrlPath
+}
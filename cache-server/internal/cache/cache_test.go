@@ -1,13 +1,13 @@
-// Make sure redis server is running
 package cache_test
 
 import (
 	"cache-server/internal/cache"
-	mock_config "cache-server/internal/config/mocks"
+	"cache-server/internal/config"
+	"context"
 	"testing"
 	"time"
 
-	"github.com/golang/mock/gomock"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 )
@@ -15,38 +15,39 @@ import (
 func TestNewCache(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
-	mockCtrl := gomock.NewController(t)
-	config := mock_config.NewMockConfigInterface(mockCtrl)
+	config := &config.Config{RedisAddr: miniredis.RunT(t).Addr()}
 
 	t.Run("Success", func(t *testing.T) {
-		config.EXPECT().Get("REDIS_ADDR").Return("localhost:6379")
-		config.EXPECT().Get("REDIS_PASSWORD").Return("")
-		config.EXPECT().Get("REDIS_DB").Return("0")
-
 		_, err := cache.NewCache(config, logger)
 
 		assert.Nil(t, err, "Error connecting to Redis")
 	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		unreachable := *config
+		unreachable.RedisAddr = server.Addr()
+		server.Close()
+
+		_, err := cache.NewCache(&unreachable, logger)
+
+		assert.NotNil(t, err)
+	})
 }
 
 func TestSetValue(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
-	mockCtrl := gomock.NewController(t)
-	config := mock_config.NewMockConfigInterface(mockCtrl)
+	config := &config.Config{RedisAddr: miniredis.RunT(t).Addr()}
 
 	t.Run("Success", func(t *testing.T) {
-		config.EXPECT().Get("REDIS_ADDR").Return("localhost:6379")
-		config.EXPECT().Get("REDIS_PASSWORD").Return("")
-		config.EXPECT().Get("REDIS_DB").Return("0")
-
 		cache, _ := cache.NewCache(config, logger)
 
-		err := cache.SetValue("key", "value", "requestId", 10)
+		err := cache.SetValue(context.Background(), "key", "value", "requestId", 10)
 
 		assert.Nil(t, err, "Error setting value in cache")
 
-		err = cache.Flush()
+		err = cache.Flush(context.Background())
 
 		assert.Nil(t, err, "Error flushing cache")
 	})
@@ -55,38 +56,29 @@ func TestSetValue(t *testing.T) {
 func TestGetValue(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
-	mockCtrl := gomock.NewController(t)
-	config := mock_config.NewMockConfigInterface(mockCtrl)
+	config := &config.Config{RedisAddr: miniredis.RunT(t).Addr()}
 
 	t.Run("Key does not exist", func(t *testing.T) {
-		config.EXPECT().Get("REDIS_ADDR").Return("localhost:6379")
-		config.EXPECT().Get("REDIS_PASSWORD").Return("")
-		config.EXPECT().Get("REDIS_DB").Return("0")
-
 		cache, _ := cache.NewCache(config, logger)
 
-		_, err := cache.GetValue("key", "requestId")
+		_, err := cache.GetValue(context.Background(), "key", "requestId")
 
 		assert.NotNil(t, err, "Error getting value from cache")
 	})
 
 	t.Run("Key exists", func(t *testing.T) {
-		config.EXPECT().Get("REDIS_ADDR").Return("localhost:6379")
-		config.EXPECT().Get("REDIS_PASSWORD").Return("")
-		config.EXPECT().Get("REDIS_DB").Return("0")
-
 		cache, _ := cache.NewCache(config, logger)
 
-		err := cache.SetValue("key", "value", "requestId", time.Second*10)
+		err := cache.SetValue(context.Background(), "key", "value", "requestId", time.Second*10)
 
 		assert.Nil(t, err, "Error setting value in cache")
 
-		val, err := cache.GetValue("key", "requestId")
+		val, err := cache.GetValue(context.Background(), "key", "requestId")
 
 		assert.Nil(t, err, "Error getting value from cache")
 		assert.Equal(t, "value", val)
 
-		err = cache.Flush()
+		err = cache.Flush(context.Background())
 
 		assert.Nil(t, err, "Error flushing cache")
 	})
@@ -0,0 +1,173 @@
+package cache_test
+
+import (
+	"cache-server/internal/cache"
+	"cache-server/internal/config"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// backend is a cache under test and a way to let time pass for it, as
+// miniredis only expires keys when told to.
+type backend struct {
+	cache cache.CacheInterface
+	wait  func(time.Duration)
+}
+
+// testConformance checks the behaviour every backend shares, so the cache
+// server works the same whichever one it runs on.
+func testConformance(t *testing.T, newBackend func(t *testing.T) backend) {
+	ctx := context.Background()
+
+	t.Run("Missing Key", func(t *testing.T) {
+		b := newBackend(t)
+
+		_, err := b.cache.GetValue(ctx, "url:missing", "abc")
+		assert.ErrorIs(t, err, cache.ErrNotFound)
+
+		_, _, err = b.cache.GetValueWithTTL(ctx, "url:missing", "abc")
+		assert.ErrorIs(t, err, cache.ErrNotFound)
+	})
+
+	t.Run("Set And Get", func(t *testing.T) {
+		b := newBackend(t)
+
+		assert.Nil(t, b.cache.SetValue(ctx, "url:abc1234", `{"url":"https://google.com"}`, "abc", time.Minute))
+
+		val, err := b.cache.GetValue(ctx, "url:abc1234", "abc")
+		assert.Nil(t, err)
+		assert.Equal(t, `{"url":"https://google.com"}`, val)
+
+		val, ttl, err := b.cache.GetValueWithTTL(ctx, "url:abc1234", "abc")
+		assert.Nil(t, err)
+		assert.Equal(t, `{"url":"https://google.com"}`, val)
+		assert.Greater(t, ttl, time.Duration(0))
+		assert.LessOrEqual(t, ttl, time.Minute)
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		b := newBackend(t)
+
+		assert.Nil(t, b.cache.SetValue(ctx, "url:abc1234", "first", "abc", time.Minute))
+		assert.Nil(t, b.cache.SetValue(ctx, "url:abc1234", "second", "abc", time.Minute))
+
+		val, err := b.cache.GetValue(ctx, "url:abc1234", "abc")
+		assert.Nil(t, err)
+		assert.Equal(t, "second", val)
+	})
+
+	t.Run("Empty Value", func(t *testing.T) {
+		b := newBackend(t)
+
+		assert.Nil(t, b.cache.SetValue(ctx, "url:abc1234", "", "abc", time.Minute))
+
+		val, err := b.cache.GetValue(ctx, "url:abc1234", "abc")
+		assert.Nil(t, err)
+		assert.Equal(t, "", val)
+	})
+
+	t.Run("No Expiry", func(t *testing.T) {
+		b := newBackend(t)
+
+		assert.Nil(t, b.cache.SetValue(ctx, "url:abc1234", "value", "abc", 0))
+
+		_, ttl, err := b.cache.GetValueWithTTL(ctx, "url:abc1234", "abc")
+		assert.Nil(t, err)
+		assert.Less(t, ttl, time.Duration(0), "a key without an expiry has a negative ttl")
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		b := newBackend(t)
+
+		assert.Nil(t, b.cache.SetValue(ctx, "url:abc1234", "value", "abc", 100*time.Millisecond))
+
+		b.wait(200 * time.Millisecond)
+
+		_, err := b.cache.GetValue(ctx, "url:abc1234", "abc")
+		assert.ErrorIs(t, err, cache.ErrNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		b := newBackend(t)
+
+		assert.Nil(t, b.cache.SetValue(ctx, "url:abc1234", "value", "abc", time.Minute))
+		assert.Nil(t, b.cache.DeleteValue(ctx, "url:abc1234", "abc"))
+
+		_, err := b.cache.GetValue(ctx, "url:abc1234", "abc")
+		assert.ErrorIs(t, err, cache.ErrNotFound)
+
+		assert.Nil(t, b.cache.DeleteValue(ctx, "url:abc1234", "abc"), "deleting a missing key is not an error")
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		b := newBackend(t)
+
+		assert.Nil(t, b.cache.SetValue(ctx, "url:abc1234", "value", "abc", time.Minute))
+		assert.Nil(t, b.cache.SetValue(ctx, "url:def5678", "value", "abc", time.Minute))
+		assert.Nil(t, b.cache.Flush(ctx))
+
+		for _, key := range []string{"url:abc1234", "url:def5678"} {
+			_, err := b.cache.GetValue(ctx, key, "abc")
+			assert.ErrorIs(t, err, cache.ErrNotFound)
+		}
+	})
+
+	t.Run("Ping", func(t *testing.T) {
+		b := newBackend(t)
+
+		assert.Nil(t, b.cache.Ping(ctx))
+	})
+}
+
+// newRedisBackend runs the backend against a miniredis server, which also
+// answers the cluster commands of a single node cluster.
+func newRedisBackend(t *testing.T, cfg *config.Config, server *miniredis.Miniredis) backend {
+	cache, err := cache.New(cfg, zap.NewNop().Sugar())
+	assert.Nil(t, err)
+	t.Cleanup(func() { cache.Close() })
+
+	return backend{cache: cache, wait: server.FastForward}
+}
+
+func TestRedisConformance(t *testing.T) {
+	testConformance(t, func(t *testing.T) backend {
+		server := miniredis.RunT(t)
+		return newRedisBackend(t, &config.Config{CacheBackend: "redis", RedisAddr: server.Addr()}, server)
+	})
+}
+
+func TestRedisClusterConformance(t *testing.T) {
+	testConformance(t, func(t *testing.T) backend {
+		server := miniredis.RunT(t)
+		return newRedisBackend(t, &config.Config{CacheBackend: "redis-cluster", RedisAddrs: []string{server.Addr()}}, server)
+	})
+}
+
+func TestRedisSentinelConformance(t *testing.T) {
+	testConformance(t, func(t *testing.T) backend {
+		server := miniredis.RunT(t)
+		sentinel := runSentinel(t, "mymaster", server.Addr())
+		return newRedisBackend(t, &config.Config{CacheBackend: "redis-sentinel", RedisAddrs: []string{sentinel}, RedisSentinelMaster: "mymaster"}, server)
+	})
+}
+
+func TestMemoryConformance(t *testing.T) {
+	testConformance(t, func(t *testing.T) backend {
+		return backend{cache: cache.NewMemoryCache(zap.NewNop().Sugar()), wait: time.Sleep}
+	})
+}
+
+func TestMemcachedConformance(t *testing.T) {
+	testConformance(t, func(t *testing.T) backend {
+		cache, err := cache.New(&config.Config{CacheBackend: "memcached", MemcachedAddrs: []string{runMemcached(t)}}, zap.NewNop().Sugar())
+		assert.Nil(t, err)
+		t.Cleanup(func() { cache.Close() })
+
+		return backend{cache: cache, wait: time.Sleep}
+	})
+}
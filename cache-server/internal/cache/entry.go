@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// EntryVersion is the version of the Entry format. Entries of another
+// version, including the bare values cached by older releases, are treated
+// as misses and looked up again.
+const EntryVersion = 1
+
+// Entry is what is cached for a short url path: the redirect response and
+// the status the database service answered with, so a path that does not
+// exist is cached as such rather than as an empty value.
+type Entry struct {
+	Value   string `json:"value,omitempty"`
+	Status  int    `json:"status"`
+	Version int    `json:"version"`
+}
+
+func NewEntry(value string, status int) Entry {
+	return Entry{Value: value, Status: status, Version: EntryVersion}
+}
+
+// Found reports whether the entry caches a redirect response rather than a
+// short url path that does not exist.
+func (entry Entry) Found() bool {
+	return entry.Status == http.StatusOK
+}
+
+func (entry Entry) Marshal() (string, error) {
+	raw, err := json.Marshal(entry)
+	return string(raw), err
+}
+
+// ParseEntry reads a cached entry, failing on values that are not entries of
+// the current version.
+func ParseEntry(raw string) (Entry, error) {
+	entry := Entry{}
+
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+		return Entry{}, err
+	}
+
+	if entry.Version != EntryVersion {
+		return Entry{}, fmt.Errorf("cache entry version %d, want %d", entry.Version, EntryVersion)
+	}
+
+	return entry, nil
+}
+
+// TTLs are how long entries are cached. Each TTL is moved by a random amount
+// of up to JitterPercent of it either way, so entries cached together, e.g.
+// after a flush, do not all expire together.
+type TTLs struct {
+	Found         time.Duration
+	NotFound      time.Duration
+	JitterPercent int
+}
+
+// Of returns the TTL of an entry.
+func (ttls TTLs) Of(entry Entry) time.Duration {
+	ttl := ttls.NotFound
+
+	if entry.Found() {
+		ttl = ttls.Found
+	}
+
+	if ttls.JitterPercent <= 0 || ttl <= 0 {
+		return ttl
+	}
+
+	jitter := float64(ttl) * float64(ttls.JitterPercent) / 100
+
+	return ttl + time.Duration((rand.Float64()*2-1)*jitter)
+}
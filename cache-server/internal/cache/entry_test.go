@@ -0,0 +1,69 @@
+package cache_test
+
+import (
+	"cache-server/internal/cache"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEntry(t *testing.T) {
+	tests := map[string]struct {
+		raw           string
+		ExpectedEntry cache.Entry
+		ExpectedError bool
+	}{
+		"Found": {
+			raw:           `{"value":"{}","status":200,"version":1}`,
+			ExpectedEntry: cache.NewEntry("{}", http.StatusOK),
+		},
+		"Not Found": {
+			raw:           `{"status":404,"version":1}`,
+			ExpectedEntry: cache.NewEntry("", http.StatusNotFound),
+		},
+		"Bare Value": {
+			raw:           `{"url":"https://google.com"}`,
+			ExpectedError: true,
+		},
+		"Empty Value": {
+			raw:           "",
+			ExpectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			entry, err := cache.ParseEntry(test.raw)
+
+			if test.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.ExpectedEntry, entry)
+
+			raw, err := entry.Marshal()
+			assert.Nil(t, err)
+			assert.JSONEq(t, test.raw, raw)
+		})
+	}
+}
+
+func TestTTLsOf(t *testing.T) {
+	ttls := cache.TTLs{Found: time.Minute, NotFound: 10 * time.Second, JitterPercent: 10}
+
+	for i := 0; i < 100; i++ {
+		found := ttls.Of(cache.NewEntry("{}", http.StatusOK))
+		assert.GreaterOrEqual(t, found, 54*time.Second)
+		assert.LessOrEqual(t, found, 66*time.Second)
+
+		notFound := ttls.Of(cache.NewEntry("", http.StatusNotFound))
+		assert.GreaterOrEqual(t, notFound, 9*time.Second)
+		assert.LessOrEqual(t, notFound, 11*time.Second)
+	}
+
+	assert.Equal(t, time.Duration(0), cache.TTLs{Found: time.Minute}.Of(cache.NewEntry("", http.StatusNotFound)), "no jitter is added to a disabled TTL")
+}
@@ -0,0 +1,136 @@
+package cache_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/stretchr/testify/assert"
+)
+
+// runSentinel runs a Sentinel that reports masterAddr as the master of
+// masterName and knows no other sentinels, and returns its address.
+func runSentinel(t *testing.T, masterName, masterAddr string) string {
+	sentinel, err := server.NewServer("127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(sentinel.Close)
+
+	host, port, err := net.SplitHostPort(masterAddr)
+	assert.Nil(t, err)
+
+	sentinel.Register("PING", func(c *server.Peer, cmd string, args []string) {
+		c.WriteInline("PONG")
+	})
+	sentinel.Register("SENTINEL", func(c *server.Peer, cmd string, args []string) {
+		switch {
+		case len(args) == 2 && strings.EqualFold(args[0], "get-master-addr-by-name") && args[1] == masterName:
+			c.WriteLen(2)
+			c.WriteBulk(host)
+			c.WriteBulk(port)
+		case len(args) == 2 && strings.EqualFold(args[0], "sentinels"):
+			c.WriteLen(0)
+		default:
+			c.WriteNull()
+		}
+	})
+
+	return sentinel.Addr().String()
+}
+
+type memcachedItem struct {
+	flags   string
+	value   []byte
+	expires time.Time
+}
+
+// runMemcached runs an in-process server speaking the part of the memcached
+// text protocol the memcached backend uses, and returns its address.
+func runMemcached(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	items := map[string]memcachedItem{}
+
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+		rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+
+		for {
+			line, err := rw.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+
+			mu.Lock()
+
+			switch fields[0] {
+			case "get", "gets":
+				for _, key := range fields[1:] {
+					item, ok := items[key]
+					if !ok || (!item.expires.IsZero() && time.Now().After(item.expires)) {
+						continue
+					}
+					fmt.Fprintf(rw, "VALUE %s %s %d 1\r\n%s\r\n", key, item.flags, len(item.value), item.value)
+				}
+				rw.WriteString("END\r\n")
+			case "set":
+				size, _ := strconv.Atoi(fields[4])
+				value := make([]byte, size+2)
+				if _, err := io.ReadFull(rw, value); err != nil {
+					mu.Unlock()
+					return
+				}
+
+				item := memcachedItem{flags: fields[2], value: value[:size]}
+				if seconds, _ := strconv.Atoi(fields[3]); seconds > 0 {
+					item.expires = time.Now().Add(time.Duration(seconds) * time.Second)
+				}
+				items[fields[1]] = item
+				rw.WriteString("STORED\r\n")
+			case "delete":
+				if _, ok := items[fields[1]]; !ok {
+					rw.WriteString("NOT_FOUND\r\n")
+					break
+				}
+				delete(items, fields[1])
+				rw.WriteString("DELETED\r\n")
+			case "flush_all":
+				items = map[string]memcachedItem{}
+				rw.WriteString("OK\r\n")
+			case "version":
+				rw.WriteString("VERSION 1.6.0\r\n")
+			default:
+				rw.WriteString("ERROR\r\n")
+			}
+
+			mu.Unlock()
+			rw.Flush()
+		}
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"cache-server/internal/config"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"go.uber.org/zap"
+)
+
+const maxMemcachedExpiry = 30 * 24 * time.Hour
+
+// memcachedCache stores keys on memcached servers, spread over them by key.
+// Memcached does not tell how long a key has left, so each value is stored
+// behind the time it expires at, in unix milliseconds, 0 for never.
+type memcachedCache struct {
+	client *memcache.Client
+	logger *zap.SugaredLogger
+}
+
+func NewMemcachedCache(config *config.Config, logger *zap.SugaredLogger) (*memcachedCache, error) {
+	cache := &memcachedCache{
+		client: memcache.New(config.MemcachedAddrs...),
+		logger: logger,
+	}
+
+	if err := cache.client.Ping(); err != nil {
+		logger.Errorw("Error connecting to memcached", zap.Error(err))
+		cache.client.Close()
+		return nil, err
+	}
+
+	logger.Infow("Successfully connected to memcached")
+	return cache, nil
+}
+
+func (cache *memcachedCache) GetValue(ctx context.Context, key, requestId string) (string, error) {
+	val, _, err := cache.GetValueWithTTL(ctx, key, requestId)
+	return val, err
+}
+
+func (cache *memcachedCache) GetValueWithTTL(ctx context.Context, key, requestId string) (string, time.Duration, error) {
+	cache.logger.Infow("Retrieve from cache with ttl", zap.String("Request Id", requestId), zap.String("key", key))
+
+	item, err := cache.client.Get(key)
+
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			cache.logger.Infow("Key not found in cache", zap.String("Request Id", requestId), zap.String("key", key))
+			return "", 0, ErrNotFound
+		}
+
+		cache.logger.Errorw("Error retrieving key", zap.String("Request Id", requestId), zap.Error(err))
+		return "", 0, err
+	}
+
+	header, val, found := strings.Cut(string(item.Value), " ")
+	expires, err := strconv.ParseInt(header, 10, 64)
+
+	if !found || err != nil {
+		cache.logger.Errorw("Error parsing cached value", zap.String("Request Id", requestId), zap.String("key", key))
+		return "", 0, fmt.Errorf("malformed value of key %q", key)
+	}
+
+	if expires == 0 {
+		return val, -1, nil
+	}
+
+	ttl := time.Until(time.UnixMilli(expires))
+
+	if ttl <= 0 {
+		cache.logger.Infow("Key not found in cache", zap.String("Request Id", requestId), zap.String("key", key))
+		return "", 0, ErrNotFound
+	}
+
+	return val, ttl, nil
+}
+
+// SetValue stores a key until expiryTime has passed, or for good when it is
+// 0, as Redis does. Memcached expires keys in whole seconds, so a key may
+// outlive expiryTime there by up to a second, but is not read after it.
+func (cache *memcachedCache) SetValue(ctx context.Context, key, value, requestId string, expiryTime time.Duration) error {
+	cache.logger.Infow("Set value in cache", zap.String("Request Id", requestId), zap.String("key", key), zap.String("value", value), zap.Any("expiryTime", expiryTime))
+
+	item := &memcache.Item{Key: key, Value: []byte("0 " + value)}
+
+	if expiryTime > 0 {
+		item.Value = []byte(strconv.FormatInt(time.Now().Add(expiryTime).UnixMilli(), 10) + " " + value)
+		item.Expiration = int32(math.Ceil(expiryTime.Seconds()))
+
+		// Memcached reads expirations over 30 days as a unix time.
+		if expiryTime > maxMemcachedExpiry {
+			item.Expiration = int32(time.Now().Add(expiryTime).Unix() + 1)
+		}
+	}
+
+	err := cache.client.Set(item)
+
+	if err != nil {
+		cache.logger.Errorw("Error setting value", zap.String("Request Id", requestId), zap.Error(err))
+	}
+
+	return err
+}
+
+func (cache *memcachedCache) DeleteValue(ctx context.Context, key, requestId string) error {
+	cache.logger.Infow("Delete value from cache", zap.String("Request Id", requestId), zap.String("key", key))
+
+	err := cache.client.Delete(key)
+
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		cache.logger.Errorw("Error deleting value", zap.String("Request Id", requestId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Flush removes every key of every memcached server.
+func (cache *memcachedCache) Flush(ctx context.Context) error {
+	return cache.client.FlushAll()
+}
+
+func (cache *memcachedCache) Ping(ctx context.Context) error {
+	return cache.client.Ping()
+}
+
+func (cache *memcachedCache) Close() error {
+	return cache.client.Close()
+}
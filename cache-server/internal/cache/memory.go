@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type memoryEntry struct {
+	value string
+	// expires is zero for a key without an expiry.
+	expires time.Time
+}
+
+// memoryCache keeps keys in the memory of the process, for local development
+// and tests. It is not shared between instances and has no size bound;
+// expired keys are dropped when they are next read or on a flush.
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	logger  *zap.SugaredLogger
+}
+
+func NewMemoryCache(logger *zap.SugaredLogger) *memoryCache {
+	return &memoryCache{
+		entries: map[string]memoryEntry{},
+		logger:  logger,
+	}
+}
+
+func (cache *memoryCache) GetValue(ctx context.Context, key, requestId string) (string, error) {
+	val, _, err := cache.GetValueWithTTL(ctx, key, requestId)
+	return val, err
+}
+
+func (cache *memoryCache) GetValueWithTTL(ctx context.Context, key, requestId string) (string, time.Duration, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, ok := cache.entries[key]
+
+	if !ok {
+		cache.logger.Infow("Key not found in cache", zap.String("Request Id", requestId), zap.String("key", key))
+		return "", 0, ErrNotFound
+	}
+
+	if entry.expires.IsZero() {
+		return entry.value, -1, nil
+	}
+
+	ttl := time.Until(entry.expires)
+
+	if ttl <= 0 {
+		delete(cache.entries, key)
+		cache.logger.Infow("Key not found in cache", zap.String("Request Id", requestId), zap.String("key", key))
+		return "", 0, ErrNotFound
+	}
+
+	return entry.value, ttl, nil
+}
+
+// SetValue stores a key until expiryTime has passed, or for good when it is
+// 0, as Redis does.
+func (cache *memoryCache) SetValue(ctx context.Context, key, value, requestId string, expiryTime time.Duration) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry := memoryEntry{value: value}
+
+	if expiryTime > 0 {
+		entry.expires = time.Now().Add(expiryTime)
+	}
+
+	cache.entries[key] = entry
+
+	return nil
+}
+
+func (cache *memoryCache) DeleteValue(ctx context.Context, key, requestId string) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	delete(cache.entries, key)
+
+	return nil
+}
+
+func (cache *memoryCache) Flush(ctx context.Context) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries = map[string]memoryEntry{}
+
+	return nil
+}
+
+func (cache *memoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (cache *memoryCache) Close() error {
+	return nil
+}
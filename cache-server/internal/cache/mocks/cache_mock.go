@@ -1,10 +1,11 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: D:\Programming\Go\url-shortner\cache-server\internal\cache\cache.go
+// Source: internal/cache/cache.go
 
 // Package mock_cache is a generated GoMock package.
 package mock_cache
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -34,31 +35,103 @@ func (m *MockCacheInterface) EXPECT() *MockCacheInterfaceMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockCacheInterface) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockCacheInterfaceMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCacheInterface)(nil).Close))
+}
+
+// DeleteValue mocks base method.
+func (m *MockCacheInterface) DeleteValue(ctx context.Context, key, requestId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteValue", ctx, key, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteValue indicates an expected call of DeleteValue.
+func (mr *MockCacheInterfaceMockRecorder) DeleteValue(ctx, key, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValue", reflect.TypeOf((*MockCacheInterface)(nil).DeleteValue), ctx, key, requestId)
+}
+
+// Flush mocks base method.
+func (m *MockCacheInterface) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockCacheInterfaceMockRecorder) Flush(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockCacheInterface)(nil).Flush), ctx)
+}
+
 // GetValue mocks base method.
-func (m *MockCacheInterface) GetValue(key, requestId string) (string, error) {
+func (m *MockCacheInterface) GetValue(ctx context.Context, key, requestId string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetValue", key, requestId)
+	ret := m.ctrl.Call(m, "GetValue", ctx, key, requestId)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetValue indicates an expected call of GetValue.
-func (mr *MockCacheInterfaceMockRecorder) GetValue(key, requestId interface{}) *gomock.Call {
+func (mr *MockCacheInterfaceMockRecorder) GetValue(ctx, key, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValue", reflect.TypeOf((*MockCacheInterface)(nil).GetValue), ctx, key, requestId)
+}
+
+// GetValueWithTTL mocks base method.
+func (m *MockCacheInterface) GetValueWithTTL(ctx context.Context, key, requestId string) (string, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValueWithTTL", ctx, key, requestId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetValueWithTTL indicates an expected call of GetValueWithTTL.
+func (mr *MockCacheInterfaceMockRecorder) GetValueWithTTL(ctx, key, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValueWithTTL", reflect.TypeOf((*MockCacheInterface)(nil).GetValueWithTTL), ctx, key, requestId)
+}
+
+// Ping mocks base method.
+func (m *MockCacheInterface) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockCacheInterfaceMockRecorder) Ping(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValue", reflect.TypeOf((*MockCacheInterface)(nil).GetValue), key, requestId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockCacheInterface)(nil).Ping), ctx)
 }
 
 // SetValue mocks base method.
-func (m *MockCacheInterface) SetValue(key, value, requestId string, expiryTime time.Duration) error {
+func (m *MockCacheInterface) SetValue(ctx context.Context, key, value, requestId string, expiryTime time.Duration) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetValue", key, value, requestId, expiryTime)
+	ret := m.ctrl.Call(m, "SetValue", ctx, key, value, requestId, expiryTime)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetValue indicates an expected call of SetValue.
-func (mr *MockCacheInterfaceMockRecorder) SetValue(key, value, requestId, expiryTime interface{}) *gomock.Call {
+func (mr *MockCacheInterfaceMockRecorder) SetValue(ctx, key, value, requestId, expiryTime interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValue", reflect.TypeOf((*MockCacheInterface)(nil).SetValue), key, value, requestId, expiryTime)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValue", reflect.TypeOf((*MockCacheInterface)(nil).SetValue), ctx, key, value, requestId, expiryTime)
 }
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"url-shortner-api/settings"
+	"url-shortner-api/tlsconfig"
+)
+
+// Config is the configuration of the cache service, read from config/app.env,
+// the environment and command line flags.
+type Config struct {
+	ListenAddr              string        `config:"LISTEN_ADDR" default:":8082"`
+	GrpcListenAddr          string        `config:"GRPC_LISTEN_ADDR" default:":9082"`
+	TlsCertFile             string        `config:"TLS_CERT_FILE"`
+	TlsKeyFile              string        `config:"TLS_KEY_FILE"`
+	TlsCaFile               string        `config:"TLS_CA_FILE"`
+	DatabaseServiceBaseUrl  string        `config:"DATABASE_SERVICE_BASE_URL" default:"http://localhost:8081"`
+	CacheBackend            string        `config:"CACHE_BACKEND" default:"redis"`
+	RedisAddr               string        `config:"REDIS_ADDR" default:"localhost:6379"`
+	RedisAddrs              []string      `config:"REDIS_ADDRS"`
+	RedisSentinelMaster     string        `config:"REDIS_SENTINEL_MASTER" default:"mymaster"`
+	RedisPassword           string        `config:"REDIS_PASSWORD" secret:"true"`
+	RedisDb                 int           `config:"REDIS_DB" default:"0"`
+	MemcachedAddrs          []string      `config:"MEMCACHED_ADDRS" default:"localhost:11211"`
+	CacheTTL                time.Duration `config:"CACHE_TTL" default:"3m"`
+	CacheNegativeTTL        time.Duration `config:"CACHE_NEGATIVE_TTL" default:"10s"`
+	CacheTTLJitterPercent   int           `config:"CACHE_TTL_JITTER_PERCENT" default:"10"`
+	EarlyRefreshWindow      time.Duration `config:"EARLY_REFRESH_WINDOW" default:"30s"`
+	CacheWarmOnStart        bool          `config:"CACHE_WARM_ON_START" default:"false"`
+	CacheWarmSource         string        `config:"CACHE_WARM_SOURCE" default:"top"`
+	CacheWarmLimit          int           `config:"CACHE_WARM_LIMIT" default:"1000"`
+	CacheWarmDays           int           `config:"CACHE_WARM_DAYS" default:"7"`
+	CacheWarmConcurrency    int           `config:"CACHE_WARM_CONCURRENCY" default:"8"`
+	CacheWarmRate           int           `config:"CACHE_WARM_RATE" default:"100"`
+	KafkaBrokers            []string      `config:"KAFKA_SERVICE_BASE_URL" default:"localhost:29092"`
+	ServiceTransport        string        `config:"SERVICE_TRANSPORT" default:"http"`
+	DatabaseServiceGrpcAddr string        `config:"DATABASE_SERVICE_GRPC_ADDR" default:"localhost:9081"`
+	RequestTimeout          time.Duration `config:"REQUEST_TIMEOUT" default:"10s" reload:"true"`
+	RouteTimeouts           string        `config:"ROUTE_TIMEOUTS" reload:"true"`
+	OtlpEndpoint            string        `config:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+}
+
+// Load reads and validates the config. args are the command line arguments
+// without the program name.
+func Load(args []string) (*Config, *settings.Source, error) {
+	config := &Config{}
+	source, err := settings.Load(config, args)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, source, nil
+}
+
+func (config *Config) Validate() error {
+	if parsed, err := url.Parse(config.DatabaseServiceBaseUrl); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("DATABASE_SERVICE_BASE_URL must be an absolute url, got %q", config.DatabaseServiceBaseUrl)
+	}
+
+	switch config.CacheBackend {
+	case "redis", "memory", "memcached":
+	case "redis-cluster", "redis-sentinel":
+		if len(config.RedisAddrs) == 0 {
+			return fmt.Errorf("REDIS_ADDRS is required with CACHE_BACKEND=%s", config.CacheBackend)
+		}
+	default:
+		return fmt.Errorf("CACHE_BACKEND must be redis, redis-cluster, redis-sentinel, memory or memcached, got %q", config.CacheBackend)
+	}
+
+	if config.RedisDb < 0 {
+		return fmt.Errorf("REDIS_DB must not be negative, got %d", config.RedisDb)
+	}
+
+	if config.CacheTTL <= 0 {
+		return fmt.Errorf("CACHE_TTL must be positive, got %s", config.CacheTTL)
+	}
+
+	if config.CacheNegativeTTL < 0 {
+		return fmt.Errorf("CACHE_NEGATIVE_TTL must not be negative, got %s", config.CacheNegativeTTL)
+	}
+
+	if config.CacheTTLJitterPercent < 0 || config.CacheTTLJitterPercent > 50 {
+		return fmt.Errorf("CACHE_TTL_JITTER_PERCENT must be between 0 and 50, got %d", config.CacheTTLJitterPercent)
+	}
+
+	if config.EarlyRefreshWindow < 0 {
+		return fmt.Errorf("EARLY_REFRESH_WINDOW must not be negative, got %s", config.EarlyRefreshWindow)
+	}
+
+	if config.CacheWarmSource != "top" && config.CacheWarmSource != "recent" {
+		return fmt.Errorf("CACHE_WARM_SOURCE must be top or recent, got %q", config.CacheWarmSource)
+	}
+
+	if config.CacheWarmLimit <= 0 || config.CacheWarmDays <= 0 || config.CacheWarmConcurrency <= 0 {
+		return fmt.Errorf("CACHE_WARM_LIMIT, CACHE_WARM_DAYS and CACHE_WARM_CONCURRENCY must be positive, got %d, %d and %d", config.CacheWarmLimit, config.CacheWarmDays, config.CacheWarmConcurrency)
+	}
+
+	// The warmer ticks once per link, every second / CACHE_WARM_RATE, which
+	// must not round down to zero.
+	if config.CacheWarmRate < 0 || config.CacheWarmRate > 10000 {
+		return fmt.Errorf("CACHE_WARM_RATE must be between 0 and 10000, got %d", config.CacheWarmRate)
+	}
+
+	if config.ServiceTransport != "http" && config.ServiceTransport != "grpc" {
+		return fmt.Errorf("SERVICE_TRANSPORT must be http or grpc, got %q", config.ServiceTransport)
+	}
+
+	return config.TlsFiles().Validate()
+}
+
+// TlsFiles are the certificate of the service and the CA of the other
+// services, which it serves and calls over mutual TLS.
+func (config *Config) TlsFiles() tlsconfig.Files {
+	return tlsconfig.Files{CertFile: config.TlsCertFile, KeyFile: config.TlsKeyFile, CAFile: config.TlsCaFile}
+}
@@ -5,36 +5,85 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"go.uber.org/zap"
 )
 
-func TestGet(t *testing.T) {
-	mockLogger := zap.NewNop().Sugar()
-	config, err := config.NewConfig(mockLogger)
-
-	if err != nil {
-		t.Fatalf("Error creating config: %v", err)
-	}
-
-	var tests = map[string]struct {
-		name     string
-		key      string
-		expected string
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		args          []string
+		ExpectedError string
 	}{
-		"NON_EXISTENT_KEY": {
-			key:      "ABC",
-			expected: "",
+		"Flags": {
+			args: []string{"--redis-addr", "redis:6379", "--redis-db", "2"},
+		},
+		"Redis Cluster": {
+			args: []string{"--cache-backend", "redis-cluster", "--redis-addrs", "redis-1:6379,redis-2:6379", "--redis-addr", "redis:6379", "--redis-db", "2"},
+		},
+		"Redis Cluster Without Nodes": {
+			args:          []string{"--cache-backend", "redis-cluster"},
+			ExpectedError: "REDIS_ADDRS is required with CACHE_BACKEND=redis-cluster",
+		},
+		"Invalid Cache Backend": {
+			args:          []string{"--cache-backend", "etcd"},
+			ExpectedError: `CACHE_BACKEND must be redis, redis-cluster, redis-sentinel, memory or memcached, got "etcd"`,
+		},
+		"Invalid Redis Db": {
+			args:          []string{"--redis-db", "-1"},
+			ExpectedError: "REDIS_DB must not be negative, got -1",
+		},
+		"Invalid Cache TTL": {
+			args:          []string{"--cache-ttl", "0s"},
+			ExpectedError: "CACHE_TTL must be positive, got 0s",
+		},
+		"Invalid Jitter": {
+			args:          []string{"--cache-ttl-jitter-percent", "60"},
+			ExpectedError: "CACHE_TTL_JITTER_PERCENT must be between 0 and 50, got 60",
 		},
-		"EXISTENT_KEY": {
-			key:      "DATABASE_SERVICE_BASE_URL",
-			expected: "http://localhost:8081",
+		"Negative Refresh Window": {
+			args:          []string{"--early-refresh-window", "-1s"},
+			ExpectedError: "EARLY_REFRESH_WINDOW must not be negative, got -1s",
+		},
+		"Invalid Warm Source": {
+			args:          []string{"--cache-warm-source", "oldest"},
+			ExpectedError: `CACHE_WARM_SOURCE must be top or recent, got "oldest"`,
+		},
+		"Invalid Warm Concurrency": {
+			args:          []string{"--cache-warm-concurrency", "0"},
+			ExpectedError: "CACHE_WARM_LIMIT, CACHE_WARM_DAYS and CACHE_WARM_CONCURRENCY must be positive, got 1000, 7 and 0",
+		},
+		"Negative Warm Rate": {
+			args:          []string{"--cache-warm-rate", "-1"},
+			ExpectedError: "CACHE_WARM_RATE must be between 0 and 10000, got -1",
+		},
+		"Warm Rate Too High": {
+			args:          []string{"--cache-warm-rate", "2000000000"},
+			ExpectedError: "CACHE_WARM_RATE must be between 0 and 10000, got 2000000000",
+		},
+		"Invalid Transport": {
+			args:          []string{"--service-transport", "udp"},
+			ExpectedError: `SERVICE_TRANSPORT must be http or grpc, got "udp"`,
+		},
+		"CA Without Certificate": {
+			args:          []string{"--tls-ca-file", "ca.crt"},
+			ExpectedError: "TLS_CA_FILE needs TLS_CERT_FILE and TLS_KEY_FILE",
+		},
+		"Invalid Database Url": {
+			args:          []string{"--database-service-base-url", "localhost"},
+			ExpectedError: `DATABASE_SERVICE_BASE_URL must be an absolute url, got "localhost"`,
 		},
 	}
 
-	for tc, test := range tests {
-		t.Run(tc, func(t *testing.T) {
-			actual := config.Get(test.key)
-			assert.Equal(t, test.expected, actual, "Expected and actual values do not match")
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			loaded, _, err := config.Load(test.args)
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, "redis:6379", loaded.RedisAddr)
+			assert.Equal(t, 2, loaded.RedisDb)
 		})
 	}
 }
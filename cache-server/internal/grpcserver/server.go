@@ -0,0 +1,91 @@
+package grpcserver
+
+import (
+	"cache-server/internal/cache"
+	"cache-server/internal/models"
+	"cache-server/internal/resolver"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"url-shortner-api/pb"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// server serves the gRPC counterpart of the /redirect and /cache routes.
+type server struct {
+	pb.UnimplementedCacheServiceServer
+	cache    cache.CacheInterface
+	logger   *zap.SugaredLogger
+	resolver resolver.ResolverInterface
+}
+
+func NewServer(cache cache.CacheInterface, logger *zap.SugaredLogger, resolver resolver.ResolverInterface) *server {
+	return &server{
+		cache:    cache,
+		logger:   logger,
+		resolver: resolver,
+	}
+}
+
+func (s *server) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveResponse, error) {
+	requestId := pb.RequestId(ctx)
+
+	s.logger.Infow("Handling resolve rpc", zap.String("Request Id", requestId), zap.String("shorturlpath", req.GetShortUrlPath()))
+
+	if req.GetShortUrlPath() == "" {
+		s.logger.Errorw("Empty short url path in request", zap.String("Request Id", requestId))
+		return nil, status.Error(codes.InvalidArgument, "empty short url path")
+	}
+
+	val, err := s.resolver.Resolve(ctx, req.GetShortUrlPath(), requestId)
+
+	if err != nil {
+		if err.Error() == http.StatusText(http.StatusNotFound) {
+			s.logger.Errorw("URL not found", zap.String("Request Id", requestId), zap.Error(err))
+			return nil, status.Error(codes.NotFound, "url not found")
+		}
+
+		if err.Error() == http.StatusText(http.StatusGone) {
+			s.logger.Errorw("URL was deleted", zap.String("Request Id", requestId), zap.Error(err))
+			return nil, status.Error(pb.CodeGone, "url was deleted")
+		}
+
+		s.logger.Errorw("Error processing resolve request", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "error processing resolve request")
+	}
+
+	response := &models.RedirectResponseModel{}
+
+	if err := json.Unmarshal([]byte(val), response); err != nil {
+		s.logger.Errorw("Error unmarshalling cached value", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "error processing resolve request")
+	}
+
+	s.logger.Infow("Successfully processed resolve request", zap.String("Request Id", requestId), zap.String("url", response.Url))
+
+	return &pb.ResolveResponse{Url: response.Url}, nil
+}
+
+func (s *server) Invalidate(ctx context.Context, req *pb.InvalidateRequest) (*pb.InvalidateResponse, error) {
+	requestId := pb.RequestId(ctx)
+
+	s.logger.Infow("Handling invalidate rpc", zap.String("Request Id", requestId), zap.String("shorturlpath", req.GetShortUrlPath()))
+
+	if req.GetShortUrlPath() == "" {
+		s.logger.Errorw("Empty short url path in request", zap.String("Request Id", requestId))
+		return nil, status.Error(codes.InvalidArgument, "empty short url path")
+	}
+
+	if err := s.resolver.Evict(ctx, req.GetShortUrlPath(), requestId); err != nil {
+		s.logger.Errorw("Error evicting value from cache", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "error evicting value from cache")
+	}
+
+	s.logger.Infow("Successfully evicted value from cache", zap.String("Request Id", requestId), zap.String("shorturlpath", req.GetShortUrlPath()))
+
+	return &pb.InvalidateResponse{}, nil
+}
@@ -0,0 +1,201 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	mock_databaseservice "cache-server/external/database-service/mocks"
+	"cache-server/internal/cache"
+	mock_cache "cache-server/internal/cache/mocks"
+	"cache-server/internal/grpcserver"
+	"cache-server/internal/middlewares"
+	"cache-server/internal/resolver"
+
+	"url-shortner-api/pb"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newClient(t *testing.T, server pb.CacheServiceServer) pb.CacheServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(middlewares.LoggingInterceptor))
+	pb.RegisterCacheServiceServer(grpcServer, server)
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewCacheServiceClient(conn)
+}
+
+var ttls = cache.TTLs{Found: time.Minute, NotFound: 10 * time.Second}
+
+func entry(value string, status int) string {
+	raw, _ := cache.NewEntry(value, status).Marshal()
+	return raw
+}
+
+func TestResolve(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		request                   *pb.ResolveRequest
+		GetValueReturnVal         string
+		GetValueReturnError       error
+		GetValueCallTimes         int
+		HandleRedirectReturnVal   string
+		HandleRedirectReturnError error
+		HandleRedirectCallTimes   int
+		SetValueVal               string
+		SetValueExpiry            interface{}
+		SetValueCallTimes         int
+		ExpectedCode              codes.Code
+		ExpectedUrl               string
+	}{
+		"Empty Short Url Path": {
+			request:      &pb.ResolveRequest{},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Cache Hit": {
+			request:           &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetValueReturnVal: entry(`{"redirecturl":"https://google.com"}`, http.StatusOK),
+			GetValueCallTimes: 1,
+			ExpectedCode:      codes.OK,
+			ExpectedUrl:       "https://google.com",
+		},
+		"Cached Not Found": {
+			request:           &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetValueReturnVal: entry("", http.StatusNotFound),
+			GetValueCallTimes: 1,
+			ExpectedCode:      codes.NotFound,
+		},
+		"Cached Deleted": {
+			request:           &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetValueReturnVal: entry("", http.StatusGone),
+			GetValueCallTimes: 1,
+			ExpectedCode:      pb.CodeGone,
+		},
+		"Cache Miss": {
+			request:                 &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetValueReturnError:     assert.AnError,
+			GetValueCallTimes:       1,
+			HandleRedirectReturnVal: `{"redirecturl":"https://google.com"}`,
+			HandleRedirectCallTimes: 1,
+			SetValueVal:             entry(`{"redirecturl":"https://google.com"}`, http.StatusOK),
+			SetValueExpiry:          ttls.Found,
+			SetValueCallTimes:       1,
+			ExpectedCode:            codes.OK,
+			ExpectedUrl:             "https://google.com",
+		},
+		"Not Found": {
+			request:                   &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetValueReturnError:       assert.AnError,
+			GetValueCallTimes:         1,
+			HandleRedirectReturnError: errors.New(http.StatusText(http.StatusNotFound)),
+			HandleRedirectCallTimes:   1,
+			SetValueVal:               entry("", http.StatusNotFound),
+			SetValueExpiry:            ttls.NotFound,
+			SetValueCallTimes:         1,
+			ExpectedCode:              codes.NotFound,
+		},
+		"Deleted": {
+			request:                   &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetValueReturnError:       assert.AnError,
+			GetValueCallTimes:         1,
+			HandleRedirectReturnError: errors.New(http.StatusText(http.StatusGone)),
+			HandleRedirectCallTimes:   1,
+			SetValueVal:               entry("", http.StatusGone),
+			SetValueExpiry:            ttls.NotFound,
+			SetValueCallTimes:         1,
+			ExpectedCode:              pb.CodeGone,
+		},
+		"Database Service Fail": {
+			request:                   &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetValueReturnError:       assert.AnError,
+			GetValueCallTimes:         1,
+			HandleRedirectReturnError: assert.AnError,
+			HandleRedirectCallTimes:   1,
+			ExpectedCode:              codes.Internal,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(mockCtrl)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+
+			mockCache.EXPECT().GetValueWithTTL(gomock.Any(), "url:abc1234", gomock.Any()).Return(test.GetValueReturnVal, time.Minute, test.GetValueReturnError).Times(test.GetValueCallTimes)
+			mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.HandleRedirectReturnVal, test.HandleRedirectReturnError).Times(test.HandleRedirectCallTimes)
+			mockCache.EXPECT().SetValue(gomock.Any(), "url:abc1234", test.SetValueVal, gomock.Any(), test.SetValueExpiry).Return(nil).Times(test.SetValueCallTimes)
+
+			client := newClient(t, grpcserver.NewServer(mockCache, logger, resolver.NewResolver(mockCache, mockDbService, ttls, 0, logger)))
+
+			resp, err := client.Resolve(pb.WithRequestId(context.Background(), "abc"), test.request)
+			assert.Equal(t, test.ExpectedCode, status.Code(err))
+			assert.Equal(t, test.ExpectedUrl, resp.GetUrl())
+		})
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		request                *pb.InvalidateRequest
+		DeleteValueReturnError error
+		DeleteValueCallTimes   int
+		ExpectedCode           codes.Code
+	}{
+		"Empty Short Url Path": {
+			request:      &pb.InvalidateRequest{},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Cache Fail": {
+			request:                &pb.InvalidateRequest{ShortUrlPath: "abc1234"},
+			DeleteValueReturnError: assert.AnError,
+			DeleteValueCallTimes:   1,
+			ExpectedCode:           codes.Internal,
+		},
+		"Success": {
+			request:              &pb.InvalidateRequest{ShortUrlPath: "abc1234"},
+			DeleteValueCallTimes: 1,
+			ExpectedCode:         codes.OK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(mockCtrl)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+
+			mockCache.EXPECT().DeleteValue(gomock.Any(), "url:abc1234", gomock.Any()).Return(test.DeleteValueReturnError).Times(test.DeleteValueCallTimes)
+
+			client := newClient(t, grpcserver.NewServer(mockCache, logger, resolver.NewResolver(mockCache, mockDbService, ttls, 0, logger)))
+
+			_, err := client.Invalidate(context.Background(), test.request)
+			assert.Equal(t, test.ExpectedCode, status.Code(err))
+		})
+	}
+}
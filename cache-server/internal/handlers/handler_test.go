@@ -4,129 +4,169 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	mock_databaseservice "cache-server/external/database-service/mocks"
+	"cache-server/internal/cache"
 	mock_cache "cache-server/internal/cache/mocks"
-	mock_config "cache-server/internal/config/mocks"
+	"cache-server/internal/config"
 	"cache-server/internal/handlers"
+	"cache-server/internal/metrics"
 	"cache-server/internal/models"
+	"cache-server/internal/resolver"
+	"cache-server/internal/warmer"
+	mock_warmer "cache-server/internal/warmer/mocks"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 )
 
 func TestHandleRedirect(t *testing.T) {
 	logger := zap.NewNop()
-	mockCtrl := gomock.NewController(t)
-
-	mockCache := mock_cache.NewMockCacheInterface(mockCtrl)
-	mockConfig := mock_config.NewMockConfigInterface(mockCtrl)
-	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
-
-	handler := handlers.NewHandler(mockCache, logger.Sugar(), mockConfig, mockDbService)
 
 	tests := map[string]struct {
 		requestBody               *models.RedirectRequestModel
-		GetValue                  *gomock.Call
 		GetValueReturnError       error
 		GetValueReturnVal         string
 		GetValueCallTimes         int
-		HandleRedirect            *gomock.Call
 		HandleRedirectReturnError error
 		HandleRedirectReturnVal   string
 		HandleRedirectCallTimes   int
-		SetValue                  *gomock.Call
 		SetValueReturnError       error
 		SetValueCallTimes         int
 		ExpectedStatusCode        int
+		ExpectedLookup            string
 	}{
 		"EmptyBody": {
 			requestBody:               nil,
-			GetValue:                  mockCache.EXPECT().GetValue(gomock.Any(), gomock.Any()),
 			GetValueReturnError:       nil,
 			GetValueReturnVal:         "",
 			GetValueCallTimes:         0,
-			HandleRedirect:            mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError: nil,
 			HandleRedirectReturnVal:   "",
 			HandleRedirectCallTimes:   0,
-			SetValue:                  mockCache.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 			SetValueReturnError:       nil,
 			SetValueCallTimes:         0,
 			ExpectedStatusCode:        http.StatusBadRequest,
 		},
 		"Cache Miss With DB Error": {
 			requestBody:               &models.RedirectRequestModel{ShortUrlPath: "shortUrl"},
-			GetValue:                  mockCache.EXPECT().GetValue(gomock.Any(), gomock.Any()),
 			GetValueReturnError:       assert.AnError,
 			GetValueReturnVal:         "",
 			GetValueCallTimes:         1,
-			HandleRedirect:            mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError: assert.AnError,
 			HandleRedirectReturnVal:   "",
 			HandleRedirectCallTimes:   1,
-			SetValue:                  mockCache.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 			SetValueReturnError:       nil,
 			SetValueCallTimes:         0,
 			ExpectedStatusCode:        http.StatusInternalServerError,
+			ExpectedLookup:            "miss",
 		},
 		"Cache Miss With DB Not Found": {
 			requestBody:               &models.RedirectRequestModel{ShortUrlPath: "shortUrl"},
-			GetValue:                  mockCache.EXPECT().GetValue(gomock.Any(), gomock.Any()),
 			GetValueReturnError:       assert.AnError,
 			GetValueReturnVal:         "",
 			GetValueCallTimes:         1,
-			HandleRedirect:            mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError: errors.New(http.StatusText(http.StatusNotFound)),
 			HandleRedirectReturnVal:   "",
 			HandleRedirectCallTimes:   1,
-			SetValue:                  mockCache.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 			SetValueReturnError:       nil,
 			SetValueCallTimes:         1,
 			ExpectedStatusCode:        http.StatusNotFound,
+			ExpectedLookup:            "miss",
+		},
+		"Cache Miss With DB Deleted": {
+			requestBody:               &models.RedirectRequestModel{ShortUrlPath: "shortUrl"},
+			GetValueReturnError:       assert.AnError,
+			GetValueReturnVal:         "",
+			GetValueCallTimes:         1,
+			HandleRedirectReturnError: errors.New(http.StatusText(http.StatusGone)),
+			HandleRedirectReturnVal:   "",
+			HandleRedirectCallTimes:   1,
+			SetValueReturnError:       nil,
+			SetValueCallTimes:         1,
+			ExpectedStatusCode:        http.StatusGone,
+			ExpectedLookup:            "miss",
 		},
 		"Cache Miss With DB Success": {
 			requestBody:               &models.RedirectRequestModel{ShortUrlPath: "shortUrl"},
-			GetValue:                  mockCache.EXPECT().GetValue(gomock.Any(), gomock.Any()),
 			GetValueReturnError:       assert.AnError,
 			GetValueReturnVal:         "",
 			GetValueCallTimes:         1,
-			HandleRedirect:            mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError: nil,
 			HandleRedirectReturnVal:   "",
 			HandleRedirectCallTimes:   1,
-			SetValue:                  mockCache.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 			SetValueReturnError:       nil,
 			SetValueCallTimes:         1,
 			ExpectedStatusCode:        http.StatusOK,
+			ExpectedLookup:            "miss",
 		},
 		"Cache Hit": {
 			requestBody:               &models.RedirectRequestModel{ShortUrlPath: "shortUrl"},
-			GetValue:                  mockCache.EXPECT().GetValue(gomock.Any(), gomock.Any()),
 			GetValueReturnError:       nil,
-			GetValueReturnVal:         "",
+			GetValueReturnVal:         `{"value":"{}","status":200,"version":1}`,
 			GetValueCallTimes:         1,
-			HandleRedirect:            mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError: nil,
 			HandleRedirectReturnVal:   "",
 			HandleRedirectCallTimes:   0,
-			SetValue:                  mockCache.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
 			SetValueReturnError:       nil,
 			SetValueCallTimes:         0,
 			ExpectedStatusCode:        http.StatusOK,
+			ExpectedLookup:            "hit",
+		},
+		"Cache Negative Hit": {
+			requestBody:               &models.RedirectRequestModel{ShortUrlPath: "shortUrl"},
+			GetValueReturnError:       nil,
+			GetValueReturnVal:         `{"status":404,"version":1}`,
+			GetValueCallTimes:         1,
+			HandleRedirectReturnError: nil,
+			HandleRedirectReturnVal:   "",
+			HandleRedirectCallTimes:   0,
+			SetValueReturnError:       nil,
+			SetValueCallTimes:         0,
+			ExpectedStatusCode:        http.StatusNotFound,
+			ExpectedLookup:            "negative_hit",
+		},
+		"Cache Deleted Hit": {
+			requestBody:               &models.RedirectRequestModel{ShortUrlPath: "shortUrl"},
+			GetValueReturnError:       nil,
+			GetValueReturnVal:         `{"status":410,"version":1}`,
+			GetValueCallTimes:         1,
+			HandleRedirectReturnError: nil,
+			HandleRedirectReturnVal:   "",
+			HandleRedirectCallTimes:   0,
+			SetValueReturnError:       nil,
+			SetValueCallTimes:         0,
+			ExpectedStatusCode:        http.StatusGone,
+			ExpectedLookup:            "negative_hit",
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			body, err := json.Marshal(test.requestBody)
-			test.GetValue.Return(test.GetValueReturnVal, test.GetValueReturnError).Times(test.GetValueCallTimes)
-			test.HandleRedirect.Return(test.GetValueReturnVal, test.HandleRedirectReturnError).Times(test.HandleRedirectCallTimes)
-			test.SetValue.Return(test.SetValueReturnError).Times(test.SetValueCallTimes)
+
+			mockCtrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(mockCtrl)
+			cfg := &config.Config{}
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+
+			mockCache.EXPECT().GetValueWithTTL(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.GetValueReturnVal, time.Minute, test.GetValueReturnError).Times(test.GetValueCallTimes)
+			mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.GetValueReturnVal, test.HandleRedirectReturnError).Times(test.HandleRedirectCallTimes)
+			mockCache.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(test.SetValueReturnError).Times(test.SetValueCallTimes)
+
+			handler := handlers.NewHandler(mockCache, logger.Sugar(), cfg, resolver.NewResolver(mockCache, mockDbService, cache.TTLs{Found: time.Minute, NotFound: 10 * time.Second}, 0, logger.Sugar()), nil)
+			hits := testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("hit"))
+			misses := testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("miss"))
+			negativeHits := testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("negative_hit"))
 
 			assert.Nil(t, err)
 
@@ -135,6 +175,223 @@ func TestHandleRedirect(t *testing.T) {
 			handler.HandleRedirect(resp, req)
 
 			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+
+			expectedHits, expectedMisses, expectedNegativeHits := hits, misses, negativeHits
+			switch test.ExpectedLookup {
+			case "hit":
+				expectedHits++
+			case "miss":
+				expectedMisses++
+			case "negative_hit":
+				expectedNegativeHits++
+			}
+
+			assert.Equal(t, expectedHits, testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("hit")))
+			assert.Equal(t, expectedMisses, testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("miss")))
+			assert.Equal(t, expectedNegativeHits, testutil.ToFloat64(metrics.CacheLookups.WithLabelValues("negative_hit")))
+		})
+	}
+}
+
+func TestHandleEvict(t *testing.T) {
+	logger := zap.NewNop()
+
+	tests := map[string]struct {
+		muxVars                map[string]string
+		DeleteValueReturnError error
+		DeleteValueCallTimes   int
+		ExpectedStatusCode     int
+	}{
+		"Empty Short Url Path": {
+			muxVars:              map[string]string{},
+			DeleteValueCallTimes: 0,
+			ExpectedStatusCode:   http.StatusBadRequest,
+		},
+		"Cache Error": {
+			muxVars:                map[string]string{"shorturlpath": "abc1234"},
+			DeleteValueReturnError: assert.AnError,
+			DeleteValueCallTimes:   1,
+			ExpectedStatusCode:     http.StatusInternalServerError,
+		},
+		"Success": {
+			muxVars:              map[string]string{"shorturlpath": "abc1234"},
+			DeleteValueCallTimes: 1,
+			ExpectedStatusCode:   http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(mockCtrl)
+			cfg := &config.Config{}
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+
+			mockCache.EXPECT().DeleteValue(gomock.Any(), "url:abc1234", gomock.Any()).Return(test.DeleteValueReturnError).Times(test.DeleteValueCallTimes)
+
+			handler := handlers.NewHandler(mockCache, logger.Sugar(), cfg, resolver.NewResolver(mockCache, mockDbService, cache.TTLs{Found: time.Minute, NotFound: 10 * time.Second}, 0, logger.Sugar()), nil)
+
+			req := httptest.NewRequest("DELETE", "/cache/abc1234", nil)
+			req = mux.SetURLVars(req, test.muxVars)
+			resp := httptest.NewRecorder()
+			handler.HandleEvict(resp, req)
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+		})
+	}
+}
+
+func TestHandleStore(t *testing.T) {
+	logger := zap.NewNop()
+
+	tests := map[string]struct {
+		requestBody         string
+		SetValueReturnError error
+		SetValueCallTimes   int
+		ExpectedValue       string
+		ExpectedStatusCode  int
+	}{
+		"Invalid JSON": {
+			requestBody:        "{",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Empty URL": {
+			requestBody:        `{"shorturlpath":"abc1234"}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Expired": {
+			requestBody:        `{"shorturlpath":"abc1234","url":"https://google.com","expires_at":"2020-01-01T00:00:00Z"}`,
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+		"Cache Error": {
+			requestBody:         `{"shorturlpath":"abc1234","url":"https://google.com"}`,
+			SetValueReturnError: assert.AnError,
+			SetValueCallTimes:   1,
+			ExpectedStatusCode:  http.StatusInternalServerError,
+		},
+		"Success": {
+			requestBody:        `{"shorturlpath":"abc1234","url":"https://google.com"}`,
+			SetValueCallTimes:  1,
+			ExpectedValue:      `{"value":"{\"redirecturl\":\"https://google.com\"}","status":200,"version":1}`,
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(mockCtrl)
+			cfg := &config.Config{}
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+
+			expectedValue := gomock.Any()
+			if test.ExpectedValue != "" {
+				expectedValue = gomock.Eq(test.ExpectedValue)
+			}
+
+			mockCache.EXPECT().SetValue(gomock.Any(), "url:abc1234", expectedValue, gomock.Any(), time.Minute).Return(test.SetValueReturnError).Times(test.SetValueCallTimes)
+
+			handler := handlers.NewHandler(mockCache, logger.Sugar(), cfg, resolver.NewResolver(mockCache, mockDbService, cache.TTLs{Found: time.Minute, NotFound: 10 * time.Second}, 0, logger.Sugar()), nil)
+
+			req := httptest.NewRequest("PUT", "/cache", strings.NewReader(test.requestBody))
+			resp := httptest.NewRecorder()
+			handler.HandleStore(resp, req)
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+		})
+	}
+}
+
+func TestHandleFlush(t *testing.T) {
+	logger := zap.NewNop()
+
+	tests := map[string]struct {
+		FlushReturnError   error
+		ExpectedStatusCode int
+	}{
+		"Cache Error": {
+			FlushReturnError:   assert.AnError,
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Success": {
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(mockCtrl)
+			cfg := &config.Config{}
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+
+			mockCache.EXPECT().Flush(gomock.Any()).Return(test.FlushReturnError)
+
+			handler := handlers.NewHandler(mockCache, logger.Sugar(), cfg, resolver.NewResolver(mockCache, mockDbService, cache.TTLs{Found: time.Minute, NotFound: 10 * time.Second}, 0, logger.Sugar()), nil)
+
+			req := httptest.NewRequest("POST", "/cache/flush", nil)
+			resp := httptest.NewRecorder()
+			handler.HandleFlush(resp, req)
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+		})
+	}
+}
+
+func TestHandleWarm(t *testing.T) {
+	logger := zap.NewNop()
+
+	tests := map[string]struct {
+		requestBody        string
+		ExpectedRequest    models.CacheWarmRequestModel
+		StartReturnError   error
+		StartCallTimes     int
+		ExpectedStatusCode int
+	}{
+		"Invalid JSON": {
+			requestBody:        "{",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Invalid Request": {
+			requestBody:        `{"source":"oldest"}`,
+			ExpectedRequest:    models.CacheWarmRequestModel{Source: "oldest"},
+			StartReturnError:   fmt.Errorf("%w: source must be top or recent", warmer.ErrInvalidRequest),
+			StartCallTimes:     1,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Already Running": {
+			StartReturnError:   warmer.ErrRunning,
+			StartCallTimes:     1,
+			ExpectedStatusCode: http.StatusConflict,
+		},
+		"Empty Body": {
+			StartCallTimes:     1,
+			ExpectedStatusCode: http.StatusAccepted,
+		},
+		"Success": {
+			requestBody:        `{"source":"recent","limit":50}`,
+			ExpectedRequest:    models.CacheWarmRequestModel{Source: "recent", Limit: 50},
+			StartCallTimes:     1,
+			ExpectedStatusCode: http.StatusAccepted,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(mockCtrl)
+			mockWarmer := mock_warmer.NewMockWarmerInterface(mockCtrl)
+			cfg := &config.Config{}
+
+			mockWarmer.EXPECT().Start(gomock.Any(), test.ExpectedRequest, gomock.Any()).Return(test.StartReturnError).Times(test.StartCallTimes)
+
+			handler := handlers.NewHandler(mockCache, logger.Sugar(), cfg, nil, mockWarmer)
+
+			req := httptest.NewRequest("POST", "/cache/warm", strings.NewReader(test.requestBody))
+			resp := httptest.NewRecorder()
+			handler.HandleWarm(resp, req)
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
 		})
 	}
 }
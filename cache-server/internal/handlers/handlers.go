@@ -1,36 +1,44 @@
 package handlers
 
 import (
-	"bytes"
-	databaseservice "cache-server/external/database-service"
 	"cache-server/internal/cache"
 	"cache-server/internal/config"
 	"cache-server/internal/models"
+	"cache-server/internal/resolver"
+	"cache-server/internal/warmer"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
-	"time"
 
+	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
 
 type HandlerInterface interface {
 	HandleRedirect(w http.ResponseWriter, r *http.Request)
+	HandleEvict(w http.ResponseWriter, r *http.Request)
+	HandleStore(w http.ResponseWriter, r *http.Request)
+	HandleFlush(w http.ResponseWriter, r *http.Request)
+	HandleWarm(w http.ResponseWriter, r *http.Request)
 }
 
 type handler struct {
-	cache     cache.CacheInterface
-	logger    *zap.SugaredLogger
-	config    config.ConfigInterface
-	dbService databaseservice.DatabaseServiceInterface
+	cache    cache.CacheInterface
+	logger   *zap.SugaredLogger
+	config   *config.Config
+	resolver resolver.ResolverInterface
+	warmer   warmer.WarmerInterface
 }
 
-func NewHandler(cache cache.CacheInterface, logger *zap.SugaredLogger, config config.ConfigInterface, dbService databaseservice.DatabaseServiceInterface) *handler {
+func NewHandler(cache cache.CacheInterface, logger *zap.SugaredLogger, config *config.Config, resolver resolver.ResolverInterface, warmer warmer.WarmerInterface) *handler {
 	return &handler{
-		cache:     cache,
-		logger:    logger,
-		config:    config,
-		dbService: dbService,
+		cache:    cache,
+		logger:   logger,
+		config:   config,
+		resolver: resolver,
+		warmer:   warmer,
 	}
 }
 
@@ -71,44 +79,166 @@ func (h *handler) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	val, err := h.cache.GetValue(string(httpBody), requestId)
+	val, err := h.resolver.Resolve(r.Context(), unmarsheledBody.ShortUrlPath, requestId)
 
 	if err != nil {
-		h.logger.Errorw("Error retrieving value from cache", zap.String("Request Id", requestId), zap.Error(err))
-		val, err = h.dbService.HandleRedirect(bytes.NewBuffer(httpBody), requestId)
+		if err.Error() == http.StatusText(http.StatusNotFound) {
+			h.logger.Errorw("URL not found", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "URL not found", http.StatusNotFound)
+			return
+		}
 
-		if err != nil {
-			if err.Error() == http.StatusText(http.StatusNotFound) {
-				h.logger.Errorw("URL not found", zap.String("Request Id", requestId), zap.Error(err))
-				http.Error(w, "URL not found", http.StatusNotFound)
-				err = h.cache.SetValue(string(httpBody), "", requestId, 10*time.Second)
+		if err.Error() == http.StatusText(http.StatusGone) {
+			h.logger.Errorw("URL was deleted", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "URL was deleted", http.StatusGone)
+			return
+		}
 
-				if err != nil {
-					h.logger.Errorw("Error setting value in cache", zap.String("Request Id", requestId), zap.Error(err))
-				}
+		h.logger.Errorw("Error processing redirect request", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error processing redirect request", http.StatusInternalServerError)
+		return
+	}
 
-				return
-			}
+	h.logger.Infow("Successfully processed redirect request", zap.String("Request Id", requestId), zap.String("value", val))
 
-			h.logger.Errorw("Error processing redirect request", zap.String("Request Id", requestId), zap.Error(err))
-			http.Error(w, "Error processing redirect request", http.StatusInternalServerError)
-			return
-		}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(val))
+
+	h.logger.Infow("Successfully responded to redirect request", zap.String("Request Id", requestId))
+
+}
+
+func (h *handler) HandleEvict(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["shorturlpath"]
+
+	if shortUrlPath == "" {
+		h.logger.Errorw("Empty short url path", zap.String("Request Id", requestId))
+		http.Error(w, "Empty short url path", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Infow("Handling evict request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	if err := h.resolver.Evict(r.Context(), shortUrlPath, requestId); err != nil {
+		h.logger.Errorw("Error evicting value from cache", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error evicting value from cache", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Infow("Successfully evicted value from cache", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+}
+
+// HandleStore caches the redirect of a link created elsewhere, so its first
+// redirect is a hit. An entry recording that the short url path did not
+// exist is replaced.
+func (h *handler) HandleStore(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling store request", zap.String("Request Id", requestId))
+
+	if r.Body == nil {
+		h.logger.Errorw("Empty request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty request body", http.StatusBadRequest)
+		return
+	}
+
+	request := &models.CacheStoreRequestModel{}
+
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error unmarshalling JSON", http.StatusBadRequest)
+		return
+	}
+
+	if request.ShortUrlPath == "" || request.Url == "" {
+		h.logger.Errorw("Empty short url path or URL in request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty short url path or URL in request body", http.StatusBadRequest)
+		return
+	}
 
-		err = h.cache.SetValue(string(httpBody), val, requestId, 3*time.Minute)
+	value, err := json.Marshal(models.RedirectResponseModel{Url: request.Url})
+
+	if err == nil {
+		err = h.resolver.Store(r.Context(), request.ShortUrlPath, string(value), request.ExpiresAt, requestId)
+	}
+
+	if err != nil {
+		h.logger.Errorw("Error storing value in cache", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error storing value in cache", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Infow("Successfully stored value in cache", zap.String("Request Id", requestId), zap.String("shorturlpath", request.ShortUrlPath))
+}
+
+func (h *handler) HandleFlush(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling flush request", zap.String("Request Id", requestId))
+
+	if err := h.cache.Flush(r.Context()); err != nil {
+		h.logger.Errorw("Error flushing cache", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error flushing cache", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Infow("Successfully flushed cache", zap.String("Request Id", requestId))
+}
+
+// HandleWarm starts warming the cache in the background. Fields left out of
+// the request, or the whole body, default to the CACHE_WARM_* settings.
+func (h *handler) HandleWarm(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling warm request", zap.String("Request Id", requestId))
+
+	request := models.CacheWarmRequestModel{}
+
+	if r.Body != nil {
+		httpBody, err := io.ReadAll(r.Body)
 
 		if err != nil {
-			h.logger.Errorw("Error setting value in cache", zap.String("Request Id", requestId), zap.Error(err))
+			h.logger.Errorw("Error reading request body", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Error reading request body", http.StatusInternalServerError)
+			return
 		}
 
-		h.logger.Infow("Successfully processed redirect request", zap.String("Request Id", requestId), zap.String("value", val))
-	} else {
-		h.logger.Infow("Successfully retrieved value from cache", zap.String("Request Id", requestId), zap.String("value", val))
+		if len(httpBody) > 0 {
+			if err := json.Unmarshal(httpBody, &request); err != nil {
+				h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+				http.Error(w, "Error unmarshalling JSON", http.StatusBadRequest)
+				return
+			}
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Write([]byte(val))
+	// The warm outlives the request, so it must not be cancelled with it.
+	err := h.warmer.Start(context.WithoutCancel(r.Context()), request, requestId)
 
-	h.logger.Infow("Successfully responded to redirect request", zap.String("Request Id", requestId))
+	switch {
+	case errors.Is(err, warmer.ErrRunning):
+		h.logger.Errorw("Cache warming already running", zap.String("Request Id", requestId))
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, warmer.ErrInvalidRequest):
+		h.logger.Errorw("Invalid warm request", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		h.logger.Errorw("Error starting cache warming", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error starting cache warming", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
 
+	h.logger.Infow("Started warming cache", zap.String("Request Id", requestId))
 }
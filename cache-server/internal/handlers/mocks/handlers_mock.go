@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: D:\Programming\Go\url-shortner\cache-server\internal\handlers\handlers.go
+// Source: internal/handlers/handlers.go
 
 // Package mock_handlers is a generated GoMock package.
 package mock_handlers
@@ -34,6 +34,30 @@ func (m *MockHandlerInterface) EXPECT() *MockHandlerInterfaceMockRecorder {
 	return m.recorder
 }
 
+// HandleEvict mocks base method.
+func (m *MockHandlerInterface) HandleEvict(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleEvict", w, r)
+}
+
+// HandleEvict indicates an expected call of HandleEvict.
+func (mr *MockHandlerInterfaceMockRecorder) HandleEvict(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvict", reflect.TypeOf((*MockHandlerInterface)(nil).HandleEvict), w, r)
+}
+
+// HandleFlush mocks base method.
+func (m *MockHandlerInterface) HandleFlush(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleFlush", w, r)
+}
+
+// HandleFlush indicates an expected call of HandleFlush.
+func (mr *MockHandlerInterfaceMockRecorder) HandleFlush(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleFlush", reflect.TypeOf((*MockHandlerInterface)(nil).HandleFlush), w, r)
+}
+
 // HandleRedirect mocks base method.
 func (m *MockHandlerInterface) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -45,3 +69,27 @@ func (mr *MockHandlerInterfaceMockRecorder) HandleRedirect(w, r interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRedirect", reflect.TypeOf((*MockHandlerInterface)(nil).HandleRedirect), w, r)
 }
+
+// HandleStore mocks base method.
+func (m *MockHandlerInterface) HandleStore(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleStore", w, r)
+}
+
+// HandleStore indicates an expected call of HandleStore.
+func (mr *MockHandlerInterfaceMockRecorder) HandleStore(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleStore", reflect.TypeOf((*MockHandlerInterface)(nil).HandleStore), w, r)
+}
+
+// HandleWarm mocks base method.
+func (m *MockHandlerInterface) HandleWarm(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleWarm", w, r)
+}
+
+// HandleWarm indicates an expected call of HandleWarm.
+func (mr *MockHandlerInterfaceMockRecorder) HandleWarm(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWarm", reflect.TypeOf((*MockHandlerInterface)(nil).HandleWarm), w, r)
+}
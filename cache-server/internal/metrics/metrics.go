@@ -0,0 +1,49 @@
+// Package metrics holds the Prometheus collectors of the cache server. They
+// are registered with the default registry and served at /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// HTTPRequests counts handled requests by route template, method and
+	// status code.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests handled.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration observes how long requests take, with the same
+	// labels as HTTPRequests.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle HTTP requests.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// CacheLookups counts redirect lookups by result: hit, negative_hit for
+	// a cached short url path that does not exist, or miss.
+	CacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_lookups_total",
+		Help: "Number of redirect lookups in the cache.",
+	}, []string{"result"})
+
+	// DatabaseLookups counts the lookups sent to the database service by
+	// reason: "miss", "refresh" when a hot entry is refreshed before it
+	// expires, or "coalesced" for misses that waited on a lookup of the same
+	// key already in flight instead of sending their own.
+	DatabaseLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_database_lookups_total",
+		Help: "Number of redirect lookups sent to, or coalesced before, the database service.",
+	}, []string{"reason"})
+
+	// CacheWarmedLinks counts the links loaded by cache warming by result:
+	// "cached", "expired" for links that expired before they were loaded, or
+	// "failed".
+	CacheWarmedLinks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_warmed_links_total",
+		Help: "Number of links loaded into the cache by cache warming.",
+	}, []string{"result"})
+)
@@ -2,7 +2,14 @@ package middlewares
 
 import (
 	"cache-server/internal/utils"
+	"context"
 	"net/http"
+
+	"url-shortner-api/pb"
+	"url-shortner-api/tracing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 func LoggingMiddleware(h http.Handler) http.Handler {
@@ -17,3 +24,18 @@ func LoggingMiddleware(h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
+
+// LoggingInterceptor is the gRPC counterpart of LoggingMiddleware: it makes
+// sure every call carries a request id, and records it on the call's span.
+func LoggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if pb.RequestId(ctx) == "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		md.Set(pb.RequestIdKey, utils.GenerateRequestId())
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	tracing.SetRequestId(ctx, pb.RequestId(ctx))
+
+	return handler(ctx, req)
+}
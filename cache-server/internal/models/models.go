@@ -1,29 +1,17 @@
 package models
 
-import "time"
+import api "url-shortner-api"
 
-type RequestModel struct {
-	Url       string    `json:"url"`
-	ExpiresAt time.Time `json:"expires_at"`
-}
-
-type ShortenRequestModel struct {
-	Url       string    `json:"url"`
-	ExpiresAt time.Time `json:"expires_at"`
-}
-
-type ShortenResponseModel struct {
-	ShortUrlPath string `json:"shorturlpath"`
-}
-
-type RedirectRequestModel struct {
-	ShortUrlPath string `json:"shorturlpath"`
-}
-
-type RedirectResponseModel struct {
-	Url string `json:"redirecturl"`
-}
-
-type ResponseModel struct {
-	Url string `json:"url"`
-}
+// The request and response models are generated from the OpenAPI
+// specification in the api module and shared with the other services.
+type (
+	RequestModel           = api.ShortenRequestModel
+	ResponseModel          = api.ShortenResponseModel
+	ShortenRequestModel    = api.ShortenRequestModel
+	ShortenResponseModel   = api.ShortenResponseModel
+	RedirectRequestModel   = api.RedirectRequestModel
+	RedirectResponseModel  = api.RedirectResponseModel
+	LinkModel              = api.LinkModel
+	CacheStoreRequestModel = api.CacheStoreRequestModel
+	CacheWarmRequestModel  = api.CacheWarmRequestModel
+)
@@ -0,0 +1,300 @@
+// Package resolver resolves short url paths for the HTTP and gRPC servers,
+// from Redis or, on a miss, from the database service.
+package resolver
+
+import (
+	"bytes"
+	databaseservice "cache-server/external/database-service"
+	"cache-server/internal/cache"
+	"cache-server/internal/metrics"
+	"cache-server/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+type ResolverInterface interface {
+	Resolve(ctx context.Context, shortUrlPath, requestId string) (string, error)
+	Store(ctx context.Context, shortUrlPath, value string, expiresAt time.Time, requestId string) error
+	Evict(ctx context.Context, shortUrlPath, requestId string) error
+}
+
+// resolver sends one database lookup per key at a time: misses of a key
+// that is already being looked up wait for that lookup, so an expired
+// popular link does not send every concurrent request to the database
+// service. Hot entries are also refreshed in the background before they
+// expire, the more likely the closer they are to expiring, once they are
+// within refreshWindow of it.
+//
+// A key evicted while it is being loaded is not cached by that load, since
+// the load may have read the link before the change that evicted it.
+type resolver struct {
+	cache         cache.CacheInterface
+	dbService     databaseservice.DatabaseServiceInterface
+	logger        *zap.SugaredLogger
+	ttls          cache.TTLs
+	refreshWindow time.Duration
+	lookups       singleflight.Group
+
+	mu          sync.Mutex
+	generations map[string]*generation
+}
+
+// generation counts the evictions of a key while loads of it are running.
+// It is dropped once the last of them finishes.
+type generation struct {
+	evictions uint64
+	loads     int
+}
+
+func NewResolver(cache cache.CacheInterface, dbService databaseservice.DatabaseServiceInterface, ttls cache.TTLs, refreshWindow time.Duration, logger *zap.SugaredLogger) *resolver {
+	return &resolver{
+		cache:         cache,
+		dbService:     dbService,
+		logger:        logger,
+		ttls:          ttls,
+		refreshWindow: refreshWindow,
+		generations:   map[string]*generation{},
+	}
+}
+
+// Resolve returns the cached redirect response of a short url path, or an
+// error whose message is the 404 status text when the path does not exist
+// and the 410 status text when its link was deleted.
+func (r *resolver) Resolve(ctx context.Context, shortUrlPath, requestId string) (string, error) {
+	key := cache.Key(shortUrlPath)
+
+	val, ttl, err := r.cache.GetValueWithTTL(ctx, key, requestId)
+
+	if err == nil {
+		entry, err := cache.ParseEntry(val)
+
+		if err == nil {
+			return r.hit(ctx, entry, ttl, shortUrlPath, requestId)
+		}
+
+		r.logger.Errorw("Error parsing cached entry", zap.String("Request Id", requestId), zap.Error(err))
+	} else if !errors.Is(err, cache.ErrNotFound) {
+		r.logger.Errorw("Error retrieving value from cache", zap.String("Request Id", requestId), zap.Error(err))
+	}
+
+	metrics.CacheLookups.WithLabelValues("miss").Inc()
+
+	// The lookup is shared with the misses that join it, so it is not
+	// cancelled with the request that started it; each caller stops
+	// waiting when its own request is cancelled.
+	started := false
+
+	lookup := r.lookups.DoChan(key, func() (interface{}, error) {
+		started = true
+		metrics.DatabaseLookups.WithLabelValues("miss").Inc()
+		return r.load(context.WithoutCancel(ctx), shortUrlPath, requestId)
+	})
+
+	select {
+	case result := <-lookup:
+		if !started {
+			metrics.DatabaseLookups.WithLabelValues("coalesced").Inc()
+		}
+
+		if result.Err != nil {
+			return "", result.Err
+		}
+
+		return result.Val.(string), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// hit serves a cached entry, refreshing it in the background when it is
+// about to expire.
+func (r *resolver) hit(ctx context.Context, entry cache.Entry, ttl time.Duration, shortUrlPath, requestId string) (string, error) {
+	if !entry.Found() {
+		metrics.CacheLookups.WithLabelValues("negative_hit").Inc()
+		return "", errors.New(http.StatusText(entry.Status))
+	}
+
+	metrics.CacheLookups.WithLabelValues("hit").Inc()
+
+	if r.refreshEarly(ttl) {
+		r.refresh(ctx, shortUrlPath, requestId)
+	}
+
+	return entry.Value, nil
+}
+
+// refreshEarly decides whether a hit with ttl left is refreshed now. A key
+// without an expiry is never refreshed.
+func (r *resolver) refreshEarly(ttl time.Duration) bool {
+	if r.refreshWindow <= 0 || ttl < 0 || ttl >= r.refreshWindow {
+		return false
+	}
+
+	return rand.Float64()*float64(r.refreshWindow) >= float64(ttl)
+}
+
+// refresh reloads a key in the background, unless it is already being
+// looked up.
+func (r *resolver) refresh(ctx context.Context, shortUrlPath, requestId string) {
+	r.lookups.DoChan(cache.Key(shortUrlPath), func() (interface{}, error) {
+		metrics.DatabaseLookups.WithLabelValues("refresh").Inc()
+		r.logger.Infow("Refreshing cache entry before it expires", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+		return r.load(context.WithoutCancel(ctx), shortUrlPath, requestId)
+	})
+}
+
+// load reads a short url path from the database service and caches it,
+// caching a path that does not exist or was deleted too.
+func (r *resolver) load(ctx context.Context, shortUrlPath, requestId string) (string, error) {
+	key := cache.Key(shortUrlPath)
+
+	current, evictions := r.startLoad(key)
+	defer r.finishLoad(key, current)
+
+	body, err := json.Marshal(models.RedirectRequestModel{ShortUrlPath: shortUrlPath})
+
+	if err != nil {
+		r.logger.Errorw("Error marshalling redirect request", zap.String("Request Id", requestId), zap.Error(err))
+		return "", err
+	}
+
+	val, err := r.dbService.HandleRedirect(ctx, bytes.NewBuffer(body), requestId)
+
+	if err != nil {
+		status := 0
+
+		switch err.Error() {
+		case http.StatusText(http.StatusNotFound):
+			r.logger.Errorw("URL not found", zap.String("Request Id", requestId), zap.Error(err))
+			status = http.StatusNotFound
+		case http.StatusText(http.StatusGone):
+			r.logger.Errorw("URL was deleted", zap.String("Request Id", requestId), zap.Error(err))
+			status = http.StatusGone
+		}
+
+		if status != 0 {
+			entry := cache.NewEntry("", status)
+			r.storeLoaded(ctx, key, entry, current, evictions, requestId)
+		}
+
+		return "", err
+	}
+
+	entry := cache.NewEntry(val, http.StatusOK)
+	r.storeLoaded(ctx, key, entry, current, evictions, requestId)
+
+	return val, nil
+}
+
+// storeLoaded caches an entry read by a load, unless the key was evicted
+// since the load started. An eviction that lands while the entry is being
+// written removes it again.
+func (r *resolver) storeLoaded(ctx context.Context, key string, entry cache.Entry, current *generation, evictions uint64, requestId string) {
+	if r.evictedSince(current, evictions) {
+		r.logger.Infow("Not caching entry evicted while it was loaded", zap.String("Request Id", requestId), zap.String("key", key))
+		return
+	}
+
+	if err := r.store(ctx, key, entry, r.ttls.Of(entry), requestId); err != nil {
+		return
+	}
+
+	if r.evictedSince(current, evictions) {
+		if err := r.cache.DeleteValue(ctx, key, requestId); err != nil {
+			r.logger.Errorw("Error evicting value from cache", zap.String("Request Id", requestId), zap.Error(err))
+		}
+	}
+}
+
+func (r *resolver) startLoad(key string) (*generation, uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.generations[key]
+
+	if !ok {
+		current = &generation{}
+		r.generations[key] = current
+	}
+
+	current.loads++
+
+	return current, current.evictions
+}
+
+func (r *resolver) finishLoad(key string, current *generation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current.loads--; current.loads == 0 {
+		delete(r.generations, key)
+	}
+}
+
+func (r *resolver) evictedSince(current *generation, evictions uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return current.evictions != evictions
+}
+
+// Evict removes a short url path from the cache. Loads of it that are
+// already running do not cache what they read, and later misses start a
+// new load rather than joining them.
+func (r *resolver) Evict(ctx context.Context, shortUrlPath, requestId string) error {
+	key := cache.Key(shortUrlPath)
+
+	r.mu.Lock()
+	if current, ok := r.generations[key]; ok {
+		current.evictions++
+	}
+	r.mu.Unlock()
+
+	r.lookups.Forget(key)
+
+	return r.cache.DeleteValue(ctx, key, requestId)
+}
+
+// Store caches the redirect response of a short url path that was looked up
+// elsewhere, for the positive TTL but no longer than until the link expires.
+// A link that has already expired is not cached.
+func (r *resolver) Store(ctx context.Context, shortUrlPath, value string, expiresAt time.Time, requestId string) error {
+	entry := cache.NewEntry(value, http.StatusOK)
+	ttl := r.ttls.Of(entry)
+
+	if !expiresAt.IsZero() {
+		ttl = min(ttl, time.Until(expiresAt))
+	}
+
+	return r.store(ctx, cache.Key(shortUrlPath), entry, ttl, requestId)
+}
+
+// store caches an entry for ttl. Entries without a positive ttl are not
+// cached, as a key stored with a ttl of 0 would never expire.
+func (r *resolver) store(ctx context.Context, key string, entry cache.Entry, ttl time.Duration, requestId string) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	raw, err := entry.Marshal()
+
+	if err != nil {
+		r.logger.Errorw("Error marshalling cache entry", zap.String("Request Id", requestId), zap.Error(err))
+		return err
+	}
+
+	if err := r.cache.SetValue(ctx, key, raw, requestId, ttl); err != nil {
+		r.logger.Errorw("Error setting value in cache", zap.String("Request Id", requestId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
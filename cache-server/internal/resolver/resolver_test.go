@@ -0,0 +1,285 @@
+package resolver_test
+
+import (
+	mock_databaseservice "cache-server/external/database-service/mocks"
+	"cache-server/internal/cache"
+	mock_cache "cache-server/internal/cache/mocks"
+	"cache-server/internal/resolver"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var ttls = cache.TTLs{Found: time.Minute, NotFound: 10 * time.Second}
+
+func entry(value string, status int) string {
+	raw, _ := cache.NewEntry(value, status).Marshal()
+	return raw
+}
+
+func TestResolve(t *testing.T) {
+	tests := map[string]struct {
+		cacheVal      string
+		cacheErr      error
+		dbVal         string
+		dbErr         error
+		ttls          cache.TTLs
+		ExpectedDb    bool
+		ExpectedSet   string
+		ExpectedVal   string
+		ExpectedExp   time.Duration
+		ExpectedError string
+	}{
+		"Hit": {
+			cacheVal:    entry(`{"url":"https://google.com"}`, http.StatusOK),
+			ttls:        ttls,
+			ExpectedVal: `{"url":"https://google.com"}`,
+		},
+		"Negative Hit": {
+			cacheVal:      entry("", http.StatusNotFound),
+			ttls:          ttls,
+			ExpectedError: http.StatusText(http.StatusNotFound),
+		},
+		"Deleted Hit": {
+			cacheVal:      entry("", http.StatusGone),
+			ttls:          ttls,
+			ExpectedError: http.StatusText(http.StatusGone),
+		},
+		"Miss": {
+			cacheErr:    errors.New("redis: nil"),
+			dbVal:       `{"url":"https://google.com"}`,
+			ttls:        ttls,
+			ExpectedDb:  true,
+			ExpectedSet: entry(`{"url":"https://google.com"}`, http.StatusOK),
+			ExpectedVal: `{"url":"https://google.com"}`,
+			ExpectedExp: time.Minute,
+		},
+		"Old Entry Format": {
+			cacheVal:    `{"url":"https://google.com"}`,
+			dbVal:       `{"url":"https://example.com"}`,
+			ttls:        ttls,
+			ExpectedDb:  true,
+			ExpectedSet: entry(`{"url":"https://example.com"}`, http.StatusOK),
+			ExpectedVal: `{"url":"https://example.com"}`,
+			ExpectedExp: time.Minute,
+		},
+		"Not Found": {
+			cacheErr:      errors.New("redis: nil"),
+			dbErr:         errors.New(http.StatusText(http.StatusNotFound)),
+			ttls:          ttls,
+			ExpectedDb:    true,
+			ExpectedSet:   entry("", http.StatusNotFound),
+			ExpectedExp:   10 * time.Second,
+			ExpectedError: http.StatusText(http.StatusNotFound),
+		},
+		"Deleted": {
+			cacheErr:      errors.New("redis: nil"),
+			dbErr:         errors.New(http.StatusText(http.StatusGone)),
+			ttls:          ttls,
+			ExpectedDb:    true,
+			ExpectedSet:   entry("", http.StatusGone),
+			ExpectedExp:   10 * time.Second,
+			ExpectedError: http.StatusText(http.StatusGone),
+		},
+		"Not Found Without Negative Caching": {
+			cacheErr:      errors.New("redis: nil"),
+			dbErr:         errors.New(http.StatusText(http.StatusNotFound)),
+			ttls:          cache.TTLs{Found: time.Minute},
+			ExpectedDb:    true,
+			ExpectedError: http.StatusText(http.StatusNotFound),
+		},
+		"Database Failure": {
+			cacheErr:      errors.New("redis: nil"),
+			dbErr:         errors.New("request failed at database service"),
+			ttls:          ttls,
+			ExpectedDb:    true,
+			ExpectedError: "request failed at database service",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(ctrl)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(ctrl)
+
+			mockCache.EXPECT().GetValueWithTTL(gomock.Any(), cache.Key("abc1234"), "abc").Return(test.cacheVal, time.Minute, test.cacheErr)
+
+			if test.ExpectedDb {
+				mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(test.dbVal, test.dbErr)
+			}
+
+			if test.ExpectedSet != "" {
+				mockCache.EXPECT().SetValue(gomock.Any(), cache.Key("abc1234"), test.ExpectedSet, "abc", test.ExpectedExp).Return(nil)
+			}
+
+			val, err := resolver.NewResolver(mockCache, mockDbService, test.ttls, 0, zap.NewNop().Sugar()).Resolve(context.Background(), "abc1234", "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.ExpectedVal, val)
+		})
+	}
+}
+
+func TestResolveCoalescesMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCache := mock_cache.NewMockCacheInterface(ctrl)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(ctrl)
+
+	mockCache.EXPECT().GetValueWithTTL(gomock.Any(), gomock.Any(), gomock.Any()).Return("", time.Duration(0), errors.New("redis: nil")).AnyTimes()
+	mockCache.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), time.Minute).Return(nil).Times(1)
+	mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, io.Reader, string) (string, error) {
+		time.Sleep(50 * time.Millisecond)
+		return `{"url":"https://google.com"}`, nil
+	}).Times(1)
+
+	linkResolver := resolver.NewResolver(mockCache, mockDbService, ttls, 0, zap.NewNop().Sugar())
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			val, err := linkResolver.Resolve(context.Background(), "abc1234", "abc")
+			assert.Nil(t, err)
+			assert.Equal(t, `{"url":"https://google.com"}`, val)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestResolveRefreshesEarly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCache := mock_cache.NewMockCacheInterface(ctrl)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(ctrl)
+
+	refreshed := make(chan struct{})
+
+	mockCache.EXPECT().GetValueWithTTL(gomock.Any(), cache.Key("abc1234"), "abc").Return(entry(`{"url":"https://google.com"}`, http.StatusOK), time.Duration(0), nil)
+	mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(`{"url":"https://example.com"}`, nil)
+	mockCache.EXPECT().SetValue(gomock.Any(), cache.Key("abc1234"), entry(`{"url":"https://example.com"}`, http.StatusOK), "abc", time.Minute).DoAndReturn(func(context.Context, string, string, string, time.Duration) error {
+		close(refreshed)
+		return nil
+	})
+
+	val, err := resolver.NewResolver(mockCache, mockDbService, ttls, time.Minute, zap.NewNop().Sugar()).Resolve(context.Background(), "abc1234", "abc")
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"url":"https://google.com"}`, val, "the cached value is served while it is refreshed")
+
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the entry was not refreshed")
+	}
+}
+
+func TestEvictDuringLoad(t *testing.T) {
+	tests := map[string]struct {
+		cacheErr error
+		cacheTTL time.Duration
+	}{
+		"Miss": {
+			cacheErr: errors.New("redis: nil"),
+		},
+		"Early Refresh": {
+			cacheTTL: time.Second,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockCache := mock_cache.NewMockCacheInterface(ctrl)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(ctrl)
+
+			loading := make(chan struct{})
+			release := make(chan struct{})
+
+			cached := ""
+			if test.cacheErr == nil {
+				cached = entry(`{"url":"https://google.com"}`, http.StatusOK)
+			}
+
+			mockCache.EXPECT().GetValueWithTTL(gomock.Any(), cache.Key("abc1234"), "abc").Return(cached, test.cacheTTL, test.cacheErr).Times(1)
+			mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").DoAndReturn(func(context.Context, io.Reader, string) (string, error) {
+				close(loading)
+				<-release
+				return `{"url":"https://google.com"}`, nil
+			}).Times(1)
+			mockCache.EXPECT().DeleteValue(gomock.Any(), cache.Key("abc1234"), "def").Return(nil).Times(1)
+			mockCache.EXPECT().SetValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			core, logs := observer.New(zap.InfoLevel)
+			linkResolver := resolver.NewResolver(mockCache, mockDbService, ttls, time.Minute, zap.New(core).Sugar())
+
+			go linkResolver.Resolve(context.Background(), "abc1234", "abc")
+
+			<-loading
+			assert.Nil(t, linkResolver.Evict(context.Background(), "abc1234", "def"))
+			close(release)
+
+			assert.Eventually(t, func() bool {
+				return logs.FilterMessage("Not caching entry evicted while it was loaded").Len() == 1
+			}, 5*time.Second, 10*time.Millisecond, "the load read before the eviction is not cached")
+		})
+	}
+}
+
+func TestEvictForgetsRunningLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCache := mock_cache.NewMockCacheInterface(ctrl)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(ctrl)
+
+	loading := make(chan struct{})
+	release := make(chan struct{})
+
+	mockCache.EXPECT().GetValueWithTTL(gomock.Any(), gomock.Any(), gomock.Any()).Return("", time.Duration(0), errors.New("redis: nil")).Times(2)
+	gomock.InOrder(
+		mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").DoAndReturn(func(context.Context, io.Reader, string) (string, error) {
+			close(loading)
+			<-release
+			return `{"url":"https://google.com"}`, nil
+		}),
+		mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "ghi").Return(`{"url":"https://example.com"}`, nil),
+	)
+	mockCache.EXPECT().DeleteValue(gomock.Any(), cache.Key("abc1234"), "def").Return(nil).Times(1)
+	mockCache.EXPECT().SetValue(gomock.Any(), cache.Key("abc1234"), entry(`{"url":"https://example.com"}`, http.StatusOK), "ghi", time.Minute).Return(nil).Times(1)
+
+	linkResolver := resolver.NewResolver(mockCache, mockDbService, ttls, 0, zap.NewNop().Sugar())
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		linkResolver.Resolve(context.Background(), "abc1234", "abc")
+	}()
+
+	<-loading
+	assert.Nil(t, linkResolver.Evict(context.Background(), "abc1234", "def"))
+
+	val, err := linkResolver.Resolve(context.Background(), "abc1234", "ghi")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"url":"https://example.com"}`, val, "a miss after the eviction does not join the running load")
+
+	close(release)
+	<-done
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/warmer/warmer.go
+
+// Package mock_warmer is a generated GoMock package.
+package mock_warmer
+
+import (
+	models "cache-server/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWarmerInterface is a mock of WarmerInterface interface.
+type MockWarmerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarmerInterfaceMockRecorder
+}
+
+// MockWarmerInterfaceMockRecorder is the mock recorder for MockWarmerInterface.
+type MockWarmerInterfaceMockRecorder struct {
+	mock *MockWarmerInterface
+}
+
+// NewMockWarmerInterface creates a new mock instance.
+func NewMockWarmerInterface(ctrl *gomock.Controller) *MockWarmerInterface {
+	mock := &MockWarmerInterface{ctrl: ctrl}
+	mock.recorder = &MockWarmerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarmerInterface) EXPECT() *MockWarmerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockWarmerInterface) Start(ctx context.Context, request models.CacheWarmRequestModel, requestId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, request, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockWarmerInterfaceMockRecorder) Start(ctx, request, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockWarmerInterface)(nil).Start), ctx, request, requestId)
+}
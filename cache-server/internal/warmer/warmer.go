@@ -0,0 +1,210 @@
+package warmer
+
+import (
+	databaseservice "cache-server/external/database-service"
+	"cache-server/internal/metrics"
+	"cache-server/internal/models"
+	"cache-server/internal/resolver"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrRunning is returned when a warm is requested while one is running.
+var ErrRunning = errors.New("cache warming already running")
+
+// ErrInvalidRequest is wrapped by the errors of warm requests that cannot be
+// run as asked.
+var ErrInvalidRequest = errors.New("invalid cache warm request")
+
+type WarmerInterface interface {
+	Start(ctx context.Context, request models.CacheWarmRequestModel, requestId string) error
+}
+
+// warmer loads the most clicked or most recently created links into the
+// cache, so a cold or freshly flushed cache does not send every redirect to
+// the database service at once. One warm runs at a time.
+type warmer struct {
+	dbService   databaseservice.DatabaseServiceInterface
+	resolver    resolver.ResolverInterface
+	defaults    models.CacheWarmRequestModel
+	concurrency int
+	rate        int
+	logger      *zap.SugaredLogger
+	running     atomic.Bool
+}
+
+// NewWarmer returns a warmer that fills in requests from defaults, caches up
+// to concurrency links at a time and no more than rate links a second, or
+// without a limit when rate is 0.
+func NewWarmer(dbService databaseservice.DatabaseServiceInterface, resolver resolver.ResolverInterface, defaults models.CacheWarmRequestModel, concurrency, rate int, logger *zap.SugaredLogger) *warmer {
+	return &warmer{
+		dbService:   dbService,
+		resolver:    resolver,
+		defaults:    defaults,
+		concurrency: max(concurrency, 1),
+		rate:        rate,
+		logger:      logger,
+	}
+}
+
+// Start checks the request and warms the cache in the background. It returns
+// ErrRunning if a warm is already running.
+func (w *warmer) Start(ctx context.Context, request models.CacheWarmRequestModel, requestId string) error {
+	request, err := w.complete(request)
+
+	if err != nil {
+		return err
+	}
+
+	if !w.running.CompareAndSwap(false, true) {
+		return ErrRunning
+	}
+
+	go func() {
+		defer w.running.Store(false)
+		w.warm(ctx, request, requestId)
+	}()
+
+	return nil
+}
+
+// Warm warms the cache and returns once every link has been loaded.
+func (w *warmer) Warm(ctx context.Context, request models.CacheWarmRequestModel, requestId string) error {
+	request, err := w.complete(request)
+
+	if err != nil {
+		return err
+	}
+
+	if !w.running.CompareAndSwap(false, true) {
+		return ErrRunning
+	}
+	defer w.running.Store(false)
+
+	w.warm(ctx, request, requestId)
+
+	return nil
+}
+
+// complete fills in the fields the request leaves out from the defaults and
+// checks the result.
+func (w *warmer) complete(request models.CacheWarmRequestModel) (models.CacheWarmRequestModel, error) {
+	if request.Source == "" {
+		request.Source = w.defaults.Source
+	}
+
+	if request.Limit == 0 {
+		request.Limit = w.defaults.Limit
+	}
+
+	if request.Days == 0 {
+		request.Days = w.defaults.Days
+	}
+
+	if request.Source != "top" && request.Source != "recent" {
+		return request, fmt.Errorf("%w: source must be top or recent, got %q", ErrInvalidRequest, request.Source)
+	}
+
+	if request.Limit < 0 || request.Days < 0 {
+		return request, fmt.Errorf("%w: limit and days must be positive", ErrInvalidRequest)
+	}
+
+	return request, nil
+}
+
+func (w *warmer) warm(ctx context.Context, request models.CacheWarmRequestModel, requestId string) {
+	w.logger.Infow("Warming cache", zap.String("Request Id", requestId), zap.String("source", request.Source), zap.Int("limit", request.Limit), zap.Int("days", request.Days))
+
+	var links []models.LinkModel
+	var err error
+
+	if request.Source == "recent" {
+		links, err = w.dbService.HandleRecentLinks(ctx, request.Limit, requestId)
+	} else {
+		links, err = w.dbService.HandleTopLinks(ctx, request.Limit, request.Days, requestId)
+	}
+
+	if err != nil {
+		w.logger.Errorw("Error listing links to warm the cache with", zap.String("Request Id", requestId), zap.Error(err))
+		return
+	}
+
+	var ticks <-chan time.Time
+
+	if w.rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(w.rate))
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	queue := make(chan models.LinkModel)
+	var cached atomic.Int64
+	var wg sync.WaitGroup
+
+	for range w.concurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for link := range queue {
+				if w.load(ctx, link, requestId) {
+					cached.Add(1)
+				}
+			}
+		}()
+	}
+
+send:
+	for i, link := range links {
+		if ticks != nil && i > 0 {
+			select {
+			case <-ticks:
+			case <-ctx.Done():
+				break send
+			}
+		}
+
+		select {
+		case queue <- link:
+		case <-ctx.Done():
+			break send
+		}
+	}
+
+	close(queue)
+	wg.Wait()
+
+	w.logger.Infow("Finished warming cache", zap.String("Request Id", requestId), zap.Int("links", len(links)), zap.Int64("cached", cached.Load()))
+}
+
+// load caches the redirect response of a link, reporting whether it was
+// cached.
+func (w *warmer) load(ctx context.Context, link models.LinkModel, requestId string) bool {
+	if !link.ExpiresAt.IsZero() && !link.ExpiresAt.After(time.Now()) {
+		metrics.CacheWarmedLinks.WithLabelValues("expired").Inc()
+		return false
+	}
+
+	value, err := json.Marshal(models.RedirectResponseModel{Url: link.Url})
+
+	if err == nil {
+		err = w.resolver.Store(ctx, link.ShortUrlPath, string(value), link.ExpiresAt, requestId)
+	}
+
+	if err != nil {
+		w.logger.Errorw("Error warming cache with link", zap.String("Request Id", requestId), zap.String("shorturlpath", link.ShortUrlPath), zap.Error(err))
+		metrics.CacheWarmedLinks.WithLabelValues("failed").Inc()
+		return false
+	}
+
+	metrics.CacheWarmedLinks.WithLabelValues("cached").Inc()
+	return true
+}
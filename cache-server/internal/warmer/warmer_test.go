@@ -0,0 +1,135 @@
+package warmer_test
+
+import (
+	mock_databaseservice "cache-server/external/database-service/mocks"
+	"cache-server/internal/cache"
+	"cache-server/internal/models"
+	"cache-server/internal/resolver"
+	"cache-server/internal/warmer"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var defaults = models.CacheWarmRequestModel{Source: "top", Limit: 1000, Days: 7}
+
+func TestWarm(t *testing.T) {
+	links := []models.LinkModel{
+		{ShortUrlPath: "abc1234", Url: "https://google.com"},
+		{ShortUrlPath: "def5678", Url: "https://example.com", ExpiresAt: time.Now().Add(time.Hour)},
+		{ShortUrlPath: "ghi9012", Url: "https://expired.com", ExpiresAt: time.Now().Add(-time.Hour)},
+	}
+
+	tests := map[string]struct {
+		request             models.CacheWarmRequestModel
+		ExpectedTopCalls    int
+		ExpectedLimit       int
+		ExpectedDays        int
+		ExpectedRecentCalls int
+		ExpectedError       error
+	}{
+		"Defaults": {
+			ExpectedTopCalls: 1,
+			ExpectedLimit:    1000,
+			ExpectedDays:     7,
+		},
+		"Top": {
+			request:          models.CacheWarmRequestModel{Limit: 10, Days: 30},
+			ExpectedTopCalls: 1,
+			ExpectedLimit:    10,
+			ExpectedDays:     30,
+		},
+		"Recent": {
+			request:             models.CacheWarmRequestModel{Source: "recent", Limit: 10},
+			ExpectedRecentCalls: 1,
+			ExpectedLimit:       10,
+		},
+		"Invalid Source": {
+			request:       models.CacheWarmRequestModel{Source: "oldest"},
+			ExpectedError: warmer.ErrInvalidRequest,
+		},
+		"Invalid Limit": {
+			request:       models.CacheWarmRequestModel{Limit: -1},
+			ExpectedError: warmer.ErrInvalidRequest,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			logger := zap.NewNop().Sugar()
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			memoryCache := cache.NewMemoryCache(logger)
+			resolver := resolver.NewResolver(memoryCache, mockDbService, cache.TTLs{Found: time.Minute}, 0, logger)
+
+			mockDbService.EXPECT().HandleTopLinks(gomock.Any(), test.ExpectedLimit, test.ExpectedDays, gomock.Any()).Return(links, nil).Times(test.ExpectedTopCalls)
+			mockDbService.EXPECT().HandleRecentLinks(gomock.Any(), test.ExpectedLimit, gomock.Any()).Return(links, nil).Times(test.ExpectedRecentCalls)
+
+			err := warmer.NewWarmer(mockDbService, resolver, defaults, 2, 0, logger).Warm(ctx, test.request, "abc")
+			assert.ErrorIs(t, err, test.ExpectedError)
+
+			if test.ExpectedError != nil {
+				return
+			}
+
+			val, err := resolver.Resolve(ctx, "abc1234", "abc")
+			assert.Nil(t, err)
+			assert.Equal(t, `{"redirecturl":"https://google.com"}`, val)
+
+			_, ttl, err := memoryCache.GetValueWithTTL(ctx, cache.Key("def5678"), "abc")
+			assert.Nil(t, err)
+			assert.LessOrEqual(t, ttl, time.Minute)
+
+			_, err = memoryCache.GetValue(ctx, cache.Key("ghi9012"), "abc")
+			assert.ErrorIs(t, err, cache.ErrNotFound, "expired links are not cached")
+		})
+	}
+}
+
+func TestWarmRateLimit(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	mockCtrl := gomock.NewController(t)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+	resolver := resolver.NewResolver(cache.NewMemoryCache(logger), mockDbService, cache.TTLs{Found: time.Minute}, 0, logger)
+
+	links := make([]models.LinkModel, 5)
+	for i := range links {
+		links[i] = models.LinkModel{ShortUrlPath: string(rune('a' + i)), Url: "https://google.com"}
+	}
+
+	mockDbService.EXPECT().HandleTopLinks(gomock.Any(), 5, 7, gomock.Any()).Return(links, nil)
+
+	start := time.Now()
+	err := warmer.NewWarmer(mockDbService, resolver, defaults, 8, 50, logger).Warm(context.Background(), models.CacheWarmRequestModel{Limit: 5}, "abc")
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 4*(time.Second/50), "5 links at 50 a second take at least 4 intervals")
+}
+
+func TestStartRunsOneWarmAtATime(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	mockCtrl := gomock.NewController(t)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+	resolver := resolver.NewResolver(cache.NewMemoryCache(logger), mockDbService, cache.TTLs{Found: time.Minute}, 0, logger)
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	mockDbService.EXPECT().HandleTopLinks(gomock.Any(), 1000, 7, gomock.Any()).DoAndReturn(func(ctx context.Context, limit, days int, requestId string) ([]models.LinkModel, error) {
+		<-release
+		defer close(done)
+		return nil, nil
+	})
+
+	w := warmer.NewWarmer(mockDbService, resolver, defaults, 1, 0, logger)
+
+	assert.Nil(t, w.Start(context.Background(), models.CacheWarmRequestModel{}, "abc"))
+	assert.ErrorIs(t, w.Start(context.Background(), models.CacheWarmRequestModel{}, "abc"), warmer.ErrRunning)
+
+	close(release)
+	<-done
+}
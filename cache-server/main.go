@@ -4,18 +4,49 @@ import (
 	databaseservice "cache-server/external/database-service"
 	"cache-server/internal/cache"
 	"cache-server/internal/config"
+	"cache-server/internal/grpcserver"
 	"cache-server/internal/handlers"
 	"cache-server/internal/logging"
+	"cache-server/internal/metrics"
 	"cache-server/internal/middlewares"
+	"cache-server/internal/models"
+	"cache-server/internal/resolver"
+	"cache-server/internal/warmer"
+	"context"
+	"errors"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 
-	kafka "github.com/cursed-ninja/go-kafka-producer"
+	"url-shortner-api/health"
+	"url-shortner-api/httpmiddleware"
+	"url-shortner-api/kafkalog"
+	"url-shortner-api/pb"
+	"url-shortner-api/resilience"
+	"url-shortner-api/settings"
+	"url-shortner-api/tlsconfig"
+	"url-shortner-api/tracing"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// shutdownTimeout is how long in-flight requests may take to finish once the
+// service is asked to stop.
+const shutdownTimeout = 20 * time.Second
+
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	initialLogger, err := zap.NewDevelopment()
 
 	if err != nil {
@@ -24,12 +55,13 @@ func main() {
 
 	logger := initialLogger.Sugar()
 
-	config, err := config.NewConfig(logger)
+	cfg, source, err := config.Load(os.Args[1:])
 	if err != nil {
 		logger.Fatalw("Could not load config", zap.Error(err))
 	}
 
-	producer := kafka.NewKafkaProducer([]string{config.Get("KAFKA_SERVICE_BASE_URL")}, "cache-server", true)
+	producer := kafkalog.NewWriter(cfg.KafkaBrokers, "cache-server")
+	defer producer.Close()
 
 	logger, err = logging.NewLogger(producer)
 	if err != nil {
@@ -37,19 +69,144 @@ func main() {
 	}
 	defer logger.Sync()
 
-	dbService := databaseservice.NewDatabaseService(config, logger)
+	logger.Infow("Loaded config", zap.Any("config", settings.Redacted(cfg)))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "cache-server", cfg.OtlpEndpoint)
+	if err != nil {
+		logger.Fatalw("Could not set up tracing", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
 
-	cacheService, err := cache.NewCache(config, logger)
+	tlsConfig, err := tlsconfig.Load(cfg.TlsFiles(), true, logger)
+	if err != nil {
+		logger.Fatalw("Could not load TLS certificates", zap.Error(err))
+	}
+	defer tlsConfig.Close()
+
+	var dbService databaseservice.DatabaseServiceInterface
+
+	switch cfg.ServiceTransport {
+	case "grpc":
+		conn, err := grpc.NewClient(cfg.DatabaseServiceGrpcAddr, tlsConfig.DialOption(), grpc.WithStatsHandler(otelgrpc.NewClientHandler()), grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(resilience.DefaultConfig("database-service-grpc"))))
+		if err != nil {
+			logger.Fatalw("Could not create database service client", zap.Error(err))
+		}
+		defer conn.Close()
+
+		dbService, err = databaseservice.NewGrpcDatabaseService(cfg, tlsConfig.Client, logger, conn)
+		if err != nil {
+			logger.Fatalw("Could not create database service client", zap.Error(err))
+		}
+	default:
+		dbService, err = databaseservice.NewDatabaseService(cfg, tlsConfig.Client, logger)
+		if err != nil {
+			logger.Fatalw("Could not create database service client", zap.Error(err))
+		}
+	}
+
+	cacheService, err := cache.New(cfg, logger)
 	if err != nil {
 		logger.Fatalw("Could not create cache service", zap.Error(err))
 	}
 	defer cacheService.Close()
 
-	handler := handlers.NewHandler(cacheService, logger, config, dbService)
+	ttls := cache.TTLs{Found: cfg.CacheTTL, NotFound: cfg.CacheNegativeTTL, JitterPercent: cfg.CacheTTLJitterPercent}
+	resolver := resolver.NewResolver(cacheService, dbService, ttls, cfg.EarlyRefreshWindow, logger)
+	warmDefaults := models.CacheWarmRequestModel{Source: cfg.CacheWarmSource, Limit: cfg.CacheWarmLimit, Days: cfg.CacheWarmDays}
+	warmer := warmer.NewWarmer(dbService, resolver, warmDefaults, cfg.CacheWarmConcurrency, cfg.CacheWarmRate, logger)
+	handler := handlers.NewHandler(cacheService, logger, cfg, resolver, warmer)
+
+	var timeouts atomic.Pointer[httpmiddleware.Timeouts]
+
+	initialTimeouts, err := httpmiddleware.ParseTimeouts(cfg.RequestTimeout, cfg.RouteTimeouts)
+	if err != nil {
+		logger.Fatalw("Invalid request timeouts", zap.Error(err))
+	}
+	timeouts.Store(&initialTimeouts)
+
+	source.Watch(logger, func(changed interface{}) {
+		reloaded := changed.(*config.Config)
+
+		reloadedTimeouts, err := httpmiddleware.ParseTimeouts(reloaded.RequestTimeout, reloaded.RouteTimeouts)
+		if err != nil {
+			logger.Errorw("Keeping the current request timeouts", zap.Error(err))
+			return
+		}
+		timeouts.Store(&reloadedTimeouts)
+	})
 
 	r := mux.NewRouter()
+	r.Use(httpmiddleware.TracingMiddleware, httpmiddleware.MetricsMiddleware(metrics.HTTPRequests, metrics.HTTPRequestDuration), httpmiddleware.TimeoutMiddleware(&timeouts))
 	r.HandleFunc("/redirect", handler.HandleRedirect).Methods(http.MethodPost)
+	r.HandleFunc("/cache", handler.HandleStore).Methods(http.MethodPut)
+	r.HandleFunc("/cache/flush", handler.HandleFlush).Methods(http.MethodPost)
+	r.HandleFunc("/cache/warm", handler.HandleWarm).Methods(http.MethodPost)
+	r.HandleFunc("/cache/{shorturlpath}", handler.HandleEvict).Methods(http.MethodDelete)
+
+	checks := health.Checks{"cache": cacheService.Ping}
 
-	http.Handle("/", middlewares.LoggingMiddleware(r))
-	logger.Error(http.ListenAndServe(":8082", nil))
+	grpcServer := grpc.NewServer(tlsConfig.ServerOption(), grpc.StatsHandler(otelgrpc.NewServerHandler()), grpc.UnaryInterceptor(middlewares.LoggingInterceptor))
+	pb.RegisterCacheServiceServer(grpcServer, grpcserver.NewServer(cacheService, logger, resolver))
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewGrpcServer(checks))
+
+	listener, err := net.Listen("tcp", cfg.GrpcListenAddr)
+	if err != nil {
+		logger.Fatalw("Could not listen for gRPC", zap.Error(err))
+	}
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Errorw("gRPC server failed", zap.Error(err))
+			stop()
+		}
+	}()
+
+	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/healthz", health.Live)
+	http.HandleFunc("/readyz", health.Ready(checks))
+	http.Handle("/", tlsConfig.RequireClientCert(middlewares.LoggingMiddleware(r)))
+
+	server := &http.Server{Addr: cfg.ListenAddr}
+
+	go func() {
+		if err := tlsConfig.ListenAndServe(server); !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorw("HTTP server failed", zap.Error(err))
+			stop()
+		}
+	}()
+
+	if cfg.CacheWarmOnStart {
+		if err := warmer.Start(ctx, warmDefaults, ""); err != nil {
+			logger.Errorw("Could not start cache warming", zap.Error(err))
+		}
+	}
+
+	<-ctx.Done()
+	logger.Infow("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorw("Could not finish in-flight requests", zap.Error(err))
+	}
+
+	stopGrpc(shutdownCtx, grpcServer)
+}
+
+// stopGrpc waits for the in-flight calls to finish, and cancels the ones still
+// running once ctx is done.
+func stopGrpc(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
 }
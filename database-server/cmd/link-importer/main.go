@@ -0,0 +1,135 @@
+// Command link-importer imports links exported from other shorteners
+// (YOURLS SQL or CSV dumps and Bitly CSV exports) into the links collection.
+//
+// Usage:
+//
+//	go run ./cmd/link-importer -source yourls-sql -file yourls.sql -dry-run
+//	go run ./cmd/link-importer -source bitly-csv -file bitly.csv -on-conflict rename
+//
+// The Mongo connection is read from config/app.env, the same file used by the
+// database server.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+	"url-shortner-database/internal/config"
+	"url-shortner-database/internal/database"
+	"url-shortner-database/internal/importer"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	source := flag.String("source", "", "format of the dump: yourls-sql, yourls-csv or bitly-csv")
+	file := flag.String("file", "", "path to the dump file")
+	table := flag.String("table", "yourls_url", "name of the YOURLS links table in a SQL dump")
+	dryRun := flag.Bool("dry-run", false, "report what would be imported without writing anything")
+	onConflict := flag.String("on-conflict", importer.ConflictSkip, "what to do when a short url path exists: skip, overwrite, rename or fail")
+	batchSize := flag.Int("batch-size", 500, "number of links written per batch")
+	owner := flag.String("owner", "", "owner to set on every imported link")
+	tags := flag.String("tags", "", "comma separated tags to add to every imported link")
+	expiry := flag.Duration("expiry", 365*24*time.Hour, "time until the imported links expire")
+	flag.Parse()
+
+	if *source == "" || *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	initialLogger, err := zap.NewDevelopment()
+
+	if err != nil {
+		panic(err)
+	}
+
+	logger := initialLogger.Sugar()
+	defer logger.Sync()
+
+	cfg, _, err := config.Load(nil)
+	if err != nil {
+		logger.Fatalw("Could not load config", zap.Error(err))
+	}
+
+	dump, err := os.Open(*file)
+	if err != nil {
+		logger.Fatalw("Could not open dump", zap.Error(err))
+	}
+	defer dump.Close()
+
+	reader, err := importer.NewSource(*source, dump, *table)
+	if err != nil {
+		logger.Fatalw("Could not read dump", zap.Error(err))
+	}
+
+	repository, err := database.New(cfg, logger)
+	if err != nil {
+		logger.Fatalw("Could not connect to database", zap.Error(err))
+	}
+	defer repository.Close()
+
+	extraTags := []string{}
+	if *tags != "" {
+		extraTags = strings.Split(*tags, ",")
+	}
+
+	linkImporter, err := importer.NewImporter(repository, logger, importer.Options{
+		DryRun:     *dryRun,
+		OnConflict: *onConflict,
+		BatchSize:  *batchSize,
+		Owner:      *owner,
+		Tags:       extraTags,
+		Expiry:     *expiry,
+	})
+	if err != nil {
+		logger.Fatalw("Invalid options", zap.Error(err))
+	}
+
+	// An interrupt stops the import after the record being read, so the
+	// report still covers everything written so far.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := linkImporter.Run(ctx, reader)
+
+	printReport(report, *dryRun)
+
+	if err != nil {
+		logger.Errorw("Import stopped", zap.Error(err))
+		repository.Close()
+		os.Exit(1)
+	}
+}
+
+func printReport(report *importer.Report, dryRun bool) {
+	if dryRun {
+		fmt.Println("Dry run, nothing was written.")
+	}
+
+	fmt.Printf("read: %d\ncreated: %d\noverwritten: %d\nrenamed: %d\nskipped: %d\ninvalid: %d\nfailed: %d\nclicks seeded: %d\n",
+		report.Read, report.Created, report.Overwritten, report.Renamed, report.Skipped, report.Invalid, report.Failed, report.Clicks)
+
+	if len(report.Renames) > 0 {
+		fmt.Println("renamed links:")
+
+		originals := make([]string, 0, len(report.Renames))
+		for original := range report.Renames {
+			originals = append(originals, original)
+		}
+		sort.Strings(originals)
+
+		for _, original := range originals {
+			fmt.Printf("  %s -> %s\n", original, report.Renames[original])
+		}
+	}
+
+	for _, message := range report.Errors {
+		fmt.Println(message)
+	}
+}
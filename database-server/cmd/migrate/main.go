@@ -0,0 +1,113 @@
+// Command migrate shows and applies the migrations of the links collection.
+// The database service applies pending migrations when it starts; run them
+// ahead of a deploy to keep long index builds out of its startup.
+//
+// Usage:
+//
+//	go run ./cmd/migrate plan
+//	go run ./cmd/migrate run
+//
+// The Mongo connection is read from config/app.env, the same file used by the
+// database server. The PostgreSQL and SQLite backends migrate their tables on
+// startup and have nothing to run here.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"url-shortner-database/internal/config"
+	"url-shortner-database/internal/migrations"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s plan|run\n\n  plan  list the pending migrations and their steps\n  run   apply the pending migrations\n", os.Args[0])
+	}
+	flag.Parse()
+
+	command := flag.Arg(0)
+
+	if flag.NArg() != 1 || (command != "plan" && command != "run") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	initialLogger, err := zap.NewDevelopment()
+
+	if err != nil {
+		panic(err)
+	}
+
+	logger := initialLogger.Sugar()
+	defer logger.Sync()
+
+	cfg, _, err := config.Load(nil)
+	if err != nil {
+		logger.Fatalw("Could not load config", zap.Error(err))
+	}
+
+	if cfg.DbBackend != "mongo" {
+		fmt.Printf("DB_BACKEND=%s migrates its tables when the database service starts.\n", cfg.DbBackend)
+		return
+	}
+
+	// An interrupt stops a run between steps; the migration it was applying
+	// stays pending and is applied again from its first step.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoUri).SetServerAPIOptions(options.ServerAPI(options.ServerAPIVersion1)))
+	if err != nil {
+		logger.Fatalw("Could not connect to mongo", zap.Error(err))
+	}
+	defer client.Disconnect(context.Background())
+
+	runner, _, err := migrations.NewMongoRunner(client.Database(cfg.DbName), cfg.CollectionName, cfg.AnalyticsCollectionName, logger)
+	if err != nil {
+		logger.Fatalw("Invalid migrations", zap.Error(err))
+	}
+
+	if command == "plan" {
+		pending, err := runner.Plan(ctx)
+		if err != nil {
+			logger.Fatalw("Could not read applied migrations", zap.Error(err))
+		}
+
+		printMigrations(pending, "pending")
+		return
+	}
+
+	applied, err := runner.Run(ctx)
+
+	printMigrations(applied, "applied")
+
+	if err != nil {
+		logger.Errorw("Migrations stopped", zap.Error(err))
+		client.Disconnect(context.Background())
+		os.Exit(1)
+	}
+}
+
+func printMigrations(list []migrations.Migration, state string) {
+	if len(list) == 0 {
+		fmt.Printf("No migrations %s.\n", state)
+		return
+	}
+
+	fmt.Printf("%d migrations %s:\n", len(list), state)
+
+	for _, migration := range list {
+		fmt.Printf("  %d: %s\n", migration.Version, migration.Description)
+
+		for _, step := range migration.Steps {
+			fmt.Printf("       - %s\n", step.Description)
+		}
+	}
+}
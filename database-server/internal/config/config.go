@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"url-shortner-api/settings"
+	"url-shortner-api/tlsconfig"
+)
+
+// Config is the configuration of the database service, read from
+// config/app.env, the environment and command line flags.
+type Config struct {
+	ListenAddr              string        `config:"LISTEN_ADDR" default:":8081"`
+	GrpcListenAddr          string        `config:"GRPC_LISTEN_ADDR" default:":9081"`
+	TlsCertFile             string        `config:"TLS_CERT_FILE"`
+	TlsKeyFile              string        `config:"TLS_KEY_FILE"`
+	TlsCaFile               string        `config:"TLS_CA_FILE"`
+	DbBackend               string        `config:"DB_BACKEND" default:"mongo"`
+	MongoUri                string        `config:"MONGO_URI" secret:"true"`
+	DbName                  string        `config:"DB_NAME" default:"url-shortener"`
+	CollectionName          string        `config:"COLLECTION_NAME" default:"urls"`
+	AnalyticsCollectionName string        `config:"ANALYTICS_COLLECTION_NAME" default:"clicks"`
+	PostgresUrl             string        `config:"POSTGRES_URL" secret:"true"`
+	SqlitePath              string        `config:"SQLITE_PATH" default:"url-shortener.db"`
+	ExpirySweepInterval     time.Duration `config:"EXPIRY_SWEEP_INTERVAL" default:"1m"`
+	DeletedRetention        time.Duration `config:"DELETED_RETENTION" default:"720h"`
+	PurgeInterval           time.Duration `config:"PURGE_INTERVAL" default:"1h"`
+	KafkaBrokers            []string      `config:"KAFKA_SERVICE_BASE_URL" default:"localhost:29092"`
+	RequestTimeout          time.Duration `config:"REQUEST_TIMEOUT" default:"10s" reload:"true"`
+	RouteTimeouts           string        `config:"ROUTE_TIMEOUTS" reload:"true"`
+	OtlpEndpoint            string        `config:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+}
+
+// Load reads and validates the config. args are the command line arguments
+// without the program name.
+func Load(args []string) (*Config, *settings.Source, error) {
+	config := &Config{}
+	source, err := settings.Load(config, args)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, source, nil
+}
+
+func (config *Config) Validate() error {
+	switch config.DbBackend {
+	case "mongo":
+		if config.MongoUri == "" {
+			return errors.New("MONGO_URI is required")
+		}
+	case "postgres":
+		if config.PostgresUrl == "" {
+			return errors.New("POSTGRES_URL is required with DB_BACKEND=postgres")
+		}
+	case "sqlite":
+		if config.SqlitePath == "" {
+			return errors.New("SQLITE_PATH is required with DB_BACKEND=sqlite")
+		}
+	default:
+		return fmt.Errorf("DB_BACKEND must be mongo, postgres or sqlite, got %q", config.DbBackend)
+	}
+
+	if config.ExpirySweepInterval < 0 {
+		return fmt.Errorf("EXPIRY_SWEEP_INTERVAL must not be negative, got %s", config.ExpirySweepInterval)
+	}
+
+	if config.DeletedRetention < 0 {
+		return fmt.Errorf("DELETED_RETENTION must not be negative, got %s", config.DeletedRetention)
+	}
+
+	if config.PurgeInterval < 0 {
+		return fmt.Errorf("PURGE_INTERVAL must not be negative, got %s", config.PurgeInterval)
+	}
+
+	return config.TlsFiles().Validate()
+}
+
+// TlsFiles are the certificate of the service and the CA of the main and
+// cache services, which must present a certificate it signed.
+func (config *Config) TlsFiles() tlsconfig.Files {
+	return tlsconfig.Files{CertFile: config.TlsCertFile, KeyFile: config.TlsKeyFile, CAFile: config.TlsCaFile}
+}
@@ -5,36 +5,63 @@ import (
 	"url-shortner-database/internal/config"
 
 	"github.com/stretchr/testify/assert"
-	"go.uber.org/zap"
 )
 
-func TestGet(t *testing.T) {
-	mockLogger := zap.NewNop().Sugar()
-	config, err := config.NewConfig(mockLogger)
-
-	if err != nil {
-		t.Fatalf("Error creating config: %v", err)
-	}
-
-	var tests = map[string]struct {
-		name     string
-		key      string
-		expected string
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		args             []string
+		ExpectedError    string
+		ExpectedBackend  string
+		ExpectedMongoUri string
 	}{
-		"NON_EXISTENT_KEY": {
-			key:      "ABC",
-			expected: "",
+		"Flags": {
+			args:             []string{"--mongo-uri", "mongodb://localhost:27017", "--collection-name", "links"},
+			ExpectedBackend:  "mongo",
+			ExpectedMongoUri: "mongodb://localhost:27017",
+		},
+		"Missing Mongo Uri": {
+			args:          []string{"--mongo-uri", ""},
+			ExpectedError: "MONGO_URI is required",
+		},
+		"Sqlite Without Mongo Uri": {
+			args:            []string{"--mongo-uri", "", "--db-backend", "sqlite", "--collection-name", "links"},
+			ExpectedBackend: "sqlite",
+		},
+		"Postgres Without Url": {
+			args:          []string{"--db-backend", "postgres"},
+			ExpectedError: "POSTGRES_URL is required with DB_BACKEND=postgres",
 		},
-		"EXISTENT_KEY": {
-			key:      "COLLECTION_NAME",
-			expected: "urls",
+		"Unknown Backend": {
+			args:          []string{"--mongo-uri", "mongodb://localhost:27017", "--db-backend", "redis"},
+			ExpectedError: `DB_BACKEND must be mongo, postgres or sqlite, got "redis"`,
+		},
+		"Negative Sweep Interval": {
+			args:          []string{"--db-backend", "sqlite", "--expiry-sweep-interval", "-1s"},
+			ExpectedError: "EXPIRY_SWEEP_INTERVAL must not be negative, got -1s",
+		},
+		"Negative Retention": {
+			args:          []string{"--db-backend", "sqlite", "--deleted-retention", "-1h"},
+			ExpectedError: "DELETED_RETENTION must not be negative, got -1h0m0s",
+		},
+		"Certificate Without Key": {
+			args:          []string{"--mongo-uri", "mongodb://localhost:27017", "--tls-cert-file", "tls.crt"},
+			ExpectedError: "TLS_CERT_FILE and TLS_KEY_FILE must be set together",
 		},
 	}
 
-	for tc, test := range tests {
-		t.Run(tc, func(t *testing.T) {
-			actual := config.Get(test.key)
-			assert.Equal(t, test.expected, actual, "Expected and actual values do not match")
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			loaded, _, err := config.Load(test.args)
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.ExpectedBackend, loaded.DbBackend)
+			assert.Equal(t, test.ExpectedMongoUri, loaded.MongoUri)
+			assert.Equal(t, "links", loaded.CollectionName)
 		})
 	}
 }
@@ -0,0 +1,355 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"url-shortner-database/internal/database"
+	"url-shortner-database/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRepository checks the behaviour every Repository shares. newRepository
+// returns an empty repository, called once per subtest.
+func testRepository(t *testing.T, newRepository func(t *testing.T) database.Repository) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	links := []models.URL{
+		{ShortUrlPath: "a", OriginalUrl: "http://a.com", Title: "Launch day", Tags: []string{"marketing"}, Owner: "growth", CreatedAt: now.Add(-4 * time.Hour), ExpiresAt: now.Add(2 * time.Hour)},
+		{ShortUrlPath: "b", OriginalUrl: "http://b.com", Title: "Pricing", Tags: []string{"marketing", "sales"}, Owner: "growth", CreatedAt: now.Add(-3 * time.Hour), ExpiresAt: now.Add(4 * time.Hour)},
+		{ShortUrlPath: "c", OriginalUrl: "http://c.com", Note: "launch checklist", Owner: "sales", CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(1 * time.Hour)},
+		{ShortUrlPath: "d", OriginalUrl: "http://d.com", Title: "Careers", CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(3 * time.Hour)},
+	}
+
+	seeded := func(t *testing.T) database.Repository {
+		repository := newRepository(t)
+
+		for _, link := range links {
+			assert.Nil(t, repository.Create(ctx, link))
+		}
+
+		return repository
+	}
+
+	paths := func(urls []models.URL) []string {
+		result := []string{}
+		for _, url := range urls {
+			result = append(result, url.ShortUrlPath)
+		}
+		return result
+	}
+
+	t.Run("Create And GetByCode", func(t *testing.T) {
+		repository := seeded(t)
+
+		url, err := repository.GetByCode(ctx, "b")
+		assert.Nil(t, err)
+		assert.Equal(t, "http://b.com", url.OriginalUrl)
+		assert.Equal(t, []string{"marketing", "sales"}, url.Tags)
+		assert.Equal(t, "growth", url.Owner)
+		assert.True(t, links[1].CreatedAt.Equal(url.CreatedAt))
+		assert.True(t, links[1].ExpiresAt.Equal(url.ExpiresAt))
+
+		_, err = repository.GetByCode(ctx, "missing")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+
+		err = repository.Create(ctx, models.URL{ShortUrlPath: "a", OriginalUrl: "http://other.com"})
+		assert.ErrorIs(t, err, database.ErrDuplicate)
+	})
+
+	t.Run("CreateMany", func(t *testing.T) {
+		repository := seeded(t)
+
+		errs, err := repository.CreateMany(ctx, []models.URL{
+			{ShortUrlPath: "e", OriginalUrl: "http://e.com"},
+			{ShortUrlPath: "a", OriginalUrl: "http://other.com"},
+			{ShortUrlPath: "f", OriginalUrl: "http://f.com"},
+		})
+		assert.Nil(t, err)
+		assert.Len(t, errs, 3)
+		assert.Nil(t, errs[0])
+		assert.ErrorIs(t, errs[1], database.ErrDuplicate)
+		assert.Nil(t, errs[2])
+
+		_, err = repository.GetByCode(ctx, "f")
+		assert.Nil(t, err, "links after a conflict are still created")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repository := seeded(t)
+
+		title, tags := "New title", []string{"new"}
+		url, err := repository.Update(ctx, "a", database.LinkUpdate{Title: &title, Tags: &tags})
+		assert.Nil(t, err)
+		assert.Equal(t, "New title", url.Title)
+		assert.Equal(t, []string{"new"}, url.Tags)
+		assert.Equal(t, "http://a.com", url.OriginalUrl, "fields left out are kept")
+
+		url, err = repository.GetByCode(ctx, "a")
+		assert.Nil(t, err)
+		assert.Equal(t, "New title", url.Title)
+
+		_, err = repository.Update(ctx, "missing", database.LinkUpdate{Title: &title})
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		repository := seeded(t)
+
+		assert.Nil(t, repository.Replace(ctx, models.URL{ShortUrlPath: "a", OriginalUrl: "http://replaced.com"}))
+		assert.Nil(t, repository.Replace(ctx, models.URL{ShortUrlPath: "new", OriginalUrl: "http://new.com"}))
+
+		url, err := repository.GetByCode(ctx, "a")
+		assert.Nil(t, err)
+		assert.Equal(t, "http://replaced.com", url.OriginalUrl)
+		assert.Empty(t, url.Title)
+
+		url, err = repository.GetByCode(ctx, "new")
+		assert.Nil(t, err)
+		assert.Equal(t, "http://new.com", url.OriginalUrl)
+	})
+
+	t.Run("Replace Deleted", func(t *testing.T) {
+		repository := seeded(t)
+
+		assert.Nil(t, repository.Delete(ctx, "a"))
+		assert.ErrorIs(t, repository.Replace(ctx, models.URL{ShortUrlPath: "a", OriginalUrl: "http://replaced.com"}), database.ErrDeleted)
+
+		url, err := repository.GetByCode(ctx, "a")
+		assert.Nil(t, err)
+		assert.True(t, url.Deleted(), "only Restore brings a deleted link back")
+		assert.NotEqual(t, "http://replaced.com", url.OriginalUrl)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repository := seeded(t)
+
+		assert.Nil(t, repository.Delete(ctx, "a"))
+		assert.ErrorIs(t, repository.Delete(ctx, "a"), database.ErrDeleted)
+		assert.ErrorIs(t, repository.Delete(ctx, "missing"), database.ErrNotFound)
+
+		url, err := repository.GetByCode(ctx, "a")
+		assert.Nil(t, err)
+		assert.True(t, url.Deleted())
+		assert.WithinDuration(t, time.Now(), url.DeletedAt, time.Minute)
+		assert.Equal(t, []string{"marketing"}, url.Tags, "deleted links keep their tags")
+
+		title := "New title"
+		_, err = repository.Update(ctx, "a", database.LinkUpdate{Title: &title})
+		assert.ErrorIs(t, err, database.ErrDeleted)
+
+		err = repository.Create(ctx, models.URL{ShortUrlPath: "a", OriginalUrl: "http://other.com"})
+		assert.ErrorIs(t, err, database.ErrDuplicate, "deleted links keep their short url path")
+
+		page, err := repository.List(ctx, database.LinkFilter{}, database.Page{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"b", "c", "d"}, paths(page.Links))
+
+		streamed := []models.URL{}
+		assert.Nil(t, repository.Stream(ctx, database.LinkFilter{Tag: "marketing"}, func(url models.URL) error {
+			streamed = append(streamed, url)
+			return nil
+		}))
+		assert.Equal(t, []string{"b"}, paths(streamed))
+
+		count, err := repository.CountByOwner(ctx, "growth")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		repository := seeded(t)
+		assert.Nil(t, repository.Delete(ctx, "a"))
+
+		_, err := repository.Restore(ctx, "a", time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, database.ErrDeleted, "links deleted before the window are not restored")
+
+		url, err := repository.Restore(ctx, "a", time.Now().Add(-time.Hour))
+		assert.Nil(t, err)
+		assert.False(t, url.Deleted())
+		assert.Equal(t, "http://a.com", url.OriginalUrl)
+		assert.Equal(t, []string{"marketing"}, url.Tags)
+
+		_, err = repository.Restore(ctx, "a", time.Now().Add(-time.Hour))
+		assert.ErrorIs(t, err, database.ErrNotDeleted)
+
+		_, err = repository.Restore(ctx, "missing", time.Now().Add(-time.Hour))
+		assert.ErrorIs(t, err, database.ErrNotFound)
+
+		page, err := repository.List(ctx, database.LinkFilter{Tag: "marketing"}, database.Page{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, paths(page.Links))
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		repository := seeded(t)
+		assert.Nil(t, repository.Delete(ctx, "a"))
+		assert.Nil(t, repository.Delete(ctx, "b"))
+		assert.Nil(t, repository.IncrementClicks(ctx, "a", now, 3))
+		assert.Nil(t, repository.IncrementClicks(ctx, "c", now, 2))
+
+		purged, err := repository.Purge(ctx, time.Now().Add(-time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), purged, "links deleted within the window are kept")
+
+		purged, err = repository.Purge(ctx, time.Now().Add(time.Minute))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), purged)
+
+		_, err = repository.GetByCode(ctx, "a")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+
+		_, err = repository.GetByCode(ctx, "c")
+		assert.Nil(t, err, "live links are not purged")
+
+		buckets, err := repository.FindClicks(ctx, "a")
+		assert.Nil(t, err)
+		assert.Empty(t, buckets, "the clicks of purged links go with them")
+
+		buckets, err = repository.FindClicks(ctx, "c")
+		assert.Nil(t, err)
+		assert.Len(t, buckets, 1)
+
+		assert.Nil(t, repository.Create(ctx, models.URL{ShortUrlPath: "a", OriginalUrl: "http://new.com"}), "purged short url paths can be taken again")
+	})
+
+	t.Run("List Filters", func(t *testing.T) {
+		repository := seeded(t)
+
+		tests := map[string]struct {
+			filter   database.LinkFilter
+			expected []string
+		}{
+			"Everything":    {filter: database.LinkFilter{}, expected: []string{"a", "b", "c", "d"}},
+			"Paths":         {filter: database.LinkFilter{ShortUrlPaths: []string{"d", "b", "missing"}}, expected: []string{"b", "d"}},
+			"Tag":           {filter: database.LinkFilter{Tag: "sales"}, expected: []string{"b"}},
+			"Owner":         {filter: database.LinkFilter{Owner: "growth"}, expected: []string{"a", "b"}},
+			"Query":         {filter: database.LinkFilter{Query: "launch"}, expected: []string{"a", "c"}},
+			"Created After": {filter: database.LinkFilter{CreatedAfter: now.Add(-3 * time.Hour)}, expected: []string{"c", "d"}},
+			"Combined":      {filter: database.LinkFilter{Tag: "marketing", Query: "launch"}, expected: []string{"a"}},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				page, err := repository.List(ctx, test.filter, database.Page{})
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, paths(page.Links))
+				assert.Empty(t, page.NextCursor)
+			})
+		}
+	})
+
+	t.Run("List Pages", func(t *testing.T) {
+		repository := seeded(t)
+
+		tests := map[string]struct {
+			page     database.Page
+			expected []string
+		}{
+			"Created At":            {page: database.Page{Sort: database.SortCreatedAt}, expected: []string{"a", "b", "c", "d"}},
+			"Created At Descending": {page: database.Page{Sort: database.SortCreatedAt, Descending: true}, expected: []string{"d", "c", "b", "a"}},
+			"Expires At":            {page: database.Page{Sort: database.SortExpiresAt}, expected: []string{"c", "a", "d", "b"}},
+			"Expires At Descending": {page: database.Page{Sort: database.SortExpiresAt, Descending: true}, expected: []string{"b", "d", "a", "c"}},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				all, err := repository.List(ctx, database.LinkFilter{}, test.page)
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, paths(all.Links))
+
+				// Walking the pages one link at a time visits every link once,
+				// in order, including the two created at the same time.
+				visited := []string{}
+				page := test.page
+				page.Limit = 1
+
+				for {
+					result, err := repository.List(ctx, database.LinkFilter{}, page)
+					assert.Nil(t, err)
+					visited = append(visited, paths(result.Links)...)
+
+					if result.NextCursor == "" || len(visited) > len(links) {
+						break
+					}
+
+					page.Cursor = result.NextCursor
+				}
+
+				assert.Equal(t, test.expected, visited)
+			})
+		}
+	})
+
+	t.Run("List Invalid Cursor", func(t *testing.T) {
+		repository := seeded(t)
+
+		_, err := repository.List(ctx, database.LinkFilter{}, database.Page{Cursor: "not a cursor!"})
+		assert.ErrorIs(t, err, database.ErrInvalidCursor)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		repository := seeded(t)
+
+		streamed := []models.URL{}
+		err := repository.Stream(ctx, database.LinkFilter{Owner: "growth"}, func(url models.URL) error {
+			streamed = append(streamed, url)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"a", "b"}, paths(streamed))
+
+		calls := 0
+		err = repository.Stream(ctx, database.LinkFilter{}, func(url models.URL) error {
+			calls++
+			return assert.AnError
+		})
+		assert.True(t, errors.Is(err, assert.AnError))
+		assert.Equal(t, 1, calls, "streaming stops at the first error")
+	})
+
+	t.Run("CountByOwner", func(t *testing.T) {
+		repository := seeded(t)
+
+		count, err := repository.CountByOwner(ctx, "growth")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), count)
+
+		count, err = repository.CountByOwner(ctx, "nobody")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Clicks", func(t *testing.T) {
+		repository := seeded(t)
+
+		today := now.Truncate(24 * time.Hour)
+		yesterday := today.AddDate(0, 0, -1)
+		lastMonth := today.AddDate(0, -1, 0)
+
+		assert.Nil(t, repository.IncrementClicks(ctx, "a", today.Add(time.Hour), 2))
+		assert.Nil(t, repository.IncrementClicks(ctx, "a", today.Add(2*time.Hour), 3))
+		assert.Nil(t, repository.IncrementClicks(ctx, "a", lastMonth, 100))
+		assert.Nil(t, repository.IncrementClicks(ctx, "b", yesterday, 7))
+		assert.Nil(t, repository.IncrementClicks(ctx, "c", today, 1))
+
+		buckets, err := repository.FindClicks(ctx, "a")
+		assert.Nil(t, err)
+		assert.Len(t, buckets, 2)
+		assert.True(t, lastMonth.Equal(buckets[0].Day), "oldest first")
+		assert.Equal(t, int64(100), buckets[0].Count)
+		assert.True(t, today.Equal(buckets[1].Day))
+		assert.Equal(t, int64(5), buckets[1].Count, "clicks of the same day share a bucket")
+
+		buckets, err = repository.FindClicks(ctx, "d")
+		assert.Nil(t, err)
+		assert.Empty(t, buckets)
+
+		top, err := repository.FindTopClicked(ctx, yesterday, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []models.ClickBucket{{ShortUrlPath: "b", Count: 7}, {ShortUrlPath: "a", Count: 5}}, top)
+	})
+}
@@ -2,12 +2,9 @@ package database_test
 
 import (
 	"testing"
-	"time"
 	"url-shortner-database/internal/database"
-	"url-shortner-database/internal/models"
 
 	"github.com/stretchr/testify/assert"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.uber.org/zap"
 )
 
@@ -16,6 +13,7 @@ var testStruct struct {
 	connectionString string
 	connectionDb     string
 	connectionColl   string
+	analyticsColl    string
 }
 
 func TestMain(m *testing.M) {
@@ -23,92 +21,44 @@ func TestMain(m *testing.M) {
 	testStruct.connectionString = "" // Enter a valid connection string
 	testStruct.connectionDb = "testDb"
 	testStruct.connectionColl = "test"
+	testStruct.analyticsColl = "test-clicks"
 	m.Run()
 }
 
-func TestNewDbConnection(t *testing.T) {
-	t.Run("Invalid Case", func(t *testing.T) {
-		_, err := database.NewDbConnection(testStruct.logger, "invalid", testStruct.connectionDb, testStruct.connectionColl)
-		assert.NotNil(t, err, "Error creating db connection")
-	})
-
-	t.Run("Valid Case", func(t *testing.T) {
-		db, err := database.NewDbConnection(testStruct.logger, testStruct.connectionString, testStruct.connectionDb, testStruct.connectionColl)
-		assert.Nil(t, err, "Error creating db connection")
-		db.DeleteDb(testStruct.connectionDb)
-		db.Disconnect()
-	})
-}
-
-func TestInsertOne(t *testing.T) {
-	db, err := database.NewDbConnection(testStruct.logger, testStruct.connectionString, testStruct.connectionDb, testStruct.connectionColl)
+func newMongoRepository(t *testing.T) database.Repository {
+	db, err := database.NewMongoRepository(testStruct.logger, testStruct.connectionString, testStruct.connectionDb, testStruct.connectionColl, testStruct.analyticsColl)
 
 	if err != nil {
 		t.Fatalf("Error creating db connection: %v", err)
 	}
 
-	document := models.URL{
-		ShortUrlPath: "test",
-		OriginalUrl:  "test",
-		ExpiresAt:    time.Now(),
-	}
-
-	err = db.InsertOne(document)
-	assert.Nil(t, err, "Error inserting document")
-
-	err = db.DeleteDb(testStruct.connectionDb)
-
-	if err != nil {
-		t.Fatalf("Error deleting database: %v", err)
-	}
+	t.Cleanup(func() {
+		if err := db.DeleteDb(testStruct.connectionDb); err != nil {
+			t.Fatalf("Error deleting database: %v", err)
+		}
 
-	err = db.Disconnect()
+		if err := db.Close(); err != nil {
+			t.Fatalf("Error disconnecting: %v", err)
+		}
+	})
 
-	if err != nil {
-		t.Fatalf("Error disconnecting: %v", err)
-	}
+	return db
 }
 
-func TestFindOne(t *testing.T) {
-	db, err := database.NewDbConnection(testStruct.logger, testStruct.connectionString, testStruct.connectionDb, testStruct.connectionColl)
-
-	if err != nil {
-		t.Fatalf("Error creating db connection: %v", err)
-	}
-
-	t.Run("Not found case", func(t *testing.T) {
-		filter := bson.D{{Key: "shortenedurl", Value: "testDb"}}
-		_, err := db.FindOne(filter)
-		assert.NotNil(t, err, "Error finding document")
+func TestNewMongoRepository(t *testing.T) {
+	t.Run("Invalid Case", func(t *testing.T) {
+		_, err := database.NewMongoRepository(testStruct.logger, "invalid", testStruct.connectionDb, testStruct.connectionColl, testStruct.analyticsColl)
+		assert.NotNil(t, err, "Error creating db connection")
 	})
 
-	document := models.URL{
-		ShortUrlPath: "test",
-		OriginalUrl:  "test",
-		ExpiresAt:    time.Now(),
-	}
-
-	err = db.InsertOne(document)
-
-	if err != nil {
-		t.Fatalf("Error inserting document: %v", err)
-	}
-
-	t.Run("Found case", func(t *testing.T) {
-		filter := bson.D{{Key: "shorturlpath", Value: "test"}}
-		_, err := db.FindOne(filter)
-		assert.Nil(t, err, "Error finding document")
+	t.Run("Valid Case", func(t *testing.T) {
+		db, err := database.NewMongoRepository(testStruct.logger, testStruct.connectionString, testStruct.connectionDb, testStruct.connectionColl, testStruct.analyticsColl)
+		assert.Nil(t, err, "Error creating db connection")
+		db.DeleteDb(testStruct.connectionDb)
+		db.Close()
 	})
+}
 
-	err = db.DeleteDb(testStruct.connectionDb)
-
-	if err != nil {
-		t.Fatalf("Error deleting database: %v", err)
-	}
-
-	err = db.Disconnect()
-
-	if err != nil {
-		t.Fatalf("Error disconnecting: %v", err)
-	}
+func TestMongoRepository(t *testing.T) {
+	testRepository(t, newMongoRepository)
 }
@@ -0,0 +1,377 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"url-shortner-database/internal/models"
+)
+
+// memoryRepository keeps links in memory. It backs the handler tests and
+// is not meant for production, as nothing is persisted and expired links are
+// never removed.
+type memoryRepository struct {
+	mu     sync.RWMutex
+	links  map[string]models.URL
+	clicks map[string]map[time.Time]int64
+}
+
+func NewMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		links:  map[string]models.URL{},
+		clicks: map[string]map[time.Time]int64{},
+	}
+}
+
+func (repository *memoryRepository) Create(ctx context.Context, url models.URL) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	return repository.create(url)
+}
+
+func (repository *memoryRepository) CreateMany(ctx context.Context, urls []models.URL) ([]error, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	errs := make([]error, len(urls))
+
+	for i, url := range urls {
+		errs[i] = repository.create(url)
+	}
+
+	return errs, nil
+}
+
+func (repository *memoryRepository) GetByCode(ctx context.Context, shortUrlPath string) (models.URL, error) {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+
+	url, ok := repository.links[shortUrlPath]
+
+	if !ok {
+		return models.URL{}, ErrNotFound
+	}
+
+	return clone(url), nil
+}
+
+func (repository *memoryRepository) Update(ctx context.Context, shortUrlPath string, update LinkUpdate) (models.URL, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	url, ok := repository.links[shortUrlPath]
+
+	if !ok {
+		return models.URL{}, ErrNotFound
+	}
+
+	if url.Deleted() {
+		return models.URL{}, ErrDeleted
+	}
+
+	if update.Url != nil {
+		url.OriginalUrl = *update.Url
+	}
+
+	if update.ExpiresAt != nil {
+		url.ExpiresAt = *update.ExpiresAt
+	}
+
+	if update.Title != nil {
+		url.Title = *update.Title
+	}
+
+	if update.Note != nil {
+		url.Note = *update.Note
+	}
+
+	if update.Tags != nil {
+		url.Tags = slices.Clone(*update.Tags)
+	}
+
+	repository.links[shortUrlPath] = url
+
+	return clone(url), nil
+}
+
+func (repository *memoryRepository) Replace(ctx context.Context, url models.URL) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	if current, ok := repository.links[url.ShortUrlPath]; ok && current.Deleted() {
+		return ErrDeleted
+	}
+
+	repository.links[url.ShortUrlPath] = clone(url)
+
+	return nil
+}
+
+func (repository *memoryRepository) Delete(ctx context.Context, shortUrlPath string) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	url, ok := repository.links[shortUrlPath]
+
+	if !ok {
+		return ErrNotFound
+	}
+
+	if url.Deleted() {
+		return ErrDeleted
+	}
+
+	url.DeletedAt = time.Now().UTC()
+	repository.links[shortUrlPath] = url
+
+	return nil
+}
+
+func (repository *memoryRepository) Restore(ctx context.Context, shortUrlPath string, deletedSince time.Time) (models.URL, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	url, ok := repository.links[shortUrlPath]
+
+	if !ok {
+		return models.URL{}, ErrNotFound
+	}
+
+	if !url.Deleted() {
+		return models.URL{}, ErrNotDeleted
+	}
+
+	if url.DeletedAt.Before(deletedSince) {
+		return models.URL{}, ErrDeleted
+	}
+
+	url.DeletedAt = time.Time{}
+	repository.links[shortUrlPath] = url
+
+	return clone(url), nil
+}
+
+func (repository *memoryRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	purged := int64(0)
+
+	for shortUrlPath, url := range repository.links {
+		if url.Deleted() && url.DeletedAt.Before(deletedBefore) {
+			delete(repository.links, shortUrlPath)
+			delete(repository.clicks, shortUrlPath)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+func (repository *memoryRepository) List(ctx context.Context, filter LinkFilter, page Page) (LinkPage, error) {
+	cursor, err := page.decodeCursor()
+
+	if err != nil {
+		return LinkPage{}, err
+	}
+
+	// after reports whether a link sorts after the cursor in the page order.
+	after := func(url models.URL) bool {
+		value := page.Sort.valueOf(url)
+
+		if page.Descending {
+			return value.Before(cursor.Value) || (value.Equal(cursor.Value) && url.ShortUrlPath < cursor.Key)
+		}
+
+		return value.After(cursor.Value) || (value.Equal(cursor.Value) && url.ShortUrlPath > cursor.Key)
+	}
+
+	results := []models.URL{}
+
+	for _, url := range repository.matching(filter) {
+		if cursor == nil || after(url) {
+			results = append(results, url)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := page.Sort.valueOf(results[i]), page.Sort.valueOf(results[j])
+
+		if !a.Equal(b) {
+			return a.Before(b) != page.Descending
+		}
+
+		return (results[i].ShortUrlPath < results[j].ShortUrlPath) != page.Descending
+	})
+
+	if page.Limit > 0 && len(results) > page.Limit+1 {
+		results = results[:page.Limit+1]
+	}
+
+	return newLinkPage(results, page)
+}
+
+func (repository *memoryRepository) Stream(ctx context.Context, filter LinkFilter, callback func(models.URL) error) error {
+	for _, url := range repository.matching(filter) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := callback(url); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repository *memoryRepository) CountByOwner(ctx context.Context, owner string) (int64, error) {
+	return int64(len(repository.matching(LinkFilter{Owner: owner}))), nil
+}
+
+func (repository *memoryRepository) IncrementClicks(ctx context.Context, shortUrlPath string, day time.Time, count int64) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	if repository.clicks[shortUrlPath] == nil {
+		repository.clicks[shortUrlPath] = map[time.Time]int64{}
+	}
+
+	repository.clicks[shortUrlPath][day.UTC().Truncate(24*time.Hour)] += count
+
+	return nil
+}
+
+func (repository *memoryRepository) FindClicks(ctx context.Context, shortUrlPath string) ([]models.ClickBucket, error) {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+
+	results := []models.ClickBucket{}
+
+	for day, count := range repository.clicks[shortUrlPath] {
+		results = append(results, models.ClickBucket{ShortUrlPath: shortUrlPath, Day: day, Count: count})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Day.Before(results[j].Day)
+	})
+
+	return results, nil
+}
+
+func (repository *memoryRepository) FindTopClicked(ctx context.Context, since time.Time, limit int64) ([]models.ClickBucket, error) {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+
+	since = since.UTC().Truncate(24 * time.Hour)
+	results := []models.ClickBucket{}
+
+	for shortUrlPath, days := range repository.clicks {
+		bucket := models.ClickBucket{ShortUrlPath: shortUrlPath}
+
+		for day, count := range days {
+			if !day.Before(since) {
+				bucket.Count += count
+			}
+		}
+
+		if bucket.Count > 0 {
+			results = append(results, bucket)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+
+		return results[i].ShortUrlPath < results[j].ShortUrlPath
+	})
+
+	if limit > 0 && int64(len(results)) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+func (repository *memoryRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (repository *memoryRepository) Close() error {
+	return nil
+}
+
+func (repository *memoryRepository) create(url models.URL) error {
+	if _, ok := repository.links[url.ShortUrlPath]; ok {
+		return ErrDuplicate
+	}
+
+	repository.links[url.ShortUrlPath] = clone(url)
+
+	return nil
+}
+
+// matching returns copies of the links matching filter, in no particular
+// order.
+func (repository *memoryRepository) matching(filter LinkFilter) []models.URL {
+	repository.mu.RLock()
+	defer repository.mu.RUnlock()
+
+	terms := strings.Fields(strings.ToLower(filter.Query))
+	results := []models.URL{}
+
+	for _, url := range repository.links {
+		if url.Deleted() {
+			continue
+		}
+
+		if len(filter.ShortUrlPaths) > 0 && !slices.Contains(filter.ShortUrlPaths, url.ShortUrlPath) {
+			continue
+		}
+
+		if filter.Tag != "" && !slices.Contains(url.Tags, filter.Tag) {
+			continue
+		}
+
+		if filter.Owner != "" && url.Owner != filter.Owner {
+			continue
+		}
+
+		if !filter.CreatedAfter.IsZero() && !url.CreatedAt.After(filter.CreatedAfter) {
+			continue
+		}
+
+		if len(terms) > 0 && !matchesAny(url, terms) {
+			continue
+		}
+
+		results = append(results, clone(url))
+	}
+
+	return results
+}
+
+// matchesAny is the in-memory take on a Mongo text search, which matches
+// links containing any of the terms.
+func matchesAny(url models.URL, terms []string) bool {
+	text := strings.ToLower(url.Title + " " + url.Note + " " + url.OriginalUrl)
+
+	for _, term := range terms {
+		if strings.Contains(text, term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func clone(url models.URL) models.URL {
+	url.Tags = slices.Clone(url.Tags)
+	return url
+}
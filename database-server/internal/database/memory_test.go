@@ -0,0 +1,36 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"url-shortner-database/internal/database"
+	"url-shortner-database/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	testRepository(t, func(t *testing.T) database.Repository {
+		return database.NewMemoryRepository()
+	})
+}
+
+func TestMemoryRepositoryCopies(t *testing.T) {
+	ctx := context.Background()
+	repository := database.NewMemoryRepository()
+
+	tags := []string{"marketing"}
+	assert.Nil(t, repository.Create(ctx, models.URL{ShortUrlPath: "a", Tags: tags}))
+
+	tags[0] = "changed"
+
+	url, err := repository.GetByCode(ctx, "a")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"marketing"}, url.Tags)
+
+	url.Tags[0] = "changed"
+
+	url, err = repository.GetByCode(ctx, "a")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"marketing"}, url.Tags, "links are not shared with callers")
+}
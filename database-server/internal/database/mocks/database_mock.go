@@ -1,65 +1,271 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: D:\Programming\Go\url-shortner\database-server\internal\database\conn.go
+// Source: internal/database/repository.go
 
 // Package mock_database is a generated GoMock package.
 package mock_database
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
+	database "url-shortner-database/internal/database"
 	models "url-shortner-database/internal/models"
 
 	gomock "github.com/golang/mock/gomock"
-	bson "go.mongodb.org/mongo-driver/bson"
 )
 
-// MockDBInterface is a mock of DBInterface interface.
-type MockDBInterface struct {
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockDBInterfaceMockRecorder
+	recorder *MockRepositoryMockRecorder
 }
 
-// MockDBInterfaceMockRecorder is the mock recorder for MockDBInterface.
-type MockDBInterfaceMockRecorder struct {
-	mock *MockDBInterface
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
 }
 
-// NewMockDBInterface creates a new mock instance.
-func NewMockDBInterface(ctrl *gomock.Controller) *MockDBInterface {
-	mock := &MockDBInterface{ctrl: ctrl}
-	mock.recorder = &MockDBInterfaceMockRecorder{mock}
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockDBInterface) EXPECT() *MockDBInterfaceMockRecorder {
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
-// FindOne mocks base method.
-func (m *MockDBInterface) FindOne(filter bson.D) (models.URL, error) {
+// Close mocks base method.
+func (m *MockRepository) Close() error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOne", filter)
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepositoryMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close))
+}
+
+// CountByOwner mocks base method.
+func (m *MockRepository) CountByOwner(ctx context.Context, owner string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByOwner", ctx, owner)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByOwner indicates an expected call of CountByOwner.
+func (mr *MockRepositoryMockRecorder) CountByOwner(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByOwner", reflect.TypeOf((*MockRepository)(nil).CountByOwner), ctx, owner)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, url models.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, url)
+}
+
+// CreateMany mocks base method.
+func (m *MockRepository) CreateMany(ctx context.Context, urls []models.URL) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, urls)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockRepositoryMockRecorder) CreateMany(ctx, urls interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockRepository)(nil).CreateMany), ctx, urls)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, shortUrlPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, shortUrlPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, shortUrlPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, shortUrlPath)
+}
+
+// FindClicks mocks base method.
+func (m *MockRepository) FindClicks(ctx context.Context, shortUrlPath string) ([]models.ClickBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClicks", ctx, shortUrlPath)
+	ret0, _ := ret[0].([]models.ClickBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClicks indicates an expected call of FindClicks.
+func (mr *MockRepositoryMockRecorder) FindClicks(ctx, shortUrlPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClicks", reflect.TypeOf((*MockRepository)(nil).FindClicks), ctx, shortUrlPath)
+}
+
+// FindTopClicked mocks base method.
+func (m *MockRepository) FindTopClicked(ctx context.Context, since time.Time, limit int64) ([]models.ClickBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTopClicked", ctx, since, limit)
+	ret0, _ := ret[0].([]models.ClickBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTopClicked indicates an expected call of FindTopClicked.
+func (mr *MockRepositoryMockRecorder) FindTopClicked(ctx, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTopClicked", reflect.TypeOf((*MockRepository)(nil).FindTopClicked), ctx, since, limit)
+}
+
+// GetByCode mocks base method.
+func (m *MockRepository) GetByCode(ctx context.Context, shortUrlPath string) (models.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", ctx, shortUrlPath)
+	ret0, _ := ret[0].(models.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockRepositoryMockRecorder) GetByCode(ctx, shortUrlPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockRepository)(nil).GetByCode), ctx, shortUrlPath)
+}
+
+// IncrementClicks mocks base method.
+func (m *MockRepository) IncrementClicks(ctx context.Context, shortUrlPath string, day time.Time, count int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementClicks", ctx, shortUrlPath, day, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementClicks indicates an expected call of IncrementClicks.
+func (mr *MockRepositoryMockRecorder) IncrementClicks(ctx, shortUrlPath, day, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementClicks", reflect.TypeOf((*MockRepository)(nil).IncrementClicks), ctx, shortUrlPath, day, count)
+}
+
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, filter database.LinkFilter, page database.Page) (database.LinkPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, page)
+	ret0, _ := ret[0].(database.LinkPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, filter, page)
+}
+
+// Ping mocks base method.
+func (m *MockRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepository)(nil).Ping), ctx)
+}
+
+// Purge mocks base method.
+func (m *MockRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockRepositoryMockRecorder) Purge(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockRepository)(nil).Purge), ctx, deletedBefore)
+}
+
+// Replace mocks base method.
+func (m *MockRepository) Replace(ctx context.Context, url models.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockRepositoryMockRecorder) Replace(ctx, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockRepository)(nil).Replace), ctx, url)
+}
+
+// Restore mocks base method.
+func (m *MockRepository) Restore(ctx context.Context, shortUrlPath string, deletedSince time.Time) (models.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, shortUrlPath, deletedSince)
 	ret0, _ := ret[0].(models.URL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindOne indicates an expected call of FindOne.
-func (mr *MockDBInterfaceMockRecorder) FindOne(filter interface{}) *gomock.Call {
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder) Restore(ctx, shortUrlPath, deletedSince interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockDBInterface)(nil).FindOne), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository)(nil).Restore), ctx, shortUrlPath, deletedSince)
 }
 
-// InsertOne mocks base method.
-func (m *MockDBInterface) InsertOne(document models.URL) error {
+// Stream mocks base method.
+func (m *MockRepository) Stream(ctx context.Context, filter database.LinkFilter, callback func(models.URL) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertOne", document)
+	ret := m.ctrl.Call(m, "Stream", ctx, filter, callback)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertOne indicates an expected call of InsertOne.
-func (mr *MockDBInterfaceMockRecorder) InsertOne(document interface{}) *gomock.Call {
+// Stream indicates an expected call of Stream.
+func (mr *MockRepositoryMockRecorder) Stream(ctx, filter, callback interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockRepository)(nil).Stream), ctx, filter, callback)
+}
+
+// Update mocks base method.
+func (m *MockRepository) Update(ctx context.Context, shortUrlPath string, update database.LinkUpdate) (models.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, shortUrlPath, update)
+	ret0, _ := ret[0].(models.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder) Update(ctx, shortUrlPath, update interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOne", reflect.TypeOf((*MockDBInterface)(nil).InsertOne), document)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, shortUrlPath, update)
 }
@@ -0,0 +1,497 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+	"url-shortner-database/internal/metrics"
+	"url-shortner-database/internal/migrations"
+	"url-shortner-database/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"go.uber.org/zap"
+)
+
+// sortKeys are the document keys of the sort fields.
+var sortKeys = map[SortField]string{
+	SortCreatedAt: "createdat",
+	SortExpiresAt: "expiresat",
+}
+
+type mongoRepository struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+	analytics  *mongo.Collection
+	logger     *zap.SugaredLogger
+}
+
+func NewMongoRepository(logger *zap.SugaredLogger, dbConnection string, dbName string, collectionName string, analyticsCollectionName string) (*mongoRepository, error) {
+	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
+	opts := options.Client().ApplyURI(dbConnection).SetServerAPIOptions(serverAPI).SetMonitor(otelmongo.NewMonitor())
+
+	client, err := mongo.Connect(context.TODO(), opts)
+
+	if err != nil {
+		logger.Errorw("Could not connect to mongo", zap.Error(err))
+		return nil, err
+	}
+
+	if err := client.Database(dbName).RunCommand(context.TODO(), bson.D{{Key: "ping", Value: 1}}).Err(); err != nil {
+		logger.Errorw("Could not ping database", zap.Error(err))
+		return nil, err
+	}
+
+	runner, collections, err := migrations.NewMongoRunner(client.Database(dbName), collectionName, analyticsCollectionName, logger)
+
+	if err == nil {
+		_, err = runner.Run(context.TODO())
+	}
+
+	if err != nil {
+		logger.Errorw("Could not migrate database", zap.Error(err))
+		client.Disconnect(context.TODO())
+		return nil, err
+	}
+
+	logger.Infow("Successfully established connection")
+
+	return &mongoRepository{
+		collection: collections.Links,
+		analytics:  collections.Clicks,
+		logger:     logger,
+		client:     client,
+	}, nil
+}
+
+func (repository *mongoRepository) Create(ctx context.Context, url models.URL) error {
+	start := time.Now()
+	_, err := repository.collection.InsertOne(ctx, url)
+	metrics.MongoOperationDuration.WithLabelValues("insert_one").Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		repository.logger.Errorw("Could not insert document", zap.Error(err), zap.Any("document", url))
+
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrDuplicate
+		}
+	}
+
+	return err
+}
+
+func (repository *mongoRepository) CreateMany(ctx context.Context, urls []models.URL) ([]error, error) {
+	errs := make([]error, len(urls))
+
+	if len(urls) == 0 {
+		return errs, nil
+	}
+
+	records := make([]interface{}, len(urls))
+	for i, url := range urls {
+		records[i] = url
+	}
+
+	_, err := repository.collection.InsertMany(ctx, records, options.InsertMany().SetOrdered(false))
+
+	if err == nil {
+		return errs, nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		repository.logger.Errorw("Could not insert documents", zap.Error(err))
+		return nil, err
+	}
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Index < 0 || writeErr.Index >= len(errs) {
+			continue
+		}
+
+		if mongo.IsDuplicateKeyError(writeErr) {
+			errs[writeErr.Index] = ErrDuplicate
+		} else {
+			errs[writeErr.Index] = writeErr
+		}
+	}
+
+	return errs, nil
+}
+
+func (repository *mongoRepository) GetByCode(ctx context.Context, shortUrlPath string) (models.URL, error) {
+	var result models.URL
+
+	start := time.Now()
+	err := repository.collection.FindOne(ctx, bson.D{{Key: "shorturlpath", Value: shortUrlPath}}).Decode(&result)
+	metrics.MongoOperationDuration.WithLabelValues("find_one").Observe(time.Since(start).Seconds())
+
+	if err == mongo.ErrNoDocuments {
+		return result, ErrNotFound
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Error retrieving documents", zap.Error(err))
+	}
+
+	return result, err
+}
+
+func (repository *mongoRepository) Update(ctx context.Context, shortUrlPath string, update LinkUpdate) (models.URL, error) {
+	var result models.URL
+
+	set := bson.D{}
+
+	if update.Url != nil {
+		set = append(set, bson.E{Key: "originalurl", Value: *update.Url})
+	}
+
+	if update.ExpiresAt != nil {
+		set = append(set, bson.E{Key: "expiresat", Value: *update.ExpiresAt})
+	}
+
+	if update.Title != nil {
+		set = append(set, bson.E{Key: "title", Value: *update.Title})
+	}
+
+	if update.Note != nil {
+		set = append(set, bson.E{Key: "note", Value: *update.Note})
+	}
+
+	if update.Tags != nil {
+		set = append(set, bson.E{Key: "tags", Value: *update.Tags})
+	}
+
+	filter := bson.D{{Key: "shorturlpath", Value: shortUrlPath}, {Key: "deletedat", Value: nil}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := repository.collection.FindOneAndUpdate(ctx, filter, bson.D{{Key: "$set", Value: set}}, opts).Decode(&result)
+
+	if err == mongo.ErrNoDocuments {
+		return result, notLive(ctx, repository, shortUrlPath)
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Could not update document", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+	}
+
+	return result, err
+}
+
+func (repository *mongoRepository) Replace(ctx context.Context, url models.URL) error {
+	filter := bson.D{{Key: "shorturlpath", Value: url.ShortUrlPath}, {Key: "deletedat", Value: nil}}
+
+	_, err := repository.collection.ReplaceOne(ctx, filter, url, options.Replace().SetUpsert(true))
+
+	// A deleted link does not match the filter, so the upsert collides
+	// with it on the unique short url path.
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDeleted
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Could not replace document", zap.Error(err), zap.Any("document", url))
+	}
+
+	return err
+}
+
+func (repository *mongoRepository) Delete(ctx context.Context, shortUrlPath string) error {
+	filter := bson.D{{Key: "shorturlpath", Value: shortUrlPath}, {Key: "deletedat", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "deletedat", Value: time.Now().UTC()}}}}
+
+	result, err := repository.collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		repository.logger.Errorw("Could not delete document", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return notLive(ctx, repository, shortUrlPath)
+	}
+
+	return nil
+}
+
+func (repository *mongoRepository) Restore(ctx context.Context, shortUrlPath string, deletedSince time.Time) (models.URL, error) {
+	var result models.URL
+
+	filter := bson.D{{Key: "shorturlpath", Value: shortUrlPath}, {Key: "deletedat", Value: bson.D{{Key: "$gte", Value: deletedSince}}}}
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "deletedat", Value: ""}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := repository.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+
+	if err == mongo.ErrNoDocuments {
+		return result, notRestorable(ctx, repository, shortUrlPath)
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Could not restore document", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+	}
+
+	return result, err
+}
+
+// Purge removes the links first and then the clicks of the short url paths
+// it found, so a failure leaves clicks behind rather than links without
+// their clicks.
+func (repository *mongoRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	filter := bson.D{{Key: "deletedat", Value: bson.D{{Key: "$lt", Value: deletedBefore}}}}
+	opts := options.Find().SetProjection(bson.D{{Key: "shorturlpath", Value: 1}})
+
+	purged, err := repository.find(ctx, filter, opts)
+
+	if err != nil || len(purged) == 0 {
+		return 0, err
+	}
+
+	shortUrlPaths := make([]string, len(purged))
+	for i, url := range purged {
+		shortUrlPaths[i] = url.ShortUrlPath
+	}
+
+	filter = append(filter, bson.E{Key: "shorturlpath", Value: bson.D{{Key: "$in", Value: shortUrlPaths}}})
+
+	result, err := repository.collection.DeleteMany(ctx, filter)
+
+	if err != nil {
+		repository.logger.Errorw("Could not purge documents", zap.Error(err))
+		return 0, err
+	}
+
+	if _, err := repository.analytics.DeleteMany(ctx, bson.D{{Key: "shorturlpath", Value: bson.D{{Key: "$in", Value: shortUrlPaths}}}}); err != nil {
+		repository.logger.Errorw("Could not purge clicks", zap.Error(err))
+		return result.DeletedCount, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (repository *mongoRepository) List(ctx context.Context, filter LinkFilter, page Page) (LinkPage, error) {
+	cursor, err := page.decodeCursor()
+
+	if err != nil {
+		return LinkPage{}, err
+	}
+
+	sortKey, direction := sortKeys[page.Sort], 1
+
+	if sortKey == "" {
+		sortKey = sortKeys[SortCreatedAt]
+	}
+
+	if page.Descending {
+		direction = -1
+	}
+
+	query := linkQuery(filter)
+
+	if cursor != nil {
+		operator := "$gt"
+		if page.Descending {
+			operator = "$lt"
+		}
+
+		query = append(query, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: sortKey, Value: bson.D{{Key: operator, Value: cursor.Value}}}},
+			bson.D{{Key: sortKey, Value: cursor.Value}, {Key: "shorturlpath", Value: bson.D{{Key: operator, Value: cursor.Key}}}},
+		}})
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: sortKey, Value: direction}, {Key: "shorturlpath", Value: direction}})
+
+	// One extra document tells whether another page exists.
+	if page.Limit > 0 {
+		opts.SetLimit(int64(page.Limit + 1))
+	}
+
+	results, err := repository.find(ctx, query, opts)
+
+	if err != nil {
+		return LinkPage{}, err
+	}
+
+	return newLinkPage(results, page)
+}
+
+func (repository *mongoRepository) Stream(ctx context.Context, filter LinkFilter, callback func(models.URL) error) error {
+	opts := options.Find().SetBatchSize(500)
+
+	cursor, err := repository.collection.Find(ctx, linkQuery(filter), opts)
+
+	if err != nil {
+		repository.logger.Errorw("Error retrieving documents", zap.Error(err))
+		return err
+	}
+
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var result models.URL
+
+		if err := cursor.Decode(&result); err != nil {
+			repository.logger.Errorw("Error decoding document", zap.Error(err))
+			return err
+		}
+
+		if err := callback(result); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func (repository *mongoRepository) CountByOwner(ctx context.Context, owner string) (int64, error) {
+	count, err := repository.collection.CountDocuments(ctx, linkQuery(LinkFilter{Owner: owner}))
+
+	if err != nil {
+		repository.logger.Errorw("Error counting documents", zap.Error(err), zap.String("owner", owner))
+	}
+
+	return count, err
+}
+
+func (repository *mongoRepository) IncrementClicks(ctx context.Context, shortUrlPath string, day time.Time, count int64) error {
+	filter := bson.D{
+		{Key: "shorturlpath", Value: shortUrlPath},
+		{Key: "day", Value: day.UTC().Truncate(24 * time.Hour)},
+	}
+	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "count", Value: count}}}}
+
+	_, err := repository.analytics.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+
+	if err != nil {
+		repository.logger.Errorw("Could not increment clicks", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+	}
+
+	return err
+}
+
+func (repository *mongoRepository) FindClicks(ctx context.Context, shortUrlPath string) ([]models.ClickBucket, error) {
+	filter := bson.D{{Key: "shorturlpath", Value: shortUrlPath}}
+	opts := options.Find().SetSort(bson.D{{Key: "day", Value: 1}})
+
+	cursor, err := repository.analytics.Find(ctx, filter, opts)
+
+	if err != nil {
+		repository.logger.Errorw("Error retrieving clicks", zap.Error(err))
+		return nil, err
+	}
+
+	results := []models.ClickBucket{}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		repository.logger.Errorw("Error decoding clicks", zap.Error(err))
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (repository *mongoRepository) FindTopClicked(ctx context.Context, since time.Time, limit int64) ([]models.ClickBucket, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "day", Value: bson.D{{Key: "$gte", Value: since.UTC().Truncate(24 * time.Hour)}}}}}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$shorturlpath"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: "$count"}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.D{{Key: "_id", Value: 0}, {Key: "shorturlpath", Value: "$_id"}, {Key: "count", Value: 1}}}},
+	}
+
+	start := time.Now()
+	cursor, err := repository.analytics.Aggregate(ctx, pipeline)
+	metrics.MongoOperationDuration.WithLabelValues("aggregate_top_clicked").Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		repository.logger.Errorw("Error aggregating clicks", zap.Error(err))
+		return nil, err
+	}
+
+	results := []models.ClickBucket{}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		repository.logger.Errorw("Error decoding clicks", zap.Error(err))
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Ping checks that the primary of the replica set can be reached.
+func (repository *mongoRepository) Ping(ctx context.Context) error {
+	return repository.client.Ping(ctx, readpref.Primary())
+}
+
+func (repository *mongoRepository) Close() error {
+	err := repository.client.Disconnect(context.TODO())
+
+	if err != nil {
+		repository.logger.Errorw("Could not disconnect from database", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (repository *mongoRepository) DeleteDb(databaseName string) error {
+	err := repository.client.Database(databaseName).Drop(context.Background())
+
+	if err != nil {
+		repository.logger.Errorw("Could not drop database", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (repository *mongoRepository) find(ctx context.Context, query bson.D, opts *options.FindOptions) ([]models.URL, error) {
+	cursor, err := repository.collection.Find(ctx, query, opts)
+
+	if err != nil {
+		repository.logger.Errorw("Error retrieving documents", zap.Error(err))
+		return nil, err
+	}
+
+	results := []models.URL{}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		repository.logger.Errorw("Error decoding documents", zap.Error(err))
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// linkQuery is the Mongo query of a filter, which never matches deleted
+// links.
+func linkQuery(filter LinkFilter) bson.D {
+	query := bson.D{{Key: "deletedat", Value: nil}}
+
+	if len(filter.ShortUrlPaths) > 0 {
+		query = append(query, bson.E{Key: "shorturlpath", Value: bson.D{{Key: "$in", Value: filter.ShortUrlPaths}}})
+	}
+
+	if filter.Tag != "" {
+		query = append(query, bson.E{Key: "tags", Value: filter.Tag})
+	}
+
+	if filter.Owner != "" {
+		query = append(query, bson.E{Key: "owner", Value: filter.Owner})
+	}
+
+	if filter.Query != "" {
+		query = append(query, bson.E{Key: "$text", Value: bson.D{{Key: "$search", Value: filter.Query}}})
+	}
+
+	if !filter.CreatedAfter.IsZero() {
+		query = append(query, bson.E{Key: "createdat", Value: bson.D{{Key: "$gt", Value: filter.CreatedAfter}}})
+	}
+
+	return query
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"time"
+	"url-shortner-database/internal/metrics"
+
+	"go.uber.org/zap"
+)
+
+// RunPurger removes the links deleted more than retention ago every interval
+// until ctx is done. Until then they can be restored.
+func RunPurger(ctx context.Context, repository Repository, retention time.Duration, interval time.Duration, logger *zap.SugaredLogger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := repository.Purge(ctx, time.Now().Add(-retention))
+
+		if err != nil {
+			logger.Errorw("Could not purge deleted links", zap.Error(err))
+			continue
+		}
+
+		if purged > 0 {
+			metrics.LinksPurged.Add(float64(purged))
+			logger.Infow("Purged deleted links", zap.Int64("count", purged))
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"url-shortner-database/internal/database"
+	"url-shortner-database/internal/metrics"
+	"url-shortner-database/internal/models"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRunPurger(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repository := database.NewMemoryRepository()
+	assert.Nil(t, repository.Create(ctx, models.URL{ShortUrlPath: "old", DeletedAt: time.Now().Add(-2 * time.Hour)}))
+	assert.Nil(t, repository.Create(ctx, models.URL{ShortUrlPath: "recent", DeletedAt: time.Now()}))
+	assert.Nil(t, repository.Create(ctx, models.URL{ShortUrlPath: "live"}))
+
+	purged := testutil.ToFloat64(metrics.LinksPurged)
+
+	go database.RunPurger(ctx, repository, time.Hour, 10*time.Millisecond, zap.NewNop().Sugar())
+
+	assert.Eventually(t, func() bool {
+		_, err := repository.GetByCode(ctx, "old")
+		return err == database.ErrNotFound
+	}, time.Second, 10*time.Millisecond)
+
+	for _, shortUrlPath := range []string{"recent", "live"} {
+		_, err := repository.GetByCode(ctx, shortUrlPath)
+		assert.Nil(t, err, shortUrlPath)
+	}
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.LinksPurged) == purged+1
+	}, time.Second, 10*time.Millisecond)
+}
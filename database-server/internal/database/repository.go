@@ -0,0 +1,222 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+	"url-shortner-database/internal/config"
+	"url-shortner-database/internal/metrics"
+	"url-shortner-database/internal/models"
+	"url-shortner-database/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+// maxKeyAttempts bounds the short url paths generated for one link before
+// CreateWithRetry gives up on collisions.
+const maxKeyAttempts = 5
+
+var (
+	ErrDuplicate     = errors.New("duplicate short url path")
+	ErrNotFound      = errors.New("short url path not found")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrDeleted       = errors.New("short url path deleted")
+	ErrNotDeleted    = errors.New("short url path not deleted")
+)
+
+// Repository stores links and their daily clicks. Implementations return
+// ErrNotFound for a short url path that does not exist and ErrDuplicate for
+// one that already does, whatever their store reports.
+//
+// Deleted links are kept, holding on to their short url path, until they are
+// purged. GetByCode returns them with DeletedAt set, Update and Delete fail
+// on them with ErrDeleted, and List, Stream and CountByOwner leave them out.
+type Repository interface {
+	Create(ctx context.Context, url models.URL) error
+	// CreateMany creates the links without stopping at the first failure. The
+	// returned slice holds the error for each link, in input order.
+	CreateMany(ctx context.Context, urls []models.URL) ([]error, error)
+	GetByCode(ctx context.Context, shortUrlPath string) (models.URL, error)
+	// Update changes the fields set in update and returns the updated link.
+	Update(ctx context.Context, shortUrlPath string, update LinkUpdate) (models.URL, error)
+	// Replace replaces the link with the same short url path, creating it if
+	// it does not exist yet. It returns ErrDeleted for a deleted link, which
+	// only Restore brings back.
+	Replace(ctx context.Context, url models.URL) error
+	// Delete marks a link deleted.
+	Delete(ctx context.Context, shortUrlPath string) error
+	// Restore revives a link deleted at or after deletedSince and returns it.
+	// It returns ErrNotDeleted for a link that is not deleted and ErrDeleted
+	// for one deleted before deletedSince.
+	Restore(ctx context.Context, shortUrlPath string, deletedSince time.Time) (models.URL, error)
+	// Purge removes the links deleted before deletedBefore along with their
+	// clicks, and returns how many links it removed.
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// List returns a page of the links matching filter, and the cursor of the
+	// next page if there is one.
+	List(ctx context.Context, filter LinkFilter, page Page) (LinkPage, error)
+	// Stream calls callback with every link matching filter, stopping at the
+	// first error.
+	Stream(ctx context.Context, filter LinkFilter, callback func(models.URL) error) error
+	CountByOwner(ctx context.Context, owner string) (int64, error)
+	// IncrementClicks adds count to the daily click bucket of a link.
+	IncrementClicks(ctx context.Context, shortUrlPath string, day time.Time, count int64) error
+	// FindClicks returns the daily click buckets of a link, oldest first.
+	FindClicks(ctx context.Context, shortUrlPath string) ([]models.ClickBucket, error)
+	// FindTopClicked returns the links clicked most since a day, most clicked
+	// first, as one bucket per link holding its clicks over the whole period.
+	FindTopClicked(ctx context.Context, since time.Time, limit int64) ([]models.ClickBucket, error)
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// New returns the repository selected by DB_BACKEND.
+func New(config *config.Config, logger *zap.SugaredLogger) (Repository, error) {
+	switch config.DbBackend {
+	case "postgres":
+		return NewPostgresRepository(logger, config.PostgresUrl, config.ExpirySweepInterval)
+	case "sqlite":
+		return NewSqliteRepository(logger, config.SqlitePath, config.ExpirySweepInterval)
+	default:
+		return NewMongoRepository(logger, config.MongoUri, config.DbName, config.CollectionName, config.AnalyticsCollectionName)
+	}
+}
+
+// LinkFilter selects links. Empty fields match every link.
+type LinkFilter struct {
+	ShortUrlPaths []string
+	Tag           string
+	Owner         string
+	// Query is a full text search of the title, note and url.
+	Query        string
+	CreatedAfter time.Time
+}
+
+type SortField string
+
+const (
+	SortCreatedAt SortField = "created_at"
+	SortExpiresAt SortField = "expires_at"
+)
+
+// Page selects a page of links, sorted by Sort and then by short url path.
+// Cursor is the NextCursor of the previous page, empty for the first one.
+type Page struct {
+	Sort       SortField
+	Descending bool
+	Cursor     string
+	Limit      int
+}
+
+type LinkPage struct {
+	Links      []models.URL
+	NextCursor string
+}
+
+// LinkUpdate holds the fields of a link to change. Nil fields are left as
+// they are.
+type LinkUpdate struct {
+	Url       *string
+	ExpiresAt *time.Time
+	Title     *string
+	Note      *string
+	Tags      *[]string
+}
+
+func (update LinkUpdate) IsEmpty() bool {
+	return update.Url == nil && update.ExpiresAt == nil && update.Title == nil && update.Note == nil && update.Tags == nil
+}
+
+func (field SortField) valueOf(url models.URL) time.Time {
+	if field == SortExpiresAt {
+		return url.ExpiresAt
+	}
+
+	return url.CreatedAt
+}
+
+// decodeCursor reads the cursor of a page, nil for the first page.
+func (page Page) decodeCursor() (*utils.Cursor, error) {
+	if page.Cursor == "" {
+		return nil, nil
+	}
+
+	cursor, err := utils.DecodeCursor(page.Cursor)
+
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// newLinkPage cuts the links, read one past the limit of the page, to the
+// page and points the next cursor at its last link if there are more.
+func newLinkPage(urls []models.URL, page Page) (LinkPage, error) {
+	if page.Limit <= 0 || len(urls) <= page.Limit {
+		return LinkPage{Links: urls}, nil
+	}
+
+	urls = urls[:page.Limit]
+	last := urls[len(urls)-1]
+
+	next, err := utils.EncodeCursor(utils.Cursor{Value: page.Sort.valueOf(last), Key: last.ShortUrlPath})
+
+	if err != nil {
+		return LinkPage{}, err
+	}
+
+	return LinkPage{Links: urls, NextCursor: next}, nil
+}
+
+// CreateWithRetry creates a link, generating a new short url path from seed
+// each time the current one is taken. The path the link was created with is
+// left in url.
+func CreateWithRetry(ctx context.Context, repository Repository, url *models.URL, seed string) error {
+	var err error
+
+	for range maxKeyAttempts {
+		err = repository.Create(ctx, *url)
+
+		if !errors.Is(err, ErrDuplicate) {
+			return err
+		}
+
+		metrics.KeyGenerationRetries.Inc()
+		url.ShortUrlPath = utils.KeyGenerationService(seed)
+	}
+
+	return err
+}
+
+// notLive tells why a write to the live link with a short url path matched
+// nothing: the link is deleted or does not exist.
+func notLive(ctx context.Context, repository Repository, shortUrlPath string) error {
+	url, err := repository.GetByCode(ctx, shortUrlPath)
+
+	if err != nil {
+		return err
+	}
+
+	if url.Deleted() {
+		return ErrDeleted
+	}
+
+	return ErrNotFound
+}
+
+// notRestorable tells why a restore of a short url path matched nothing: the
+// link does not exist, is not deleted or was deleted too long ago.
+func notRestorable(ctx context.Context, repository Repository, shortUrlPath string) error {
+	url, err := repository.GetByCode(ctx, shortUrlPath)
+
+	if err != nil {
+		return err
+	}
+
+	if !url.Deleted() {
+		return ErrNotDeleted
+	}
+
+	return ErrDeleted
+}
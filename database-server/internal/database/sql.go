@@ -0,0 +1,912 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"url-shortner-database/internal/models"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// streamBatch is the number of links Stream reads at a time.
+const streamBatch = 500
+
+// sortColumns are the columns of the sort fields.
+var sortColumns = map[SortField]string{
+	SortCreatedAt: "created_at",
+	SortExpiresAt: "expires_at",
+}
+
+var tracer = otel.Tracer("url-shortner-database/internal/database")
+
+const linkColumns = "shorturlpath, originalurl, title, note, owner, created_at, expires_at, deleted_at"
+
+// dialect holds what differs between the SQL databases.
+type dialect struct {
+	name string
+	// system is the db.system attribute of the spans.
+	system attribute.KeyValue
+	// migrations are the statements of each schema version, oldest first.
+	// A version is applied in one transaction and never changed once
+	// released; changes to the schema go into a new version.
+	migrations [][]string
+	// migrationLock is run first in every migration transaction to keep other
+	// processes from migrating at the same time, if the database needs it.
+	migrationLock string
+	// numbered databases take $1, $2... instead of ? as placeholders.
+	numbered    bool
+	isDuplicate func(err error) bool
+}
+
+// sqlRepository stores links in a SQL database. Times are stored as Unix
+// milliseconds, the precision Mongo keeps, so they compare the same way in
+// every database. Expired links are deleted by a sweeper, as Mongo does with
+// its TTL index.
+type sqlRepository struct {
+	db      *sql.DB
+	dialect dialect
+	logger  *zap.SugaredLogger
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// newSqlRepository migrates the schema and starts sweeping expired links
+// every sweepInterval, or never if it is 0.
+func newSqlRepository(db *sql.DB, dialect dialect, sweepInterval time.Duration, logger *zap.SugaredLogger) (*sqlRepository, error) {
+	repository := &sqlRepository{
+		db:      db,
+		dialect: dialect,
+		logger:  logger,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	if err := db.PingContext(context.TODO()); err != nil {
+		logger.Errorw("Could not ping database", zap.String("backend", dialect.name), zap.Error(err))
+		db.Close()
+		return nil, err
+	}
+
+	if err := repository.migrate(context.TODO()); err != nil {
+		logger.Errorw("Could not migrate database", zap.String("backend", dialect.name), zap.Error(err))
+		db.Close()
+		return nil, err
+	}
+
+	if sweepInterval > 0 {
+		go repository.sweep(sweepInterval)
+	} else {
+		close(repository.stopped)
+	}
+
+	logger.Infow("Successfully established connection", zap.String("backend", dialect.name))
+
+	return repository, nil
+}
+
+// migrate applies the schema versions newer than the one recorded in
+// schema_migrations, one transaction per version.
+func (repository *sqlRepository) migrate(ctx context.Context) error {
+	for {
+		version, err := repository.migrateNext(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if version == 0 {
+			return nil
+		}
+
+		repository.logger.Infow("Migrated database", zap.String("backend", repository.dialect.name), zap.Int("version", version))
+	}
+}
+
+// migrateNext applies the schema version after the one recorded in
+// schema_migrations and returns it, or 0 if the schema is up to date. The
+// recorded version is read inside the transaction, after taking the migration
+// lock of the dialect, if it has one, so replicas starting together apply each version once.
+func (repository *sqlRepository) migrateNext(ctx context.Context) (int, error) {
+	version := 0
+
+	err := repository.inTx(ctx, func(tx *sql.Tx) error {
+		if repository.dialect.migrationLock != "" {
+			if _, err := tx.ExecContext(ctx, repository.dialect.migrationLock); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY, applied_at BIGINT NOT NULL)"); err != nil {
+			return err
+		}
+
+		var current int
+
+		if err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+			return err
+		}
+
+		if current > len(repository.dialect.migrations) {
+			return fmt.Errorf("database schema version %d is newer than the latest known version %d", current, len(repository.dialect.migrations))
+		}
+
+		if current == len(repository.dialect.migrations) {
+			return nil
+		}
+
+		for _, statement := range repository.dialect.migrations[current] {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("schema version %d: %w", current+1, err)
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, repository.rebind("INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)"), current+1, time.Now().UnixMilli()); err != nil {
+			return fmt.Errorf("schema version %d: %w", current+1, err)
+		}
+
+		version = current + 1
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (repository *sqlRepository) Create(ctx context.Context, url models.URL) error {
+	ctx, span := repository.startSpan(ctx, "Create")
+	defer span.End()
+
+	err := repository.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, repository.rebind("INSERT INTO links ("+linkColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)"),
+			url.ShortUrlPath, url.OriginalUrl, url.Title, url.Note, url.Owner, toMillis(url.CreatedAt), toMillis(url.ExpiresAt), toMillis(url.DeletedAt))
+
+		if err != nil {
+			return err
+		}
+
+		return repository.insertTags(ctx, tx, url.ShortUrlPath, url.Tags)
+	})
+
+	if repository.dialect.isDuplicate(err) {
+		return ErrDuplicate
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Could not insert link", zap.Error(err), zap.Any("document", url))
+	}
+
+	return err
+}
+
+func (repository *sqlRepository) CreateMany(ctx context.Context, urls []models.URL) ([]error, error) {
+	ctx, span := repository.startSpan(ctx, "CreateMany")
+	defer span.End()
+
+	errs := make([]error, len(urls))
+
+	for i, url := range urls {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		errs[i] = repository.Create(ctx, url)
+	}
+
+	return errs, nil
+}
+
+func (repository *sqlRepository) GetByCode(ctx context.Context, shortUrlPath string) (models.URL, error) {
+	ctx, span := repository.startSpan(ctx, "GetByCode")
+	defer span.End()
+
+	row := repository.db.QueryRowContext(ctx, repository.rebind("SELECT "+linkColumns+" FROM links WHERE shorturlpath = ?"), shortUrlPath)
+
+	url, err := scanLink(row)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.URL{}, ErrNotFound
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Error retrieving link", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+		return models.URL{}, err
+	}
+
+	urls := []models.URL{url}
+
+	if err := repository.loadTags(ctx, urls); err != nil {
+		return models.URL{}, err
+	}
+
+	return urls[0], nil
+}
+
+func (repository *sqlRepository) Update(ctx context.Context, shortUrlPath string, update LinkUpdate) (models.URL, error) {
+	ctx, span := repository.startSpan(ctx, "Update")
+	defer span.End()
+
+	columns, args := []string{}, []interface{}{}
+
+	if update.Url != nil {
+		columns, args = append(columns, "originalurl = ?"), append(args, *update.Url)
+	}
+
+	if update.ExpiresAt != nil {
+		columns, args = append(columns, "expires_at = ?"), append(args, toMillis(*update.ExpiresAt))
+	}
+
+	if update.Title != nil {
+		columns, args = append(columns, "title = ?"), append(args, *update.Title)
+	}
+
+	if update.Note != nil {
+		columns, args = append(columns, "note = ?"), append(args, *update.Note)
+	}
+
+	err := repository.inTx(ctx, func(tx *sql.Tx) error {
+		var found bool
+
+		if len(columns) > 0 {
+			result, err := tx.ExecContext(ctx, repository.rebind("UPDATE links SET "+strings.Join(columns, ", ")+" WHERE shorturlpath = ? AND deleted_at = 0"), append(args, shortUrlPath)...)
+
+			if err != nil {
+				return err
+			}
+
+			affected, err := result.RowsAffected()
+
+			if err != nil {
+				return err
+			}
+
+			found = affected > 0
+		} else {
+			err := tx.QueryRowContext(ctx, repository.rebind("SELECT COUNT(*) > 0 FROM links WHERE shorturlpath = ? AND deleted_at = 0"), shortUrlPath).Scan(&found)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if !found {
+			return ErrNotFound
+		}
+
+		if update.Tags == nil {
+			return nil
+		}
+
+		return repository.replaceTags(ctx, tx, shortUrlPath, *update.Tags)
+	})
+
+	// The link is looked up outside the transaction, which may hold the
+	// only connection.
+	if errors.Is(err, ErrNotFound) {
+		return models.URL{}, notLive(ctx, repository, shortUrlPath)
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Could not update link", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+		return models.URL{}, err
+	}
+
+	return repository.GetByCode(ctx, shortUrlPath)
+}
+
+func (repository *sqlRepository) Replace(ctx context.Context, url models.URL) error {
+	ctx, span := repository.startSpan(ctx, "Replace")
+	defer span.End()
+
+	err := repository.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, repository.rebind("INSERT INTO links ("+linkColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT (shorturlpath) DO UPDATE SET originalurl = excluded.originalurl, title = excluded.title, note = excluded.note, "+
+			"owner = excluded.owner, created_at = excluded.created_at, expires_at = excluded.expires_at, deleted_at = excluded.deleted_at "+
+			"WHERE links.deleted_at = 0"),
+			url.ShortUrlPath, url.OriginalUrl, url.Title, url.Note, url.Owner, toMillis(url.CreatedAt), toMillis(url.ExpiresAt), toMillis(url.DeletedAt))
+
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+
+		if err != nil {
+			return err
+		}
+
+		// The row of a deleted link is neither inserted nor updated.
+		if affected == 0 {
+			return ErrDeleted
+		}
+
+		return repository.replaceTags(ctx, tx, url.ShortUrlPath, url.Tags)
+	})
+
+	if err != nil && !errors.Is(err, ErrDeleted) {
+		repository.logger.Errorw("Could not replace link", zap.Error(err), zap.Any("document", url))
+	}
+
+	return err
+}
+
+func (repository *sqlRepository) Delete(ctx context.Context, shortUrlPath string) error {
+	ctx, span := repository.startSpan(ctx, "Delete")
+	defer span.End()
+
+	result, err := repository.db.ExecContext(ctx, repository.rebind("UPDATE links SET deleted_at = ? WHERE shorturlpath = ? AND deleted_at = 0"), time.Now().UnixMilli(), shortUrlPath)
+
+	if err == nil {
+		var affected int64
+
+		if affected, err = result.RowsAffected(); err == nil && affected == 0 {
+			return notLive(ctx, repository, shortUrlPath)
+		}
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Could not delete link", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+	}
+
+	return err
+}
+
+func (repository *sqlRepository) Restore(ctx context.Context, shortUrlPath string, deletedSince time.Time) (models.URL, error) {
+	ctx, span := repository.startSpan(ctx, "Restore")
+	defer span.End()
+
+	result, err := repository.db.ExecContext(ctx, repository.rebind("UPDATE links SET deleted_at = 0 WHERE shorturlpath = ? AND deleted_at > 0 AND deleted_at >= ?"), shortUrlPath, toMillis(deletedSince))
+
+	if err == nil {
+		var affected int64
+
+		if affected, err = result.RowsAffected(); err == nil && affected == 0 {
+			return models.URL{}, notRestorable(ctx, repository, shortUrlPath)
+		}
+	}
+
+	if err != nil {
+		repository.logger.Errorw("Could not restore link", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+		return models.URL{}, err
+	}
+
+	return repository.GetByCode(ctx, shortUrlPath)
+}
+
+func (repository *sqlRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := repository.startSpan(ctx, "Purge")
+	defer span.End()
+
+	var purged int64
+	purgeable := "SELECT shorturlpath FROM links WHERE deleted_at > 0 AND deleted_at < ?"
+	before := deletedBefore.UnixMilli()
+
+	err := repository.inTx(ctx, func(tx *sql.Tx) error {
+		for _, table := range []string{"link_tags", "link_clicks"} {
+			if _, err := tx.ExecContext(ctx, repository.rebind("DELETE FROM "+table+" WHERE shorturlpath IN ("+purgeable+")"), before); err != nil {
+				return err
+			}
+		}
+
+		result, err := tx.ExecContext(ctx, repository.rebind("DELETE FROM links WHERE deleted_at > 0 AND deleted_at < ?"), before)
+
+		if err != nil {
+			return err
+		}
+
+		purged, err = result.RowsAffected()
+		return err
+	})
+
+	if err != nil {
+		repository.logger.Errorw("Could not purge links", zap.Error(err))
+	}
+
+	return purged, err
+}
+
+func (repository *sqlRepository) List(ctx context.Context, filter LinkFilter, page Page) (LinkPage, error) {
+	ctx, span := repository.startSpan(ctx, "List")
+	defer span.End()
+
+	cursor, err := page.decodeCursor()
+
+	if err != nil {
+		return LinkPage{}, err
+	}
+
+	column, operator, direction := sortColumns[page.Sort], ">", "ASC"
+
+	if column == "" {
+		column = sortColumns[SortCreatedAt]
+	}
+
+	if page.Descending {
+		operator, direction = "<", "DESC"
+	}
+
+	conditions, args := linkConditions(filter)
+
+	if cursor != nil {
+		value := toMillis(cursor.Value)
+		conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND shorturlpath %[2]s ?))", column, operator))
+		args = append(args, value, value, cursor.Key)
+	}
+
+	query := "SELECT " + linkColumns + " FROM links" + where(conditions) + fmt.Sprintf(" ORDER BY %[1]s %[2]s, shorturlpath %[2]s", column, direction)
+
+	// One extra link tells whether another page exists.
+	if page.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(page.Limit+1)
+	}
+
+	rows, err := repository.db.QueryContext(ctx, repository.rebind(query), args...)
+
+	if err != nil {
+		repository.logger.Errorw("Error retrieving links", zap.Error(err))
+		return LinkPage{}, err
+	}
+
+	defer rows.Close()
+
+	results := []models.URL{}
+
+	for rows.Next() {
+		url, err := scanLink(rows)
+
+		if err != nil {
+			repository.logger.Errorw("Error decoding link", zap.Error(err))
+			return LinkPage{}, err
+		}
+
+		results = append(results, url)
+	}
+
+	if err := rows.Err(); err != nil {
+		repository.logger.Errorw("Error retrieving links", zap.Error(err))
+		return LinkPage{}, err
+	}
+
+	if err := repository.loadTags(ctx, results); err != nil {
+		return LinkPage{}, err
+	}
+
+	return newLinkPage(results, page)
+}
+
+// Stream reads the links a page at a time, so it does not hold a connection
+// while callback runs.
+func (repository *sqlRepository) Stream(ctx context.Context, filter LinkFilter, callback func(models.URL) error) error {
+	ctx, span := repository.startSpan(ctx, "Stream")
+	defer span.End()
+
+	page := Page{Sort: SortCreatedAt, Limit: streamBatch}
+
+	for {
+		links, err := repository.List(ctx, filter, page)
+
+		if err != nil {
+			return err
+		}
+
+		for _, url := range links.Links {
+			if err := callback(url); err != nil {
+				return err
+			}
+		}
+
+		if links.NextCursor == "" {
+			return nil
+		}
+
+		page.Cursor = links.NextCursor
+	}
+}
+
+func (repository *sqlRepository) CountByOwner(ctx context.Context, owner string) (int64, error) {
+	ctx, span := repository.startSpan(ctx, "CountByOwner")
+	defer span.End()
+
+	var count int64
+
+	err := repository.db.QueryRowContext(ctx, repository.rebind("SELECT COUNT(*) FROM links WHERE owner = ? AND deleted_at = 0"), owner).Scan(&count)
+
+	if err != nil {
+		repository.logger.Errorw("Error counting links", zap.Error(err), zap.String("owner", owner))
+	}
+
+	return count, err
+}
+
+func (repository *sqlRepository) IncrementClicks(ctx context.Context, shortUrlPath string, day time.Time, count int64) error {
+	ctx, span := repository.startSpan(ctx, "IncrementClicks")
+	defer span.End()
+
+	_, err := repository.db.ExecContext(ctx, repository.rebind("INSERT INTO link_clicks (shorturlpath, day, count) VALUES (?, ?, ?) "+
+		"ON CONFLICT (shorturlpath, day) DO UPDATE SET count = link_clicks.count + excluded.count"),
+		shortUrlPath, day.UTC().Truncate(24*time.Hour).UnixMilli(), count)
+
+	if err != nil {
+		repository.logger.Errorw("Could not increment clicks", zap.Error(err), zap.String("shorturlpath", shortUrlPath))
+	}
+
+	return err
+}
+
+func (repository *sqlRepository) FindClicks(ctx context.Context, shortUrlPath string) ([]models.ClickBucket, error) {
+	ctx, span := repository.startSpan(ctx, "FindClicks")
+	defer span.End()
+
+	rows, err := repository.db.QueryContext(ctx, repository.rebind("SELECT day, count FROM link_clicks WHERE shorturlpath = ? ORDER BY day"), shortUrlPath)
+
+	if err != nil {
+		repository.logger.Errorw("Error retrieving clicks", zap.Error(err))
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []models.ClickBucket{}
+
+	for rows.Next() {
+		var day int64
+		bucket := models.ClickBucket{ShortUrlPath: shortUrlPath}
+
+		if err := rows.Scan(&day, &bucket.Count); err != nil {
+			repository.logger.Errorw("Error decoding clicks", zap.Error(err))
+			return nil, err
+		}
+
+		bucket.Day = fromMillis(day)
+		results = append(results, bucket)
+	}
+
+	return results, rows.Err()
+}
+
+func (repository *sqlRepository) FindTopClicked(ctx context.Context, since time.Time, limit int64) ([]models.ClickBucket, error) {
+	ctx, span := repository.startSpan(ctx, "FindTopClicked")
+	defer span.End()
+
+	rows, err := repository.db.QueryContext(ctx, repository.rebind("SELECT shorturlpath, CAST(SUM(count) AS BIGINT) AS total FROM link_clicks WHERE day >= ? "+
+		"GROUP BY shorturlpath ORDER BY total DESC, shorturlpath LIMIT ?"),
+		since.UTC().Truncate(24*time.Hour).UnixMilli(), limit)
+
+	if err != nil {
+		repository.logger.Errorw("Error aggregating clicks", zap.Error(err))
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []models.ClickBucket{}
+
+	for rows.Next() {
+		bucket := models.ClickBucket{}
+
+		if err := rows.Scan(&bucket.ShortUrlPath, &bucket.Count); err != nil {
+			repository.logger.Errorw("Error decoding clicks", zap.Error(err))
+			return nil, err
+		}
+
+		results = append(results, bucket)
+	}
+
+	return results, rows.Err()
+}
+
+func (repository *sqlRepository) Ping(ctx context.Context) error {
+	ctx, span := repository.startSpan(ctx, "Ping")
+	defer span.End()
+
+	return repository.db.PingContext(ctx)
+}
+
+// Close stops the sweeper and closes the database.
+func (repository *sqlRepository) Close() error {
+	select {
+	case <-repository.stop:
+	default:
+		close(repository.stop)
+	}
+
+	<-repository.stopped
+
+	err := repository.db.Close()
+
+	if err != nil {
+		repository.logger.Errorw("Could not close database", zap.Error(err))
+	}
+
+	return err
+}
+
+// SweepExpired deletes the links that have expired and returns how many it
+// deleted. Their clicks are kept, as they are with Mongo.
+func (repository *sqlRepository) SweepExpired(ctx context.Context) (int64, error) {
+	ctx, span := repository.startSpan(ctx, "SweepExpired")
+	defer span.End()
+
+	var deleted int64
+	now := time.Now().UnixMilli()
+
+	err := repository.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, repository.rebind("DELETE FROM link_tags WHERE shorturlpath IN (SELECT shorturlpath FROM links WHERE expires_at > 0 AND expires_at <= ?)"), now)
+
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, repository.rebind("DELETE FROM links WHERE expires_at > 0 AND expires_at <= ?"), now)
+
+		if err != nil {
+			return err
+		}
+
+		deleted, err = result.RowsAffected()
+		return err
+	})
+
+	return deleted, err
+}
+
+func (repository *sqlRepository) sweep(interval time.Duration) {
+	defer close(repository.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-repository.stop:
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := repository.SweepExpired(context.Background())
+
+		if err != nil {
+			repository.logger.Errorw("Could not delete expired links", zap.String("backend", repository.dialect.name), zap.Error(err))
+			continue
+		}
+
+		if deleted > 0 {
+			repository.logger.Infow("Deleted expired links", zap.String("backend", repository.dialect.name), zap.Int64("count", deleted))
+		}
+	}
+}
+
+func (repository *sqlRepository) insertTags(ctx context.Context, tx *sql.Tx, shortUrlPath string, tags []string) error {
+	for position, tag := range tags {
+		_, err := tx.ExecContext(ctx, repository.rebind("INSERT INTO link_tags (shorturlpath, position, tag) VALUES (?, ?, ?)"), shortUrlPath, position, tag)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repository *sqlRepository) replaceTags(ctx context.Context, tx *sql.Tx, shortUrlPath string, tags []string) error {
+	if _, err := tx.ExecContext(ctx, repository.rebind("DELETE FROM link_tags WHERE shorturlpath = ?"), shortUrlPath); err != nil {
+		return err
+	}
+
+	return repository.insertTags(ctx, tx, shortUrlPath, tags)
+}
+
+// loadTags reads the tags of the links in one query.
+func (repository *sqlRepository) loadTags(ctx context.Context, urls []models.URL) error {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	byPath := map[string]*models.URL{}
+	args := make([]interface{}, len(urls))
+
+	for i := range urls {
+		byPath[urls[i].ShortUrlPath] = &urls[i]
+		args[i] = urls[i].ShortUrlPath
+	}
+
+	query := "SELECT shorturlpath, tag FROM link_tags WHERE shorturlpath IN (" + placeholders(len(urls)) + ") ORDER BY shorturlpath, position"
+
+	rows, err := repository.db.QueryContext(ctx, repository.rebind(query), args...)
+
+	if err != nil {
+		repository.logger.Errorw("Error retrieving tags", zap.Error(err))
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var shortUrlPath, tag string
+
+		if err := rows.Scan(&shortUrlPath, &tag); err != nil {
+			repository.logger.Errorw("Error decoding tags", zap.Error(err))
+			return err
+		}
+
+		if url, ok := byPath[shortUrlPath]; ok {
+			url.Tags = append(url.Tags, tag)
+		}
+	}
+
+	return rows.Err()
+}
+
+// startSpan starts the span of a repository call, named after the call and
+// the dialect, as otelmongo does for the Mongo repository.
+func (repository *sqlRepository) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, repository.dialect.name+"."+operation, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(repository.dialect.system, semconv.DBOperation(operation)))
+}
+
+// inTx runs fn in a transaction, committing it if fn succeeds.
+func (repository *sqlRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := repository.db.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// rebind turns the ? placeholders of a query into the ones of the dialect.
+func (repository *sqlRepository) rebind(query string) string {
+	if !repository.dialect.numbered {
+		return query
+	}
+
+	var builder strings.Builder
+	number := 0
+
+	for _, char := range query {
+		if char == '?' {
+			number++
+			builder.WriteString("$" + strconv.Itoa(number))
+			continue
+		}
+
+		builder.WriteRune(char)
+	}
+
+	return builder.String()
+}
+
+// linkConditions are the SQL conditions and arguments of a filter, which
+// never match deleted links.
+func linkConditions(filter LinkFilter) ([]string, []interface{}) {
+	conditions, args := []string{"deleted_at = 0"}, []interface{}{}
+
+	if len(filter.ShortUrlPaths) > 0 {
+		conditions = append(conditions, "shorturlpath IN ("+placeholders(len(filter.ShortUrlPaths))+")")
+		for _, shortUrlPath := range filter.ShortUrlPaths {
+			args = append(args, shortUrlPath)
+		}
+	}
+
+	if filter.Tag != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM link_tags WHERE link_tags.shorturlpath = links.shorturlpath AND link_tags.tag = ?)")
+		args = append(args, filter.Tag)
+	}
+
+	if filter.Owner != "" {
+		conditions = append(conditions, "owner = ?")
+		args = append(args, filter.Owner)
+	}
+
+	// Like Mongo's text search, a link matches if it contains any of the
+	// words.
+	if terms := strings.Fields(strings.ToLower(filter.Query)); len(terms) > 0 {
+		matches := []string{}
+
+		for _, term := range terms {
+			pattern := "%" + escapeLike(term) + "%"
+			matches = append(matches, `LOWER(title) LIKE ? ESCAPE '\' OR LOWER(note) LIKE ? ESCAPE '\' OR LOWER(originalurl) LIKE ? ESCAPE '\'`)
+			args = append(args, pattern, pattern, pattern)
+		}
+
+		conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+
+	if !filter.CreatedAfter.IsZero() {
+		conditions = append(conditions, "created_at > ?")
+		args = append(args, toMillis(filter.CreatedAfter))
+	}
+
+	return conditions, args
+}
+
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func placeholders(count int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", count), ", ")
+}
+
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLink(row scanner) (models.URL, error) {
+	var url models.URL
+	var createdAt, expiresAt, deletedAt int64
+
+	err := row.Scan(&url.ShortUrlPath, &url.OriginalUrl, &url.Title, &url.Note, &url.Owner, &createdAt, &expiresAt, &deletedAt)
+
+	url.CreatedAt, url.ExpiresAt, url.DeletedAt = fromMillis(createdAt), fromMillis(expiresAt), fromMillis(deletedAt)
+
+	return url, err
+}
+
+// toMillis stores the zero time as 0, so links without an expiry are never
+// swept.
+func toMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixMilli()
+}
+
+func fromMillis(millis int64) time.Time {
+	if millis == 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(millis).UTC()
+}
+
+// linkSchema is the first schema version, shared by the SQL databases but
+// for the type of the id column.
+func linkSchema(idColumn string) []string {
+	return []string{
+		"CREATE TABLE links (id " + idColumn + ", shorturlpath TEXT NOT NULL, originalurl TEXT NOT NULL, title TEXT NOT NULL DEFAULT '', " +
+			"note TEXT NOT NULL DEFAULT '', owner TEXT NOT NULL DEFAULT '', created_at BIGINT NOT NULL, expires_at BIGINT NOT NULL)",
+		"CREATE UNIQUE INDEX links_shorturlpath_idx ON links (shorturlpath)",
+		"CREATE INDEX links_created_at_idx ON links (created_at, shorturlpath)",
+		"CREATE INDEX links_expires_at_idx ON links (expires_at, shorturlpath)",
+		"CREATE INDEX links_owner_idx ON links (owner)",
+		"CREATE TABLE link_tags (shorturlpath TEXT NOT NULL, position INTEGER NOT NULL, tag TEXT NOT NULL, PRIMARY KEY (shorturlpath, position))",
+		"CREATE INDEX link_tags_tag_idx ON link_tags (tag)",
+		"CREATE TABLE link_clicks (shorturlpath TEXT NOT NULL, day BIGINT NOT NULL, count BIGINT NOT NULL, PRIMARY KEY (shorturlpath, day))",
+		"CREATE INDEX link_clicks_day_idx ON link_clicks (day)",
+	}
+}
+
+// softDeleteSchema is the second schema version, which marks deleted links
+// instead of removing them.
+var softDeleteSchema = []string{
+	"ALTER TABLE links ADD COLUMN deleted_at BIGINT NOT NULL DEFAULT 0",
+	"CREATE INDEX links_deleted_at_idx ON links (deleted_at)",
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.uber.org/zap"
+)
+
+var sqlite = dialect{
+	name:   "sqlite",
+	system: semconv.DBSystemSqlite,
+	migrations: [][]string{
+		linkSchema("INTEGER PRIMARY KEY AUTOINCREMENT"),
+		softDeleteSchema,
+	},
+	isDuplicate: func(err error) bool {
+		var sqliteErr sqlite3.Error
+		return errors.As(err, &sqliteErr) &&
+			(sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+	},
+}
+
+// NewSqliteRepository opens the SQLite database in the file at path, creating
+// it if needed, and brings its schema up to date. ":memory:" keeps the
+// database in memory, e.g. for tests.
+func NewSqliteRepository(logger *zap.SugaredLogger, path string, sweepInterval time.Duration) (*sqlRepository, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL")
+
+	if err != nil {
+		logger.Errorw("Could not open sqlite database", zap.Error(err))
+		return nil, err
+	}
+
+	// SQLite takes one writer at a time, and an in-memory database only
+	// lives as long as its connection.
+	db.SetMaxOpenConns(1)
+
+	return newSqlRepository(db, sqlite, sweepInterval, logger)
+}
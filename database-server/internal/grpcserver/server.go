@@ -0,0 +1,100 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+	"url-shortner-database/internal/database"
+	"url-shortner-database/internal/models"
+	"url-shortner-database/internal/utils"
+
+	"url-shortner-api/pb"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// server serves the gRPC counterpart of the /shorten and /redirect routes.
+type server struct {
+	pb.UnimplementedDatabaseServiceServer
+	dbConnection database.Repository
+	logger       *zap.SugaredLogger
+}
+
+func NewServer(logger *zap.SugaredLogger, dbConnection database.Repository) *server {
+	return &server{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (s *server) Shorten(ctx context.Context, req *pb.ShortenRequest) (*pb.ShortenResponse, error) {
+	requestId := pb.RequestId(ctx)
+
+	s.logger.Infow("Handling shorten rpc", zap.String("Request Id", requestId), zap.Any("request", req))
+
+	if req.GetUrl() == "" {
+		s.logger.Errorw("Empty URL in request", zap.String("Request Id", requestId))
+		return nil, status.Error(codes.InvalidArgument, "empty url")
+	}
+
+	var expiresAt time.Time
+	if req.GetExpiresAt() != nil {
+		expiresAt = req.GetExpiresAt().AsTime()
+	}
+
+	url := models.URL{
+		ShortUrlPath: utils.KeyGenerationService(req.GetUrl() + requestId),
+		OriginalUrl:  req.GetUrl(),
+		ExpiresAt:    utils.GetExpirationTime(expiresAt),
+		CreatedAt:    time.Now(),
+		Title:        req.GetTitle(),
+		Note:         req.GetNote(),
+		Tags:         utils.NormalizeTags(req.GetTags()),
+		Owner:        req.GetOwner(),
+	}
+
+	s.logger.Infow("Generated shortened URL", zap.String("Request Id", requestId), zap.Any("url", url))
+
+	if err := database.CreateWithRetry(ctx, s.dbConnection, &url, req.GetUrl()+requestId); err != nil {
+		s.logger.Errorw("Error inserting document", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "error inserting document")
+	}
+
+	s.logger.Infow("Successfully shortened URL", zap.String("Request Id", requestId), zap.String("shorturlpath", url.ShortUrlPath))
+
+	return &pb.ShortenResponse{ShortUrlPath: url.ShortUrlPath}, nil
+}
+
+func (s *server) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveResponse, error) {
+	requestId := pb.RequestId(ctx)
+
+	s.logger.Infow("Handling resolve rpc", zap.String("Request Id", requestId), zap.String("shorturlpath", req.GetShortUrlPath()))
+
+	if req.GetShortUrlPath() == "" {
+		s.logger.Errorw("Empty short url path in request", zap.String("Request Id", requestId))
+		return nil, status.Error(codes.InvalidArgument, "empty short url path")
+	}
+
+	url, err := s.dbConnection.GetByCode(ctx, req.GetShortUrlPath())
+
+	if errors.Is(err, database.ErrNotFound) {
+		s.logger.Errorw("Document not found", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	if err != nil {
+		s.logger.Errorw("Error retrieving document", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, status.Error(codes.Internal, "error retrieving document")
+	}
+
+	if url.Deleted() {
+		s.logger.Errorw("Document deleted", zap.String("Request Id", requestId), zap.String("shorturlpath", req.GetShortUrlPath()))
+		return nil, status.Error(pb.CodeGone, "link was deleted")
+	}
+
+	s.logger.Infow("Found document", zap.String("Request Id", requestId), zap.Any("document", url))
+
+	return &pb.ResolveResponse{Url: url.OriginalUrl}, nil
+}
@@ -0,0 +1,165 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+	"url-shortner-database/internal/database"
+	mock_database "url-shortner-database/internal/database/mocks"
+	"url-shortner-database/internal/grpcserver"
+	"url-shortner-database/internal/middlewares"
+	"url-shortner-database/internal/models"
+
+	"url-shortner-api/pb"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newClient(t *testing.T, server pb.DatabaseServiceServer) pb.DatabaseServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(middlewares.LoggingInterceptor))
+	pb.RegisterDatabaseServiceServer(grpcServer, server)
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewDatabaseServiceClient(conn)
+}
+
+func TestShorten(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		request           *pb.ShortenRequest
+		CreateReturnError error
+		CreateDuplicates  int
+		CreateCallTimes   int
+		ExpectedCode      codes.Code
+	}{
+		"Empty Url": {
+			request:      &pb.ShortenRequest{},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Error Create": {
+			request:           &pb.ShortenRequest{Url: "http://www.google.com", Tags: []string{"a"}},
+			CreateReturnError: assert.AnError,
+			CreateCallTimes:   1,
+			ExpectedCode:      codes.Internal,
+		},
+		"Duplicate Short Url Path": {
+			request:          &pb.ShortenRequest{Url: "http://www.google.com", Tags: []string{"a"}},
+			CreateDuplicates: 2,
+			CreateCallTimes:  3,
+			ExpectedCode:     codes.OK,
+		},
+		"Success": {
+			request:         &pb.ShortenRequest{Url: "http://www.google.com", Tags: []string{"A", "a"}},
+			CreateCallTimes: 1,
+			ExpectedCode:    codes.OK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockObj := mock_database.NewMockRepository(mockCtrl)
+			duplicates := test.CreateDuplicates
+			mockObj.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, url models.URL) error {
+				assert.Equal(t, "http://www.google.com", url.OriginalUrl)
+				assert.Equal(t, []string{"a"}, url.Tags)
+				assert.True(t, url.ExpiresAt.After(url.CreatedAt))
+
+				if duplicates > 0 {
+					duplicates--
+					return database.ErrDuplicate
+				}
+
+				return test.CreateReturnError
+			}).Times(test.CreateCallTimes)
+
+			client := newClient(t, grpcserver.NewServer(logger, mockObj))
+
+			resp, err := client.Shorten(pb.WithRequestId(context.Background(), "abc"), test.request)
+			assert.Equal(t, test.ExpectedCode, status.Code(err))
+
+			if test.ExpectedCode == codes.OK {
+				assert.Len(t, resp.GetShortUrlPath(), 7)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		request              *pb.ResolveRequest
+		GetByCodeReturnUrl   models.URL
+		GetByCodeReturnError error
+		GetByCodeCallTimes   int
+		ExpectedCode         codes.Code
+		ExpectedUrl          string
+	}{
+		"Empty Short Url Path": {
+			request:      &pb.ResolveRequest{},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		"Not Found": {
+			request:              &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetByCodeReturnError: database.ErrNotFound,
+			GetByCodeCallTimes:   1,
+			ExpectedCode:         codes.NotFound,
+		},
+		"Database Error": {
+			request:              &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetByCodeReturnError: assert.AnError,
+			GetByCodeCallTimes:   1,
+			ExpectedCode:         codes.Internal,
+		},
+		"Deleted": {
+			request:            &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetByCodeReturnUrl: models.URL{ShortUrlPath: "abc1234", OriginalUrl: "http://www.google.com", DeletedAt: time.Now()},
+			GetByCodeCallTimes: 1,
+			ExpectedCode:       pb.CodeGone,
+		},
+		"Success": {
+			request:            &pb.ResolveRequest{ShortUrlPath: "abc1234"},
+			GetByCodeReturnUrl: models.URL{ShortUrlPath: "abc1234", OriginalUrl: "http://www.google.com"},
+			GetByCodeCallTimes: 1,
+			ExpectedCode:       codes.OK,
+			ExpectedUrl:        "http://www.google.com",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockObj := mock_database.NewMockRepository(mockCtrl)
+			mockObj.EXPECT().GetByCode(gomock.Any(), "abc1234").Return(test.GetByCodeReturnUrl, test.GetByCodeReturnError).Times(test.GetByCodeCallTimes)
+
+			client := newClient(t, grpcserver.NewServer(logger, mockObj))
+
+			resp, err := client.Resolve(context.Background(), test.request)
+			assert.Equal(t, test.ExpectedCode, status.Code(err))
+			assert.Equal(t, test.ExpectedUrl, resp.GetUrl())
+		})
+	}
+}
@@ -2,25 +2,49 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 	"url-shortner-database/internal/database"
+	"url-shortner-database/internal/metrics"
 	"url-shortner-database/internal/models"
 	"url-shortner-database/internal/utils"
 
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
 
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+	maxImportBatch   = 1000
+	defaultStatsDays = 30
+	maxStatsDays     = 366
+	defaultTopLimit  = 100
+	maxTopLimit      = 1000
+	defaultTopDays   = 7
+)
+
+var sortFields = map[string]database.SortField{
+	"created_at": database.SortCreatedAt,
+	"expires_at": database.SortExpiresAt,
+}
+
 type baseHandler struct {
-	dbConnection database.DBInterface
+	dbConnection database.Repository
 	logger       *zap.SugaredLogger
+	// retention is how long deleted links can be restored.
+	retention time.Duration
 }
 
-func NewBaseHandler(logger *zap.SugaredLogger, dbConnection database.DBInterface) *baseHandler {
+func NewBaseHandler(logger *zap.SugaredLogger, dbConnection database.Repository, retention time.Duration) *baseHandler {
 	return &baseHandler{
 		dbConnection: dbConnection,
 		logger:       logger,
+		retention:    retention,
 	}
 }
 
@@ -64,15 +88,16 @@ func (h *baseHandler) HandleShorten(w http.ResponseWriter, r *http.Request) {
 		ShortUrlPath: utils.KeyGenerationService(unmarsheledBody.Url + requestId),
 		OriginalUrl:  unmarsheledBody.Url,
 		ExpiresAt:    utils.GetExpirationTime(unmarsheledBody.ExpiresAt),
-	}
-
-	for _, err := h.dbConnection.FindOne(bson.D{{Key: "shorturlpath", Value: url.ShortUrlPath}}); err == nil; {
-		url.ShortUrlPath = utils.KeyGenerationService(unmarsheledBody.Url + requestId)
+		CreatedAt:    time.Now(),
+		Title:        unmarsheledBody.Title,
+		Note:         unmarsheledBody.Note,
+		Tags:         utils.NormalizeTags(unmarsheledBody.Tags),
+		Owner:        unmarsheledBody.Owner,
 	}
 
 	h.logger.Infow("Generated shortened URL", zap.String("Request Id", requestId), zap.Any("url", url))
 
-	err = h.dbConnection.InsertOne(url)
+	err = database.CreateWithRetry(r.Context(), h.dbConnection, &url, unmarsheledBody.Url+requestId)
 
 	if err != nil {
 		h.logger.Errorw("Error inserting document", zap.String("Request Id", requestId), zap.Error(err))
@@ -135,11 +160,9 @@ func (h *baseHandler) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	url, err := h.dbConnection.FindOne(bson.D{{Key: "shorturlpath", Value: unmarsheledBody.ShortUrlPath}})
+	url, ok := h.getByCode(w, r, unmarsheledBody.ShortUrlPath)
 
-	if err != nil {
-		h.logger.Errorw("Document not found", zap.String("Request Id", requestId), zap.Error(err))
-		http.Error(w, "Not found", http.StatusNotFound)
+	if !ok {
 		return
 	}
 
@@ -162,3 +185,705 @@ func (h *baseHandler) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Infow("Successfully redirected URL", zap.String("Request Id", requestId), zap.Any("response", jsonResponse))
 }
+
+func (h *baseHandler) HandleListLinks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling list links request", zap.String("Request Id", requestId), zap.Any("request", r.Body))
+
+	if r.Body == nil {
+		h.logger.Errorw("Empty request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty request body", http.StatusBadRequest)
+		return
+	}
+
+	httpBody, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		h.logger.Errorw("Error reading request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error reading request body", http.StatusInternalServerError)
+		return
+	}
+
+	unmarsheledBody := &models.ListRequestModel{}
+
+	err = json.Unmarshal(httpBody, unmarsheledBody)
+
+	if err != nil {
+		h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error unmarshalling JSON", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Infow("Successfully unmarshalled request body", zap.String("Request Id", requestId), zap.Any("request", unmarsheledBody))
+
+	sortField, descending := parseSort(unmarsheledBody.Sort)
+
+	if sortField == "" {
+		h.logger.Errorw("Invalid list request", zap.String("Request Id", requestId), zap.String("sort", unmarsheledBody.Sort))
+		http.Error(w, "invalid sort field", http.StatusBadRequest)
+		return
+	}
+
+	limit := unmarsheledBody.Limit
+
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	filter := database.LinkFilter{
+		Tag:          strings.ToLower(strings.TrimSpace(unmarsheledBody.Tag)),
+		Query:        unmarsheledBody.Query,
+		CreatedAfter: unmarsheledBody.CreatedAfter,
+	}
+	page := database.Page{
+		Sort:       sortField,
+		Descending: descending,
+		Cursor:     unmarsheledBody.Cursor,
+		Limit:      limit,
+	}
+
+	links, err := h.dbConnection.List(r.Context(), filter, page)
+
+	if errors.Is(err, database.ErrInvalidCursor) {
+		h.logger.Errorw("Invalid list request", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		h.logger.Errorw("Error retrieving documents", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error retrieving documents", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.ListResponseModel{
+		Links:      []models.LinkModel{},
+		NextCursor: links.NextCursor,
+	}
+
+	for _, url := range links.Links {
+		response.Links = append(response.Links, models.NewLinkModel(url))
+	}
+
+	jsonResponse, err := json.Marshal(response)
+
+	if err != nil {
+		h.logger.Errorw("Error marshalling JSON", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error marshalling JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonResponse)
+
+	h.logger.Infow("Successfully listed links", zap.String("Request Id", requestId), zap.Int("count", len(response.Links)))
+}
+
+// HandleTopLinks lists the links clicked most over the last days, most
+// clicked first, e.g. to warm the cache with.
+func (h *baseHandler) HandleTopLinks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling top links request", zap.String("Request Id", requestId))
+
+	if r.Body == nil {
+		h.logger.Errorw("Empty request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty request body", http.StatusBadRequest)
+		return
+	}
+
+	unmarsheledBody := &models.TopLinksRequestModel{}
+
+	if err := json.NewDecoder(r.Body).Decode(unmarsheledBody); err != nil {
+		h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error unmarshalling JSON", http.StatusBadRequest)
+		return
+	}
+
+	limit, days := unmarsheledBody.Limit, unmarsheledBody.Days
+
+	if limit <= 0 {
+		limit = defaultTopLimit
+	}
+
+	if days <= 0 {
+		days = defaultTopDays
+	}
+
+	if limit > maxTopLimit || days > maxStatsDays {
+		h.logger.Errorw("Invalid top links request", zap.String("Request Id", requestId), zap.Int("limit", limit), zap.Int("days", days))
+		http.Error(w, "Invalid limit or days", http.StatusBadRequest)
+		return
+	}
+
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	buckets, err := h.dbConnection.FindTopClicked(r.Context(), since, int64(limit))
+
+	if err != nil {
+		h.logger.Errorw("Error retrieving clicks", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error retrieving clicks", http.StatusInternalServerError)
+		return
+	}
+
+	response := models.ListResponseModel{
+		Links: []models.LinkModel{},
+	}
+
+	if len(buckets) > 0 {
+		shortUrlPaths := make([]string, len(buckets))
+		for i, bucket := range buckets {
+			shortUrlPaths[i] = bucket.ShortUrlPath
+		}
+
+		links, err := h.dbConnection.List(r.Context(), database.LinkFilter{ShortUrlPaths: shortUrlPaths}, database.Page{Limit: len(buckets)})
+
+		if err != nil {
+			h.logger.Errorw("Error retrieving documents", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Error retrieving documents", http.StatusInternalServerError)
+			return
+		}
+
+		byPath := map[string]models.URL{}
+		for _, url := range links.Links {
+			byPath[url.ShortUrlPath] = url
+		}
+
+		// Links deleted since they were clicked are left out.
+		for _, bucket := range buckets {
+			if url, ok := byPath[bucket.ShortUrlPath]; ok {
+				response.Links = append(response.Links, models.NewLinkModel(url))
+			}
+		}
+	}
+
+	h.writeJSON(w, requestId, response)
+
+	h.logger.Infow("Successfully listed top links", zap.String("Request Id", requestId), zap.Int("count", len(response.Links)))
+}
+
+// parseSort reads a sort such as "-created_at", defaulting to the newest
+// links first. The field is empty for an unknown sort.
+func parseSort(sort string) (database.SortField, bool) {
+	if sort == "" {
+		sort = "-created_at"
+	}
+
+	descending := strings.HasPrefix(sort, "-")
+
+	return sortFields[strings.TrimPrefix(sort, "-")], descending
+}
+
+func (h *baseHandler) HandleExportLinks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling export links request", zap.String("Request Id", requestId))
+
+	unmarsheledBody := &models.ExportRequestModel{}
+
+	if r.Body != nil {
+		httpBody, err := io.ReadAll(r.Body)
+
+		if err != nil {
+			h.logger.Errorw("Error reading request body", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Error reading request body", http.StatusInternalServerError)
+			return
+		}
+
+		if len(httpBody) > 0 {
+			if err := json.Unmarshal(httpBody, unmarsheledBody); err != nil {
+				h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+				http.Error(w, "Error unmarshalling JSON", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	filter := database.LinkFilter{
+		Tag:   strings.ToLower(strings.TrimSpace(unmarsheledBody.Tag)),
+		Owner: unmarsheledBody.Owner,
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	count := 0
+
+	err := h.dbConnection.Stream(r.Context(), filter, func(url models.URL) error {
+		if err := encoder.Encode(models.NewLinkModel(url)); err != nil {
+			return err
+		}
+
+		count++
+
+		if flusher != nil && count%100 == 0 {
+			flusher.Flush()
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		h.logger.Errorw("Error streaming links", zap.String("Request Id", requestId), zap.Int("count", count), zap.Error(err))
+
+		if count == 0 {
+			http.Error(w, "Error retrieving documents", http.StatusInternalServerError)
+			return
+		}
+
+		// Headers are already sent once the first link is written, so the
+		// response is aborted for the client to see a broken stream rather
+		// than a complete one.
+		panic(http.ErrAbortHandler)
+	}
+
+	h.logger.Infow("Successfully exported links", zap.String("Request Id", requestId), zap.Int("count", count))
+}
+
+func (h *baseHandler) HandleImportLinks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling import links request", zap.String("Request Id", requestId))
+
+	if r.Body == nil {
+		h.logger.Errorw("Empty request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty request body", http.StatusBadRequest)
+		return
+	}
+
+	httpBody, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		h.logger.Errorw("Error reading request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error reading request body", http.StatusInternalServerError)
+		return
+	}
+
+	unmarsheledBody := &models.ImportRequestModel{}
+
+	err = json.Unmarshal(httpBody, unmarsheledBody)
+
+	if err != nil {
+		h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error unmarshalling JSON", http.StatusBadRequest)
+		return
+	}
+
+	if len(unmarsheledBody.Links) > maxImportBatch {
+		h.logger.Errorw("Import batch too large", zap.String("Request Id", requestId), zap.Int("count", len(unmarsheledBody.Links)))
+		http.Error(w, "Import batch too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	response := models.ImportResponseModel{
+		Results: make([]models.ImportResultModel, len(unmarsheledBody.Links)),
+	}
+
+	documents := []models.URL{}
+	documentIndexes := []int{}
+	// generated tells which documents got a generated short url path rather
+	// than the one of the caller.
+	generated := []bool{}
+
+	for i, link := range unmarsheledBody.Links {
+		response.Results[i] = models.ImportResultModel{Index: i, ShortUrlPath: link.ShortUrlPath}
+
+		if link.Url == "" {
+			response.Results[i].Status = models.ImportStatusInvalid
+			response.Results[i].Error = "empty url"
+			continue
+		}
+
+		if link.ShortUrlPath == "" {
+			link.ShortUrlPath = utils.KeyGenerationService(link.Url + requestId)
+		} else if !utils.IsValidShortUrlPath(link.ShortUrlPath) {
+			response.Results[i].Status = models.ImportStatusInvalid
+			response.Results[i].Error = "invalid short url path"
+			continue
+		}
+
+		createdAt := link.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		documents = append(documents, models.URL{
+			ShortUrlPath: link.ShortUrlPath,
+			OriginalUrl:  link.Url,
+			ExpiresAt:    utils.GetExpirationTime(link.ExpiresAt),
+			CreatedAt:    createdAt,
+			Title:        link.Title,
+			Note:         link.Note,
+			Tags:         utils.NormalizeTags(link.Tags),
+			Owner:        link.Owner,
+		})
+		documentIndexes = append(documentIndexes, i)
+		generated = append(generated, response.Results[i].ShortUrlPath == "")
+	}
+
+	errs, err := h.dbConnection.CreateMany(r.Context(), documents)
+
+	if err != nil {
+		h.logger.Errorw("Error inserting documents", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error inserting documents", http.StatusInternalServerError)
+		return
+	}
+
+	for i, index := range documentIndexes {
+		// A taken generated short url path is not a conflict of the caller,
+		// so the link is created under a new one, like a shortened link.
+		if generated[i] && errors.Is(errs[i], database.ErrDuplicate) {
+			seed := documents[i].OriginalUrl + requestId
+
+			metrics.KeyGenerationRetries.Inc()
+			documents[i].ShortUrlPath = utils.KeyGenerationService(seed)
+			errs[i] = database.CreateWithRetry(r.Context(), h.dbConnection, &documents[i], seed)
+		}
+
+		switch {
+		case errs[i] == nil:
+			response.Results[index].Status = models.ImportStatusCreated
+			response.Results[index].ShortUrlPath = documents[i].ShortUrlPath
+		case errors.Is(errs[i], database.ErrDuplicate) && !generated[i]:
+			response.Results[index].Status = models.ImportStatusConflict
+			response.Results[index].Error = errs[i].Error()
+		default:
+			response.Results[index].Status = models.ImportStatusFailed
+			response.Results[index].Error = errs[i].Error()
+		}
+	}
+
+	jsonResponse, err := json.Marshal(response)
+
+	if err != nil {
+		h.logger.Errorw("Error marshalling JSON", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error marshalling JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonResponse)
+
+	h.logger.Infow("Successfully imported links", zap.String("Request Id", requestId), zap.Int("count", len(documents)))
+}
+
+func (h *baseHandler) HandleGetLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["shorturlpath"]
+
+	h.logger.Infow("Handling get link request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	url, ok := h.getByCode(w, r, shortUrlPath)
+
+	if !ok {
+		return
+	}
+
+	h.writeJSON(w, requestId, models.NewLinkModel(url))
+}
+
+func (h *baseHandler) HandleUpdateLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["shorturlpath"]
+
+	h.logger.Infow("Handling update link request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	if r.Body == nil {
+		h.logger.Errorw("Empty request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty request body", http.StatusBadRequest)
+		return
+	}
+
+	httpBody, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		h.logger.Errorw("Error reading request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error reading request body", http.StatusInternalServerError)
+		return
+	}
+
+	unmarsheledBody := &models.UpdateRequestModel{}
+
+	err = json.Unmarshal(httpBody, unmarsheledBody)
+
+	if err != nil {
+		h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error unmarshalling JSON", http.StatusBadRequest)
+		return
+	}
+
+	if unmarsheledBody.Url != nil && *unmarsheledBody.Url == "" {
+		h.logger.Errorw("Empty URL in request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty URL in request body", http.StatusBadRequest)
+		return
+	}
+
+	update := database.LinkUpdate{
+		Url:   unmarsheledBody.Url,
+		Title: unmarsheledBody.Title,
+		Note:  unmarsheledBody.Note,
+	}
+
+	if unmarsheledBody.ExpiresAt != nil {
+		expiresAt := utils.GetExpirationTime(*unmarsheledBody.ExpiresAt)
+		update.ExpiresAt = &expiresAt
+	}
+
+	if unmarsheledBody.Tags != nil {
+		tags := utils.NormalizeTags(*unmarsheledBody.Tags)
+		update.Tags = &tags
+	}
+
+	if update.IsEmpty() {
+		h.logger.Errorw("Nothing to update", zap.String("Request Id", requestId))
+		http.Error(w, "Nothing to update", http.StatusBadRequest)
+		return
+	}
+
+	url, err := h.dbConnection.Update(r.Context(), shortUrlPath, update)
+
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			h.logger.Errorw("Document not found", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, database.ErrDeleted) {
+			h.logger.Errorw("Document deleted", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Link was deleted", http.StatusGone)
+			return
+		}
+
+		h.logger.Errorw("Error updating document", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error updating document", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, requestId, models.NewLinkModel(url))
+
+	h.logger.Infow("Successfully updated link", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+}
+
+func (h *baseHandler) HandleDeleteLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["shorturlpath"]
+
+	h.logger.Infow("Handling delete link request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	err := h.dbConnection.Delete(r.Context(), shortUrlPath)
+
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			h.logger.Errorw("Document not found", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, database.ErrDeleted) {
+			h.logger.Errorw("Document already deleted", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Link was deleted", http.StatusGone)
+			return
+		}
+
+		h.logger.Errorw("Error deleting document", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error deleting document", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Infow("Successfully deleted link", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+}
+
+// HandleRestoreLink revives a link deleted within the retention window.
+func (h *baseHandler) HandleRestoreLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["shorturlpath"]
+
+	h.logger.Infow("Handling restore link request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	url, err := h.dbConnection.Restore(r.Context(), shortUrlPath, time.Now().Add(-h.retention))
+
+	if err != nil {
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			h.logger.Errorw("Document not found", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Not found", http.StatusNotFound)
+		case errors.Is(err, database.ErrNotDeleted):
+			h.logger.Errorw("Document not deleted", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Link is not deleted", http.StatusConflict)
+		case errors.Is(err, database.ErrDeleted):
+			h.logger.Errorw("Document deleted before the retention window", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Link was deleted too long ago to restore", http.StatusGone)
+		default:
+			h.logger.Errorw("Error restoring document", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Error restoring document", http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	h.writeJSON(w, requestId, models.NewLinkModel(url))
+
+	h.logger.Infow("Successfully restored link", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+}
+
+func (h *baseHandler) HandleLinkStats(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["shorturlpath"]
+
+	h.logger.Infow("Handling link stats request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	days := defaultStatsDays
+
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+
+		if err != nil || parsed <= 0 || parsed > maxStatsDays {
+			h.logger.Errorw("Invalid days parameter", zap.String("Request Id", requestId), zap.String("days", value))
+			http.Error(w, "Invalid days", http.StatusBadRequest)
+			return
+		}
+
+		days = parsed
+	}
+
+	if _, ok := h.getByCode(w, r, shortUrlPath); !ok {
+		return
+	}
+
+	buckets, err := h.dbConnection.FindClicks(r.Context(), shortUrlPath)
+
+	if err != nil {
+		h.logger.Errorw("Error retrieving clicks", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error retrieving clicks", http.StatusInternalServerError)
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	counts := map[time.Time]int64{}
+	response := models.StatsResponseModel{
+		ShortUrlPath: shortUrlPath,
+		Daily:        []models.DailyClicksModel{},
+	}
+
+	for _, bucket := range buckets {
+		response.TotalClicks += bucket.Count
+		counts[bucket.Day.UTC()] += bucket.Count
+	}
+
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		response.Daily = append(response.Daily, models.DailyClicksModel{Day: day, Count: counts[day]})
+	}
+
+	h.writeJSON(w, requestId, response)
+}
+
+func (h *baseHandler) HandleRecordClicks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling record clicks request", zap.String("Request Id", requestId))
+
+	if r.Body == nil {
+		h.logger.Errorw("Empty request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty request body", http.StatusBadRequest)
+		return
+	}
+
+	httpBody, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		h.logger.Errorw("Error reading request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error reading request body", http.StatusInternalServerError)
+		return
+	}
+
+	unmarsheledBody := &models.RecordClicksRequestModel{}
+
+	err = json.Unmarshal(httpBody, unmarsheledBody)
+
+	if err != nil {
+		h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error unmarshalling JSON", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	failed := 0
+
+	for shortUrlPath, count := range unmarsheledBody.Clicks {
+		if count <= 0 {
+			continue
+		}
+
+		if err := h.dbConnection.IncrementClicks(r.Context(), shortUrlPath, now, count); err != nil {
+			h.logger.Errorw("Error recording clicks", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath), zap.Error(err))
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		http.Error(w, "Error recording clicks", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Infow("Successfully recorded clicks", zap.String("Request Id", requestId), zap.Int("links", len(unmarsheledBody.Clicks)))
+}
+
+// getByCode looks a link up, answering 404 if it does not exist, 410 if it
+// was deleted and 500 if it cannot be read. It reports whether the link was
+// found.
+func (h *baseHandler) getByCode(w http.ResponseWriter, r *http.Request, shortUrlPath string) (models.URL, bool) {
+	requestId := r.Header.Get("X-request-id")
+
+	url, err := h.dbConnection.GetByCode(r.Context(), shortUrlPath)
+
+	if errors.Is(err, database.ErrNotFound) {
+		h.logger.Errorw("Document not found", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+		http.Error(w, "Not found", http.StatusNotFound)
+		return url, false
+	}
+
+	if err != nil {
+		h.logger.Errorw("Error retrieving document", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error retrieving document", http.StatusInternalServerError)
+		return url, false
+	}
+
+	if url.Deleted() {
+		h.logger.Errorw("Document deleted", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+		http.Error(w, "Link was deleted", http.StatusGone)
+		return url, false
+	}
+
+	return url, true
+}
+
+func (h *baseHandler) writeJSON(w http.ResponseWriter, requestId string, response interface{}) {
+	jsonResponse, err := json.Marshal(response)
+
+	if err != nil {
+		h.logger.Errorw("Error marshalling JSON", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Error marshalling JSON", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonResponse)
+}
@@ -2,63 +2,157 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+	"url-shortner-database/internal/database"
 	mock_database "url-shortner-database/internal/database/mocks"
 	"url-shortner-database/internal/handlers"
+	"url-shortner-database/internal/metrics"
 	"url-shortner-database/internal/models"
+	"url-shortner-database/internal/utils"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 )
 
-func TestHandleShorten(t *testing.T) {
-	logger := zap.NewNop().Sugar()
+// faultyRepository is a memory repository whose method named failing returns
+// assert.AnError.
+type faultyRepository struct {
+	database.Repository
+	failing string
+}
+
+func newRepository(t *testing.T, failing string, urls ...models.URL) *faultyRepository {
+	repository := database.NewMemoryRepository()
+
+	for _, url := range urls {
+		assert.Nil(t, repository.Create(context.Background(), url))
+	}
+
+	return &faultyRepository{Repository: repository, failing: failing}
+}
+
+func (r *faultyRepository) Create(ctx context.Context, url models.URL) error {
+	if r.failing == "Create" {
+		return assert.AnError
+	}
+	return r.Repository.Create(ctx, url)
+}
+
+func (r *faultyRepository) CreateMany(ctx context.Context, urls []models.URL) ([]error, error) {
+	if r.failing == "CreateMany" {
+		return nil, assert.AnError
+	}
+	return r.Repository.CreateMany(ctx, urls)
+}
+
+func (r *faultyRepository) GetByCode(ctx context.Context, shortUrlPath string) (models.URL, error) {
+	if r.failing == "GetByCode" {
+		return models.URL{}, assert.AnError
+	}
+	return r.Repository.GetByCode(ctx, shortUrlPath)
+}
+
+func (r *faultyRepository) Update(ctx context.Context, shortUrlPath string, update database.LinkUpdate) (models.URL, error) {
+	if r.failing == "Update" {
+		return models.URL{}, assert.AnError
+	}
+	return r.Repository.Update(ctx, shortUrlPath, update)
+}
+
+func (r *faultyRepository) Delete(ctx context.Context, shortUrlPath string) error {
+	if r.failing == "Delete" {
+		return assert.AnError
+	}
+	return r.Repository.Delete(ctx, shortUrlPath)
+}
+
+func (r *faultyRepository) Restore(ctx context.Context, shortUrlPath string, deletedSince time.Time) (models.URL, error) {
+	if r.failing == "Restore" {
+		return models.URL{}, assert.AnError
+	}
+	return r.Repository.Restore(ctx, shortUrlPath, deletedSince)
+}
+
+func (r *faultyRepository) List(ctx context.Context, filter database.LinkFilter, page database.Page) (database.LinkPage, error) {
+	if r.failing == "List" {
+		return database.LinkPage{}, assert.AnError
+	}
+	return r.Repository.List(ctx, filter, page)
+}
+
+func (r *faultyRepository) Stream(ctx context.Context, filter database.LinkFilter, callback func(models.URL) error) error {
+	if r.failing == "Stream" {
+		return assert.AnError
+	}
+	if r.failing == "StreamAfterFirst" {
+		streamed := 0
+		return r.Repository.Stream(ctx, filter, func(url models.URL) error {
+			if streamed++; streamed > 1 {
+				return assert.AnError
+			}
+			return callback(url)
+		})
+	}
+	return r.Repository.Stream(ctx, filter, callback)
+}
 
-	mockCtrl := gomock.NewController(t)
-	mockObj := mock_database.NewMockDBInterface(mockCtrl)
-	mockObj.EXPECT().FindOne(gomock.Any()).Return(models.URL{}, errors.New("Not Found")).AnyTimes()
+func (r *faultyRepository) IncrementClicks(ctx context.Context, shortUrlPath string, day time.Time, count int64) error {
+	if r.failing == "IncrementClicks" {
+		return assert.AnError
+	}
+	return r.Repository.IncrementClicks(ctx, shortUrlPath, day, count)
+}
 
-	handler := handlers.NewBaseHandler(logger, mockObj)
+func (r *faultyRepository) FindClicks(ctx context.Context, shortUrlPath string) ([]models.ClickBucket, error) {
+	if r.failing == "FindClicks" {
+		return nil, assert.AnError
+	}
+	return r.Repository.FindClicks(ctx, shortUrlPath)
+}
+
+func (r *faultyRepository) FindTopClicked(ctx context.Context, since time.Time, limit int64) ([]models.ClickBucket, error) {
+	if r.failing == "FindTopClicked" {
+		return nil, assert.AnError
+	}
+	return r.Repository.FindTopClicked(ctx, since, limit)
+}
+
+func TestHandleShorten(t *testing.T) {
+	logger := zap.NewNop().Sugar()
 
 	tests := map[string]struct {
-		reqBody              *models.ShortenRequestModel
-		InsertOne            *gomock.Call
-		InsertOneReturnError error
-		InsertOneCall        int
-		ExpectedStatusCode   int
+		reqBody            *models.ShortenRequestModel
+		Failing            string
+		ExpectedStatusCode int
 	}{
 		"Empty Request Body": {
-			reqBody:              nil,
-			InsertOne:            mockObj.EXPECT().InsertOne(gomock.Any()),
-			InsertOneReturnError: nil,
-			InsertOneCall:        0,
-			ExpectedStatusCode:   http.StatusBadRequest,
-		},
-		"Error InsertOne": {
-			reqBody:              &models.ShortenRequestModel{Url: "http://www.google.com"},
-			InsertOne:            mockObj.EXPECT().InsertOne(gomock.Any()),
-			InsertOneCall:        1,
-			InsertOneReturnError: assert.AnError,
-			ExpectedStatusCode:   http.StatusInternalServerError,
+			reqBody:            nil,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Error Create": {
+			reqBody:            &models.ShortenRequestModel{Url: "http://www.google.com"},
+			Failing:            "Create",
+			ExpectedStatusCode: http.StatusInternalServerError,
 		},
 		"Success": {
-			reqBody:              &models.ShortenRequestModel{Url: "http://www.google.com"},
-			InsertOne:            mockObj.EXPECT().InsertOne(gomock.Any()),
-			InsertOneCall:        1,
-			InsertOneReturnError: nil,
-			ExpectedStatusCode:   http.StatusOK,
+			reqBody:            &models.ShortenRequestModel{Url: "http://www.google.com", Tags: []string{"A"}},
+			ExpectedStatusCode: http.StatusOK,
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			test.InsertOne.Return(test.InsertOneReturnError).Times(test.InsertOneCall)
+			repository := newRepository(t, test.Failing)
+			handler := handlers.NewBaseHandler(logger, repository, time.Hour)
 
 			body, err := json.Marshal(test.reqBody)
 
@@ -70,6 +164,71 @@ func TestHandleShorten(t *testing.T) {
 			resp := httptest.NewRecorder()
 			handler.HandleShorten(resp, req)
 			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode != http.StatusOK {
+				return
+			}
+
+			response := models.ShortenResponseModel{}
+			assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+			url, err := repository.GetByCode(context.Background(), response.ShortUrlPath)
+			assert.Nil(t, err)
+			assert.Equal(t, "http://www.google.com", url.OriginalUrl)
+			assert.Equal(t, []string{"a"}, url.Tags)
+		})
+	}
+}
+
+func TestHandleShortenTakenKey(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		Duplicates         int
+		CreateCallTimes    int
+		ExpectedStatusCode int
+		ExpectedRetries    float64
+	}{
+		"Retried": {
+			Duplicates:         1,
+			CreateCallTimes:    2,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedRetries:    1,
+		},
+		"Gives Up": {
+			Duplicates:         100,
+			CreateCallTimes:    5,
+			ExpectedStatusCode: http.StatusInternalServerError,
+			ExpectedRetries:    5,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockObj := mock_database.NewMockRepository(mockCtrl)
+
+			duplicates := test.Duplicates
+			mockObj.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, url models.URL) error {
+				if duplicates > 0 {
+					duplicates--
+					return database.ErrDuplicate
+				}
+				return nil
+			}).Times(test.CreateCallTimes)
+
+			handler := handlers.NewBaseHandler(logger, mockObj, time.Hour)
+			retries := testutil.ToFloat64(metrics.KeyGenerationRetries)
+
+			body, err := json.Marshal(&models.ShortenRequestModel{Url: "http://www.google.com"})
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest("POST", "/shorten", bytes.NewBuffer(body))
+			resp := httptest.NewRecorder()
+			handler.HandleShorten(resp, req)
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+			assert.Equal(t, retries+test.ExpectedRetries, testutil.ToFloat64(metrics.KeyGenerationRetries))
 		})
 	}
 }
@@ -77,48 +236,41 @@ func TestHandleShorten(t *testing.T) {
 func TestHandleRedirect(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
-	mockCtrl := gomock.NewController(t)
-	mockObj := mock_database.NewMockDBInterface(mockCtrl)
-
-	handler := handlers.NewBaseHandler(logger, mockObj)
+	link := models.URL{ShortUrlPath: "test", OriginalUrl: "http://www.google.com", ExpiresAt: time.Now().AddDate(0, 1, 0)}
 
 	tests := map[string]struct {
 		reqBody            *models.RedirectRequestModel
-		FindOne            *gomock.Call
-		FindOneReturnError error
-		FindOneReturnUrl   models.URL
-		FindOneCall        int
+		Failing            string
 		ExpectedStatusCode int
+		ExpectedUrl        string
 	}{
 		"Empty Request URL": {
 			reqBody:            nil,
-			FindOne:            mockObj.EXPECT().FindOne(gomock.Any()),
-			FindOneReturnError: nil,
-			FindOneReturnUrl:   models.URL{},
-			FindOneCall:        0,
 			ExpectedStatusCode: http.StatusBadRequest,
 		},
-		"Error Find One": {
-			reqBody:            &models.RedirectRequestModel{ShortUrlPath: "test"},
-			FindOne:            mockObj.EXPECT().FindOne(gomock.Any()),
-			FindOneReturnError: assert.AnError,
-			FindOneReturnUrl:   models.URL{},
-			FindOneCall:        1,
+		"Not Found": {
+			reqBody:            &models.RedirectRequestModel{ShortUrlPath: "missing"},
 			ExpectedStatusCode: http.StatusNotFound,
 		},
+		"Error GetByCode": {
+			reqBody:            &models.RedirectRequestModel{ShortUrlPath: "test"},
+			Failing:            "GetByCode",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Deleted": {
+			reqBody:            &models.RedirectRequestModel{ShortUrlPath: "deleted"},
+			ExpectedStatusCode: http.StatusGone,
+		},
 		"Success": {
 			reqBody:            &models.RedirectRequestModel{ShortUrlPath: "test"},
-			FindOne:            mockObj.EXPECT().FindOne(gomock.Any()),
-			FindOneReturnError: nil,
-			FindOneReturnUrl:   models.URL{ShortUrlPath: "test", OriginalUrl: "http://www.google.com", ExpiresAt: time.Now().AddDate(0, 1, 0)},
-			FindOneCall:        1,
 			ExpectedStatusCode: http.StatusOK,
+			ExpectedUrl:        "http://www.google.com",
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			test.FindOne.Return(test.FindOneReturnUrl, test.FindOneReturnError).Times(test.FindOneCall)
+			handler := handlers.NewBaseHandler(logger, newRepository(t, test.Failing, link, models.URL{ShortUrlPath: "deleted", DeletedAt: time.Now()}), time.Hour)
 
 			body, err := json.Marshal(test.reqBody)
 
@@ -130,6 +282,744 @@ func TestHandleRedirect(t *testing.T) {
 			resp := httptest.NewRecorder()
 			handler.HandleRedirect(resp, req)
 			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode != http.StatusOK {
+				return
+			}
+
+			response := models.RedirectResponseModel{}
+			assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+			assert.Equal(t, test.ExpectedUrl, response.Url)
+		})
+	}
+}
+
+func TestHandleListLinks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	links := []models.URL{
+		{ShortUrlPath: "a", OriginalUrl: "http://a.com", Title: "Launch day", Tags: []string{"marketing"}, CreatedAt: now.Add(-3 * time.Hour), ExpiresAt: now.Add(3 * time.Hour)},
+		{ShortUrlPath: "b", OriginalUrl: "http://b.com", Title: "Pricing", Tags: []string{"marketing"}, CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(1 * time.Hour)},
+		{ShortUrlPath: "c", OriginalUrl: "http://c.com", Note: "launch checklist", CreatedAt: now.Add(-1 * time.Hour), ExpiresAt: now.Add(2 * time.Hour)},
+	}
+
+	cursor, err := utils.EncodeCursor(utils.Cursor{Value: now.Add(-2 * time.Hour), Key: "b"})
+
+	if err != nil {
+		t.Fatalf("Error encoding cursor: %v", err)
+	}
+
+	tests := map[string]struct {
+		reqBody            *models.ListRequestModel
+		Failing            string
+		ExpectedStatusCode int
+		ExpectedLinks      []string
+		ExpectNextCursor   bool
+	}{
+		"Empty Request Body": {
+			reqBody:            nil,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedLinks:      []string{"c", "b", "a"},
+		},
+		"Invalid Sort": {
+			reqBody:            &models.ListRequestModel{Sort: "title"},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Invalid Cursor": {
+			reqBody:            &models.ListRequestModel{Cursor: "not a cursor!"},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Error List": {
+			reqBody:            &models.ListRequestModel{Tag: "marketing"},
+			Failing:            "List",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Tag And Query": {
+			reqBody:            &models.ListRequestModel{Tag: " Marketing ", Query: "launch"},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedLinks:      []string{"a"},
+		},
+		"Last Page": {
+			reqBody:            &models.ListRequestModel{Cursor: cursor, Limit: 2},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedLinks:      []string{"a"},
+		},
+		"Has Next Page": {
+			reqBody:            &models.ListRequestModel{Sort: "expires_at", Limit: 2},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedLinks:      []string{"b", "c"},
+			ExpectNextCursor:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := handlers.NewBaseHandler(logger, newRepository(t, test.Failing, links...), time.Hour)
+
+			body, err := json.Marshal(test.reqBody)
+
+			if err != nil {
+				t.Error("Error marshalling request body")
+			}
+
+			req := httptest.NewRequest("POST", "/links", bytes.NewBuffer(body))
+			resp := httptest.NewRecorder()
+			handler.HandleListLinks(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode != http.StatusOK {
+				return
+			}
+
+			response := models.ListResponseModel{}
+			assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+			paths := []string{}
+			for _, link := range response.Links {
+				paths = append(paths, link.ShortUrlPath)
+			}
+
+			assert.Equal(t, test.ExpectedLinks, paths)
+			assert.Equal(t, test.ExpectNextCursor, response.NextCursor != "")
+		})
+	}
+}
+
+func TestHandleExportLinks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	links := []models.URL{
+		{ShortUrlPath: "a", OriginalUrl: "http://a.com", Owner: "growth"},
+		{ShortUrlPath: "b", OriginalUrl: "http://b.com", Owner: "growth"},
+		{ShortUrlPath: "c", OriginalUrl: "http://c.com", Owner: "sales"},
+	}
+
+	tests := map[string]struct {
+		reqBody            *models.ExportRequestModel
+		Failing            string
+		ExpectedStatusCode int
+		ExpectedLines      int
+		ExpectedAbort      bool
+	}{
+		"Stream Error": {
+			reqBody:            &models.ExportRequestModel{Tag: "marketing"},
+			Failing:            "Stream",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Stream Error After First Link": {
+			reqBody:       &models.ExportRequestModel{Owner: "growth"},
+			Failing:       "StreamAfterFirst",
+			ExpectedAbort: true,
+		},
+		"Success": {
+			reqBody:            &models.ExportRequestModel{Owner: "growth"},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedLines:      2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := handlers.NewBaseHandler(logger, newRepository(t, test.Failing, links...), time.Hour)
+
+			body, err := json.Marshal(test.reqBody)
+
+			if err != nil {
+				t.Error("Error marshalling request body")
+			}
+
+			req := httptest.NewRequest("POST", "/links/export", bytes.NewBuffer(body))
+			resp := httptest.NewRecorder()
+
+			if test.ExpectedAbort {
+				assert.PanicsWithValue(t, http.ErrAbortHandler, func() { handler.HandleExportLinks(resp, req) })
+				return
+			}
+
+			handler.HandleExportLinks(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode == http.StatusOK {
+				assert.Equal(t, test.ExpectedLines, strings.Count(resp.Body.String(), "\n"))
+			}
+		})
+	}
+}
+
+func TestHandleImportLinks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		reqBody               *models.ImportRequestModel
+		CreateManyReturnErrs  []error
+		CreateManyReturnError error
+		CreateManyCall        int
+		ExpectedStatusCode    int
+		ExpectedStatuses      []string
+	}{
+		"Empty Request Body": {
+			reqBody:            nil,
+			CreateManyCall:     1,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedStatuses:   []string{},
+		},
+		"Error CreateMany": {
+			reqBody:               &models.ImportRequestModel{Links: []models.LinkModel{{Url: "http://a.com"}}},
+			CreateManyReturnError: assert.AnError,
+			CreateManyCall:        1,
+			ExpectedStatusCode:    http.StatusInternalServerError,
+		},
+		"Mixed Results": {
+			reqBody: &models.ImportRequestModel{Links: []models.LinkModel{
+				{ShortUrlPath: "abc", Url: "http://a.com"},
+				{ShortUrlPath: "bad path", Url: "http://b.com"},
+				{ShortUrlPath: "taken", Url: "http://c.com"},
+				{ShortUrlPath: "empty"},
+				{Url: "http://d.com"},
+				{ShortUrlPath: "broken", Url: "http://e.com"},
+			}},
+			CreateManyReturnErrs: []error{nil, database.ErrDuplicate, nil, assert.AnError},
+			CreateManyCall:       1,
+			ExpectedStatusCode:   http.StatusOK,
+			ExpectedStatuses: []string{
+				models.ImportStatusCreated,
+				models.ImportStatusInvalid,
+				models.ImportStatusConflict,
+				models.ImportStatusInvalid,
+				models.ImportStatusCreated,
+				models.ImportStatusFailed,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockObj := mock_database.NewMockRepository(mockCtrl)
+			mockObj.EXPECT().CreateMany(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, documents []models.URL) ([]error, error) {
+				if test.CreateManyReturnError != nil {
+					return nil, test.CreateManyReturnError
+				}
+				if test.CreateManyReturnErrs == nil {
+					return make([]error, len(documents)), nil
+				}
+				return test.CreateManyReturnErrs, nil
+			}).Times(test.CreateManyCall)
+
+			handler := handlers.NewBaseHandler(logger, mockObj, time.Hour)
+
+			body, err := json.Marshal(test.reqBody)
+
+			if err != nil {
+				t.Error("Error marshalling request body")
+			}
+
+			req := httptest.NewRequest("POST", "/links/import", bytes.NewBuffer(body))
+			resp := httptest.NewRecorder()
+			handler.HandleImportLinks(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode != http.StatusOK {
+				return
+			}
+
+			response := models.ImportResponseModel{}
+			assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+			statuses := []string{}
+			for _, result := range response.Results {
+				statuses = append(statuses, result.Status)
+			}
+
+			assert.Equal(t, test.ExpectedStatuses, statuses)
+		})
+	}
+}
+
+func TestHandleImportLinksGeneratedKeyTaken(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		Duplicates       int
+		CreateCallTimes  int
+		ExpectedStatuses []string
+		ExpectedRetries  float64
+	}{
+		"Retried": {
+			CreateCallTimes:  1,
+			ExpectedStatuses: []string{models.ImportStatusCreated, models.ImportStatusConflict},
+			ExpectedRetries:  1,
+		},
+		"Gives Up": {
+			Duplicates:       100,
+			CreateCallTimes:  5,
+			ExpectedStatuses: []string{models.ImportStatusFailed, models.ImportStatusConflict},
+			ExpectedRetries:  6,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockObj := mock_database.NewMockRepository(mockCtrl)
+
+			mockObj.EXPECT().CreateMany(gomock.Any(), gomock.Any()).Return([]error{database.ErrDuplicate, database.ErrDuplicate}, nil).Times(1)
+
+			created := ""
+			duplicates := test.Duplicates
+			mockObj.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, url models.URL) error {
+				assert.Equal(t, "http://a.com", url.OriginalUrl, "only the generated short url path is retried")
+				if duplicates > 0 {
+					duplicates--
+					return database.ErrDuplicate
+				}
+				created = url.ShortUrlPath
+				return nil
+			}).Times(test.CreateCallTimes)
+
+			handler := handlers.NewBaseHandler(logger, mockObj, time.Hour)
+			retries := testutil.ToFloat64(metrics.KeyGenerationRetries)
+
+			body := `{"links":[{"url":"http://a.com"},{"shorturlpath":"taken","url":"http://b.com"}]}`
+
+			req := httptest.NewRequest("POST", "/links/import", strings.NewReader(body))
+			resp := httptest.NewRecorder()
+			handler.HandleImportLinks(resp, req)
+			assert.Equal(t, http.StatusOK, resp.Code, resp.Result().Status)
+
+			response := models.ImportResponseModel{}
+			assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+			statuses := []string{}
+			for _, result := range response.Results {
+				statuses = append(statuses, result.Status)
+			}
+
+			assert.Equal(t, test.ExpectedStatuses, statuses)
+			assert.Equal(t, created, response.Results[0].ShortUrlPath)
+			assert.Equal(t, retries+test.ExpectedRetries, testutil.ToFloat64(metrics.KeyGenerationRetries))
+		})
+	}
+}
+
+func TestHandleImportLinksConflict(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	repository := newRepository(t, "", models.URL{ShortUrlPath: "taken", OriginalUrl: "http://taken.com"})
+	handler := handlers.NewBaseHandler(logger, repository, time.Hour)
+
+	body := `{"links":[{"shorturlpath":"fresh","url":"http://a.com"},{"shorturlpath":"taken","url":"http://b.com"}]}`
+
+	req := httptest.NewRequest("POST", "/links/import", strings.NewReader(body))
+	resp := httptest.NewRecorder()
+	handler.HandleImportLinks(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code, resp.Result().Status)
+
+	response := models.ImportResponseModel{}
+	assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+	assert.Equal(t, models.ImportStatusCreated, response.Results[0].Status)
+	assert.Equal(t, models.ImportStatusConflict, response.Results[1].Status)
+
+	url, err := repository.GetByCode(context.Background(), "taken")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://taken.com", url.OriginalUrl, "conflicting links are left as they are")
+}
+
+func TestHandleGetLink(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		shortUrlPath       string
+		Failing            string
+		ExpectedStatusCode int
+	}{
+		"Not Found": {
+			shortUrlPath:       "missing",
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		"Error GetByCode": {
+			shortUrlPath:       "test",
+			Failing:            "GetByCode",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Success": {
+			shortUrlPath:       "test",
+			ExpectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := handlers.NewBaseHandler(logger, newRepository(t, test.Failing, models.URL{ShortUrlPath: "test", OriginalUrl: "http://www.google.com"}), time.Hour)
+
+			req := httptest.NewRequest("GET", "/links/"+test.shortUrlPath, nil)
+			req = mux.SetURLVars(req, map[string]string{"shorturlpath": test.shortUrlPath})
+			resp := httptest.NewRecorder()
+			handler.HandleGetLink(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+		})
+	}
+}
+
+func TestHandleUpdateLink(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		shortUrlPath       string
+		reqBody            string
+		Failing            string
+		ExpectedStatusCode int
+		ExpectedLink       models.LinkModel
+	}{
+		"Invalid Body": {
+			shortUrlPath:       "test",
+			reqBody:            "{",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Nothing To Update": {
+			shortUrlPath:       "test",
+			reqBody:            "{}",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Empty Url": {
+			shortUrlPath:       "test",
+			reqBody:            `{"url":""}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Not Found": {
+			shortUrlPath:       "missing",
+			reqBody:            `{"title":"New title"}`,
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		"Error Update": {
+			shortUrlPath:       "test",
+			reqBody:            `{"title":"New title"}`,
+			Failing:            "Update",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Success": {
+			shortUrlPath:       "test",
+			reqBody:            `{"url":"http://www.google.com","tags":["A"],"expires_at":"2030-01-01T00:00:00Z"}`,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedLink: models.LinkModel{
+				ShortUrlPath: "test",
+				Url:          "http://www.google.com",
+				Title:        "Old title",
+				Tags:         []string{"a"},
+				ExpiresAt:    time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := handlers.NewBaseHandler(logger, newRepository(t, test.Failing, models.URL{ShortUrlPath: "test", OriginalUrl: "http://example.com", Title: "Old title"}), time.Hour)
+
+			req := httptest.NewRequest("PATCH", "/links/"+test.shortUrlPath, strings.NewReader(test.reqBody))
+			req = mux.SetURLVars(req, map[string]string{"shorturlpath": test.shortUrlPath})
+			resp := httptest.NewRecorder()
+			handler.HandleUpdateLink(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode != http.StatusOK {
+				return
+			}
+
+			response := models.LinkModel{}
+			assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+			assert.Equal(t, test.ExpectedLink, response)
+		})
+	}
+}
+
+func TestHandleDeleteLink(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		shortUrlPath       string
+		Failing            string
+		ExpectedStatusCode int
+	}{
+		"Not Found": {
+			shortUrlPath:       "missing",
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		"Error Delete": {
+			shortUrlPath:       "test",
+			Failing:            "Delete",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Already Deleted": {
+			shortUrlPath:       "deleted",
+			ExpectedStatusCode: http.StatusGone,
+		},
+		"Success": {
+			shortUrlPath:       "test",
+			ExpectedStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repository := newRepository(t, test.Failing, models.URL{ShortUrlPath: "test"}, models.URL{ShortUrlPath: "deleted", DeletedAt: time.Now()})
+			handler := handlers.NewBaseHandler(logger, repository, time.Hour)
+
+			req := httptest.NewRequest("DELETE", "/links/"+test.shortUrlPath, nil)
+			req = mux.SetURLVars(req, map[string]string{"shorturlpath": test.shortUrlPath})
+			resp := httptest.NewRecorder()
+			handler.HandleDeleteLink(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode == http.StatusNoContent {
+				url, err := repository.GetByCode(context.Background(), "test")
+				assert.Nil(t, err)
+				assert.True(t, url.Deleted(), "the link is kept until it is purged")
+			}
+		})
+	}
+}
+
+func TestHandleRestoreLink(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		shortUrlPath       string
+		Failing            string
+		ExpectedStatusCode int
+	}{
+		"Not Found": {
+			shortUrlPath:       "missing",
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		"Not Deleted": {
+			shortUrlPath:       "live",
+			ExpectedStatusCode: http.StatusConflict,
+		},
+		"Past Retention": {
+			shortUrlPath:       "old",
+			ExpectedStatusCode: http.StatusGone,
+		},
+		"Error Restore": {
+			shortUrlPath:       "deleted",
+			Failing:            "Restore",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Success": {
+			shortUrlPath:       "deleted",
+			ExpectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repository := newRepository(t, test.Failing,
+				models.URL{ShortUrlPath: "live", OriginalUrl: "http://live.com"},
+				models.URL{ShortUrlPath: "deleted", OriginalUrl: "http://deleted.com", DeletedAt: time.Now().Add(-time.Minute)},
+				models.URL{ShortUrlPath: "old", OriginalUrl: "http://old.com", DeletedAt: time.Now().Add(-2 * time.Hour)},
+			)
+			handler := handlers.NewBaseHandler(logger, repository, time.Hour)
+
+			req := httptest.NewRequest("POST", "/links/"+test.shortUrlPath+"/restore", nil)
+			req = mux.SetURLVars(req, map[string]string{"shorturlpath": test.shortUrlPath})
+			resp := httptest.NewRecorder()
+			handler.HandleRestoreLink(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode == http.StatusOK {
+				link := models.LinkModel{}
+				assert.Nil(t, json.NewDecoder(resp.Body).Decode(&link))
+				assert.Equal(t, "http://deleted.com", link.Url)
+
+				url, err := repository.GetByCode(context.Background(), "deleted")
+				assert.Nil(t, err)
+				assert.False(t, url.Deleted())
+			}
+		})
+	}
+}
+
+func TestHandleLinkStats(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	tests := map[string]struct {
+		reqUrl             string
+		shortUrlPath       string
+		Failing            string
+		ExpectedStatusCode int
+		ExpectedTotal      int64
+		ExpectedDays       int
+		ExpectedToday      int64
+	}{
+		"Invalid Days": {
+			reqUrl:             "/links/test/stats?days=0",
+			shortUrlPath:       "test",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Not Found": {
+			reqUrl:             "/links/missing/stats",
+			shortUrlPath:       "missing",
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		"Error FindClicks": {
+			reqUrl:             "/links/test/stats",
+			shortUrlPath:       "test",
+			Failing:            "FindClicks",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Success": {
+			reqUrl:             "/links/test/stats?days=7",
+			shortUrlPath:       "test",
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedTotal:      105,
+			ExpectedDays:       7,
+			ExpectedToday:      5,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repository := newRepository(t, test.Failing, models.URL{ShortUrlPath: "test"})
+			assert.Nil(t, repository.IncrementClicks(context.Background(), "test", today.AddDate(-1, 0, 0), 100))
+			assert.Nil(t, repository.IncrementClicks(context.Background(), "test", today, 5))
+
+			handler := handlers.NewBaseHandler(logger, repository, time.Hour)
+
+			req := httptest.NewRequest("GET", test.reqUrl, nil)
+			req = mux.SetURLVars(req, map[string]string{"shorturlpath": test.shortUrlPath})
+			resp := httptest.NewRecorder()
+			handler.HandleLinkStats(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode != http.StatusOK {
+				return
+			}
+
+			response := models.StatsResponseModel{}
+			assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+			assert.Equal(t, test.ExpectedTotal, response.TotalClicks)
+			assert.Equal(t, test.ExpectedDays, len(response.Daily))
+			assert.Equal(t, test.ExpectedToday, response.Daily[len(response.Daily)-1].Count)
+		})
+	}
+}
+
+func TestHandleTopLinks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	links := []models.URL{{ShortUrlPath: "least"}, {ShortUrlPath: "most"}, {ShortUrlPath: "unclicked"}}
+
+	tests := map[string]struct {
+		reqBody            string
+		clicks             map[string]int64
+		Failing            string
+		ExpectedStatusCode int
+		ExpectedLinks      []string
+	}{
+		"Invalid Body": {
+			reqBody:            "{",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Invalid Limit": {
+			reqBody:            `{"limit":5000}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Error FindTopClicked": {
+			reqBody:            `{}`,
+			Failing:            "FindTopClicked",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"No Clicks": {
+			reqBody:            `{}`,
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedLinks:      []string{},
+		},
+		"Success": {
+			reqBody:            `{"limit":3,"days":30}`,
+			clicks:             map[string]int64{"most": 100, "deleted": 50, "least": 5},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedLinks:      []string{"most", "least"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repository := newRepository(t, test.Failing, links...)
+
+			for shortUrlPath, count := range test.clicks {
+				assert.Nil(t, repository.IncrementClicks(context.Background(), shortUrlPath, time.Now(), count))
+			}
+
+			handler := handlers.NewBaseHandler(logger, repository, time.Hour)
+
+			req := httptest.NewRequest("POST", "/links/top", strings.NewReader(test.reqBody))
+			resp := httptest.NewRecorder()
+			handler.HandleTopLinks(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode != http.StatusOK {
+				return
+			}
+
+			response := models.ListResponseModel{}
+			assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+
+			paths := []string{}
+			for _, link := range response.Links {
+				paths = append(paths, link.ShortUrlPath)
+			}
+			assert.Equal(t, test.ExpectedLinks, paths)
+		})
+	}
+}
+
+func TestHandleRecordClicks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		reqBody            string
+		Failing            string
+		ExpectedStatusCode int
+		ExpectedClicks     map[string]int64
+	}{
+		"Invalid Body": {
+			reqBody:            "{",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Error IncrementClicks": {
+			reqBody:            `{"clicks":{"abc":2}}`,
+			Failing:            "IncrementClicks",
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"Success": {
+			reqBody:            `{"clicks":{"abc":2,"def":1,"ignored":0}}`,
+			ExpectedStatusCode: http.StatusNoContent,
+			ExpectedClicks:     map[string]int64{"abc": 2, "def": 1, "ignored": 0},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repository := newRepository(t, test.Failing)
+			handler := handlers.NewBaseHandler(logger, repository, time.Hour)
+
+			req := httptest.NewRequest("POST", "/clicks", strings.NewReader(test.reqBody))
+			resp := httptest.NewRecorder()
+			handler.HandleRecordClicks(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			for shortUrlPath, expected := range test.ExpectedClicks {
+				buckets, err := repository.FindClicks(context.Background(), shortUrlPath)
+				assert.Nil(t, err)
+
+				total := int64(0)
+				for _, bucket := range buckets {
+					total += bucket.Count
+				}
+				assert.Equal(t, expected, total, shortUrlPath)
+			}
 		})
 	}
 }
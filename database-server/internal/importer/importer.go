@@ -0,0 +1,285 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"url-shortner-database/internal/database"
+	"url-shortner-database/internal/models"
+	"url-shortner-database/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictRename    = "rename"
+	ConflictFail      = "fail"
+
+	maxRenameAttempts = 5
+)
+
+var ErrConflict = errors.New("short url path already exists")
+
+type Options struct {
+	DryRun     bool
+	OnConflict string
+	BatchSize  int
+	Owner      string
+	Tags       []string
+	Expiry     time.Duration
+}
+
+type Report struct {
+	Read        int
+	Created     int
+	Overwritten int
+	Renamed     int
+	Skipped     int
+	Invalid     int
+	Failed      int
+	Clicks      int64
+	Errors      []string
+	Renames     map[string]string
+}
+
+type Importer struct {
+	db      database.Repository
+	logger  *zap.SugaredLogger
+	options Options
+	now     func() time.Time
+}
+
+func NewImporter(db database.Repository, logger *zap.SugaredLogger, options Options) (*Importer, error) {
+	switch options.OnConflict {
+	case "":
+		options.OnConflict = ConflictSkip
+	case ConflictSkip, ConflictOverwrite, ConflictRename, ConflictFail:
+	default:
+		return nil, fmt.Errorf("unknown conflict mode %q", options.OnConflict)
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = 500
+	}
+
+	return &Importer{
+		db:      db,
+		logger:  logger,
+		options: options,
+		now:     time.Now,
+	}, nil
+}
+
+// Run reads every record of the source and writes it in batches. Invalid
+// records are reported and skipped; with ConflictFail the first existing short
+// url path stops the import. Cancelling ctx stops it between records.
+func (i *Importer) Run(ctx context.Context, source Source) (*Report, error) {
+	report := &Report{Renames: map[string]string{}}
+	batch := []Record{}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		record, err := source.Next()
+
+		if err == io.EOF {
+			break
+		}
+
+		var recordErr *RecordError
+		if errors.As(err, &recordErr) {
+			report.Read++
+			report.Invalid++
+			report.Errors = append(report.Errors, recordErr.Error())
+			continue
+		}
+
+		if err != nil {
+			return report, err
+		}
+
+		report.Read++
+
+		if !utils.IsValidShortUrlPath(record.URL.ShortUrlPath) {
+			report.Invalid++
+			report.Errors = append(report.Errors, fmt.Sprintf("record %d: invalid short url path %q", record.Line, record.URL.ShortUrlPath))
+			continue
+		}
+
+		batch = append(batch, i.prepare(record))
+
+		if len(batch) >= i.options.BatchSize {
+			if err := i.writeBatch(ctx, batch, report); err != nil {
+				return report, err
+			}
+
+			batch = batch[:0]
+		}
+	}
+
+	if err := i.writeBatch(ctx, batch, report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+func (i *Importer) prepare(record Record) Record {
+	now := i.now()
+
+	if record.URL.CreatedAt.IsZero() {
+		record.URL.CreatedAt = now
+	}
+
+	record.URL.ExpiresAt = now.Add(i.options.Expiry)
+	record.URL.Tags = utils.NormalizeTags(append(record.URL.Tags, i.options.Tags...))
+
+	if i.options.Owner != "" {
+		record.URL.Owner = i.options.Owner
+	}
+
+	return record
+}
+
+func (i *Importer) writeBatch(ctx context.Context, batch []Record, report *Report) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if i.options.DryRun {
+		return i.planBatch(ctx, batch, report)
+	}
+
+	documents := make([]models.URL, len(batch))
+	for index, record := range batch {
+		documents[index] = record.URL
+	}
+
+	errs, err := i.db.CreateMany(ctx, documents)
+
+	if err != nil {
+		return err
+	}
+
+	for index, record := range batch {
+		switch {
+		case errs[index] == nil:
+			report.Created++
+			i.seedClicks(ctx, record, report)
+		case errors.Is(errs[index], database.ErrDuplicate):
+			if err := i.resolveConflict(ctx, record, report); err != nil {
+				return err
+			}
+		default:
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("record %d: %v", record.Line, errs[index]))
+		}
+	}
+
+	i.logger.Infow("Imported batch", zap.Int("size", len(batch)), zap.Int("read", report.Read))
+
+	return nil
+}
+
+func (i *Importer) planBatch(ctx context.Context, batch []Record, report *Report) error {
+	for _, record := range batch {
+		existing, err := i.db.GetByCode(ctx, record.URL.ShortUrlPath)
+
+		if errors.Is(err, database.ErrNotFound) {
+			report.Created++
+			report.Clicks += record.Clicks
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		switch i.options.OnConflict {
+		case ConflictSkip:
+			report.Skipped++
+		case ConflictOverwrite:
+			// Replace does not bring a deleted link back.
+			if existing.Deleted() {
+				report.Failed++
+				report.Errors = append(report.Errors, fmt.Sprintf("record %d: %v", record.Line, database.ErrDeleted))
+				continue
+			}
+
+			report.Overwritten++
+			report.Clicks += record.Clicks
+		case ConflictRename:
+			report.Renamed++
+			report.Clicks += record.Clicks
+		case ConflictFail:
+			return fmt.Errorf("record %d: %q: %w", record.Line, record.URL.ShortUrlPath, ErrConflict)
+		}
+	}
+
+	return nil
+}
+
+func (i *Importer) resolveConflict(ctx context.Context, record Record, report *Report) error {
+	switch i.options.OnConflict {
+	case ConflictSkip:
+		report.Skipped++
+		return nil
+	case ConflictFail:
+		return fmt.Errorf("record %d: %q: %w", record.Line, record.URL.ShortUrlPath, ErrConflict)
+	case ConflictOverwrite:
+		if err := i.db.Replace(ctx, record.URL); err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("record %d: %v", record.Line, err))
+			return nil
+		}
+
+		report.Overwritten++
+		i.seedClicks(ctx, record, report)
+		return nil
+	}
+
+	original := record.URL.ShortUrlPath
+
+	for attempt := 0; attempt < maxRenameAttempts; attempt++ {
+		record.URL.ShortUrlPath = utils.KeyGenerationService(record.URL.OriginalUrl + original)
+
+		err := i.db.Create(ctx, record.URL)
+
+		if err == nil {
+			report.Renamed++
+			report.Renames[original] = record.URL.ShortUrlPath
+			i.seedClicks(ctx, record, report)
+			return nil
+		}
+
+		if !errors.Is(err, database.ErrDuplicate) {
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("record %d: %v", record.Line, err))
+			return nil
+		}
+	}
+
+	report.Failed++
+	report.Errors = append(report.Errors, fmt.Sprintf("record %d: could not find a free short url path for %q", record.Line, original))
+
+	return nil
+}
+
+func (i *Importer) seedClicks(ctx context.Context, record Record, report *Report) {
+	if record.Clicks <= 0 {
+		return
+	}
+
+	if err := i.db.IncrementClicks(ctx, record.URL.ShortUrlPath, record.URL.CreatedAt, record.Clicks); err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("record %d: seeding clicks: %v", record.Line, err))
+		return
+	}
+
+	report.Clicks += record.Clicks
+}
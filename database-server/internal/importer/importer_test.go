@@ -0,0 +1,175 @@
+package importer_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+	"url-shortner-database/internal/database"
+	mock_database "url-shortner-database/internal/database/mocks"
+	"url-shortner-database/internal/importer"
+	"url-shortner-database/internal/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type sliceSource struct {
+	records []importer.Record
+	errs    []error
+}
+
+func (s *sliceSource) Next() (importer.Record, error) {
+	if len(s.records) == 0 {
+		return importer.Record{}, io.EOF
+	}
+
+	record, err := s.records[0], s.errs[0]
+	s.records, s.errs = s.records[1:], s.errs[1:]
+
+	return record, err
+}
+
+func newSource() *sliceSource {
+	return &sliceSource{
+		records: []importer.Record{
+			{Line: 1, URL: models.URL{ShortUrlPath: "new", OriginalUrl: "http://new.com"}, Clicks: 5},
+			{Line: 2, URL: models.URL{ShortUrlPath: "taken", OriginalUrl: "http://taken.com"}, Clicks: 7},
+			{Line: 3, URL: models.URL{ShortUrlPath: "not valid", OriginalUrl: "http://invalid.com"}},
+			{Line: 4},
+		},
+		errs: []error{nil, nil, nil, &importer.RecordError{Line: 4, Err: errors.New("missing keyword or url")}},
+	}
+}
+
+func TestRun(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		options       importer.Options
+		setup         func(mockDb *mock_database.MockRepository)
+		expectedError error
+		expected      importer.Report
+	}{
+		"Skip": {
+			options: importer.Options{OnConflict: importer.ConflictSkip, Tags: []string{"Imported"}},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().CreateMany(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, documents []models.URL) ([]error, error) {
+					assert.Equal(t, []string{"imported"}, documents[0].Tags)
+					assert.False(t, documents[0].CreatedAt.IsZero())
+					return []error{nil, database.ErrDuplicate}, nil
+				})
+				mockDb.EXPECT().IncrementClicks(gomock.Any(), "new", gomock.Any(), int64(5)).Return(nil)
+			},
+			expected: importer.Report{Read: 4, Created: 1, Skipped: 1, Invalid: 2, Clicks: 5},
+		},
+		"Overwrite": {
+			options: importer.Options{OnConflict: importer.ConflictOverwrite},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().CreateMany(gomock.Any(), gomock.Any()).Return([]error{nil, database.ErrDuplicate}, nil)
+				mockDb.EXPECT().Replace(gomock.Any(), gomock.Any()).Return(nil)
+				mockDb.EXPECT().IncrementClicks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			expected: importer.Report{Read: 4, Created: 1, Overwritten: 1, Invalid: 2, Clicks: 12},
+		},
+		"Overwrite Deleted": {
+			options: importer.Options{OnConflict: importer.ConflictOverwrite},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().CreateMany(gomock.Any(), gomock.Any()).Return([]error{nil, database.ErrDuplicate}, nil)
+				mockDb.EXPECT().Replace(gomock.Any(), gomock.Any()).Return(database.ErrDeleted)
+				mockDb.EXPECT().IncrementClicks(gomock.Any(), "new", gomock.Any(), int64(5)).Return(nil)
+			},
+			expected: importer.Report{Read: 4, Created: 1, Failed: 1, Invalid: 2, Clicks: 5},
+		},
+		"Rename": {
+			options: importer.Options{OnConflict: importer.ConflictRename},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().CreateMany(gomock.Any(), gomock.Any()).Return([]error{nil, database.ErrDuplicate}, nil)
+				gomock.InOrder(
+					mockDb.EXPECT().Create(gomock.Any(), gomock.Any()).Return(database.ErrDuplicate),
+					mockDb.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+				)
+				mockDb.EXPECT().IncrementClicks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			expected: importer.Report{Read: 4, Created: 1, Renamed: 1, Invalid: 2, Clicks: 12},
+		},
+		"Fail": {
+			options: importer.Options{OnConflict: importer.ConflictFail},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().CreateMany(gomock.Any(), gomock.Any()).Return([]error{nil, database.ErrDuplicate}, nil)
+				mockDb.EXPECT().IncrementClicks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedError: importer.ErrConflict,
+			expected:      importer.Report{Read: 4, Created: 1, Invalid: 2, Clicks: 5},
+		},
+		"Dry Run": {
+			options: importer.Options{DryRun: true, OnConflict: importer.ConflictRename, BatchSize: 1},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().GetByCode(gomock.Any(), "new").Return(models.URL{}, database.ErrNotFound)
+				mockDb.EXPECT().GetByCode(gomock.Any(), "taken").Return(models.URL{ShortUrlPath: "taken"}, nil)
+			},
+			expected: importer.Report{Read: 4, Created: 1, Renamed: 1, Invalid: 2, Clicks: 12},
+		},
+		"Dry Run Overwrite Deleted": {
+			options: importer.Options{DryRun: true, OnConflict: importer.ConflictOverwrite, BatchSize: 1},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().GetByCode(gomock.Any(), "new").Return(models.URL{}, database.ErrNotFound)
+				mockDb.EXPECT().GetByCode(gomock.Any(), "taken").Return(models.URL{ShortUrlPath: "taken", DeletedAt: time.Now()}, nil)
+			},
+			expected: importer.Report{Read: 4, Created: 1, Failed: 1, Invalid: 2, Clicks: 5},
+		},
+		"Dry Run Error": {
+			options: importer.Options{DryRun: true},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().GetByCode(gomock.Any(), "new").Return(models.URL{}, assert.AnError)
+			},
+			expectedError: assert.AnError,
+			expected:      importer.Report{Read: 4, Invalid: 2},
+		},
+		"Insert Error": {
+			options: importer.Options{},
+			setup: func(mockDb *mock_database.MockRepository) {
+				mockDb.EXPECT().CreateMany(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			expectedError: assert.AnError,
+			expected:      importer.Report{Read: 4, Invalid: 2},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDb := mock_database.NewMockRepository(mockCtrl)
+			test.setup(mockDb)
+
+			test.options.Expiry = time.Hour
+
+			linkImporter, err := importer.NewImporter(mockDb, logger, test.options)
+			assert.Nil(t, err)
+
+			report, err := linkImporter.Run(context.Background(), newSource())
+
+			if test.expectedError != nil {
+				assert.True(t, errors.Is(err, test.expectedError), "Expected %v, got %v", test.expectedError, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, test.expected.Read, report.Read)
+			assert.Equal(t, test.expected.Created, report.Created)
+			assert.Equal(t, test.expected.Overwritten, report.Overwritten)
+			assert.Equal(t, test.expected.Renamed, report.Renamed)
+			assert.Equal(t, test.expected.Skipped, report.Skipped)
+			assert.Equal(t, test.expected.Failed, report.Failed)
+			assert.Equal(t, test.expected.Invalid, report.Invalid)
+			assert.Equal(t, test.expected.Clicks, report.Clicks)
+		})
+	}
+}
+
+func TestNewImporterInvalidMode(t *testing.T) {
+	_, err := importer.NewImporter(nil, zap.NewNop().Sugar(), importer.Options{OnConflict: "merge"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,305 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"url-shortner-database/internal/models"
+)
+
+const (
+	SourceYourlsSQL = "yourls-sql"
+	SourceYourlsCSV = "yourls-csv"
+	SourceBitlyCSV  = "bitly-csv"
+
+	yourlsTimeLayout = "2006-01-02 15:04:05"
+)
+
+var ErrUnsupportedSource = errors.New("unsupported source")
+
+// yourlsColumns is the column order of the yourls_url table, used when a dump
+// does not list the columns of its INSERT statements.
+var yourlsColumns = []string{"keyword", "url", "title", "timestamp", "ip", "clicks"}
+
+var bitlyColumnAliases = map[string][]string{
+	"link":    {"link", "bitlink", "short_url", "short url", "custom_bitlinks", "custom bitlinks"},
+	"url":     {"long_url", "long url", "destination", "original_url", "url"},
+	"title":   {"title"},
+	"created": {"created_at", "created at", "created", "date created"},
+	"tags":    {"tags"},
+	"clicks":  {"clicks", "total_clicks", "total clicks", "engagements"},
+}
+
+var bitlyTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02", "1/2/2006 15:04", "1/2/2006"}
+
+// Record is a link read from a dump of another shortener.
+type Record struct {
+	Line   int
+	URL    models.URL
+	Clicks int64
+}
+
+// RecordError reports a record that could not be mapped. Reading can
+// continue with the next record.
+type RecordError struct {
+	Line int
+	Err  error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+type Source interface {
+	Next() (Record, error)
+}
+
+func NewSource(source string, r io.Reader, table string) (Source, error) {
+	switch source {
+	case SourceYourlsSQL:
+		return newYourlsSqlSource(r, table), nil
+	case SourceYourlsCSV:
+		return newCsvSource(r, yourlsRecord)
+	case SourceBitlyCSV:
+		return newCsvSource(r, bitlyRecord)
+	default:
+		return nil, ErrUnsupportedSource
+	}
+}
+
+type yourlsSqlSource struct {
+	statements *sqlStatementReader
+	table      string
+	pending    []map[string]string
+	line       int
+}
+
+func newYourlsSqlSource(r io.Reader, table string) *yourlsSqlSource {
+	if table == "" {
+		table = "yourls_url"
+	}
+
+	return &yourlsSqlSource{
+		statements: newSqlStatementReader(r),
+		table:      table,
+	}
+}
+
+func (y *yourlsSqlSource) Next() (Record, error) {
+	for len(y.pending) == 0 {
+		statement, err := y.statements.Next()
+
+		if err != nil {
+			return Record{}, err
+		}
+
+		table, columns, rows, err := parseInsert(statement)
+
+		if err != nil || !strings.EqualFold(table, y.table) {
+			continue
+		}
+
+		if len(columns) == 0 {
+			columns = yourlsColumns
+		}
+
+		for _, row := range rows {
+			values := map[string]string{}
+			for i, column := range columns {
+				if i < len(row) {
+					values[strings.ToLower(column)] = row[i]
+				}
+			}
+
+			y.pending = append(y.pending, values)
+		}
+	}
+
+	values := y.pending[0]
+	y.pending = y.pending[1:]
+	y.line++
+
+	record, err := yourlsRecord(values)
+	record.Line = y.line
+
+	if err != nil {
+		return record, &RecordError{Line: y.line, Err: err}
+	}
+
+	return record, nil
+}
+
+type csvSource struct {
+	reader  *csv.Reader
+	columns []string
+	mapper  func(map[string]string) (Record, error)
+	line    int
+}
+
+func newCsvSource(r io.Reader, mapper func(map[string]string) (Record, error)) (*csvSource, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	for i, column := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+	}
+
+	return &csvSource{reader: reader, columns: columns, mapper: mapper, line: 1}, nil
+}
+
+func (c *csvSource) Next() (Record, error) {
+	row, err := c.reader.Read()
+	c.line++
+
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return Record{Line: c.line}, &RecordError{Line: c.line, Err: err}
+		}
+
+		return Record{}, err
+	}
+
+	values := map[string]string{}
+	for i, column := range c.columns {
+		if i < len(row) {
+			values[column] = strings.TrimSpace(row[i])
+		}
+	}
+
+	record, err := c.mapper(values)
+	record.Line = c.line
+
+	if err != nil {
+		return record, &RecordError{Line: c.line, Err: err}
+	}
+
+	return record, nil
+}
+
+func yourlsRecord(values map[string]string) (Record, error) {
+	record := Record{
+		URL: models.URL{
+			ShortUrlPath: values["keyword"],
+			OriginalUrl:  values["url"],
+			Title:        values["title"],
+		},
+	}
+
+	if record.URL.ShortUrlPath == "" || record.URL.OriginalUrl == "" {
+		return record, errors.New("missing keyword or url")
+	}
+
+	if timestamp := values["timestamp"]; timestamp != "" && !strings.HasPrefix(timestamp, "0000") {
+		createdAt, err := time.Parse(yourlsTimeLayout, timestamp)
+		if err != nil {
+			return record, fmt.Errorf("invalid timestamp: %w", err)
+		}
+		record.URL.CreatedAt = createdAt
+	}
+
+	clicks, err := parseClicks(values["clicks"])
+	record.Clicks = clicks
+
+	return record, err
+}
+
+func bitlyRecord(values map[string]string) (Record, error) {
+	field := func(name string) string {
+		for _, alias := range bitlyColumnAliases[name] {
+			if value := values[alias]; value != "" {
+				return value
+			}
+		}
+
+		return ""
+	}
+
+	record := Record{
+		URL: models.URL{
+			ShortUrlPath: bitlyKeyword(field("link")),
+			OriginalUrl:  field("url"),
+			Title:        field("title"),
+		},
+	}
+
+	if record.URL.ShortUrlPath == "" || record.URL.OriginalUrl == "" {
+		return record, errors.New("missing bitlink or long url")
+	}
+
+	if tags := field("tags"); tags != "" {
+		record.URL.Tags = strings.FieldsFunc(tags, func(r rune) bool { return r == ',' || r == '|' || r == ';' })
+	}
+
+	if created := field("created"); created != "" {
+		createdAt, err := parseBitlyTime(created)
+		if err != nil {
+			return record, err
+		}
+		record.URL.CreatedAt = createdAt
+	}
+
+	clicks, err := parseClicks(field("clicks"))
+	record.Clicks = clicks
+
+	return record, err
+}
+
+// bitlyKeyword extracts the back-half of a bitlink, e.g. "abc123" from
+// "https://bit.ly/abc123".
+func bitlyKeyword(link string) string {
+	if link == "" {
+		return ""
+	}
+
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	return strings.Trim(parsed.Path, "/")
+}
+
+func parseBitlyTime(value string) (time.Time, error) {
+	for _, layout := range bitlyTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid created time %q", value)
+}
+
+func parseClicks(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	clicks, err := strconv.ParseInt(strings.ReplaceAll(value, ",", ""), 10, 64)
+
+	if err != nil || clicks < 0 {
+		return 0, fmt.Errorf("invalid clicks %q", value)
+	}
+
+	return clicks, nil
+}
@@ -0,0 +1,113 @@
+package importer_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"url-shortner-database/internal/importer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readAll(t *testing.T, source importer.Source) ([]importer.Record, int) {
+	records := []importer.Record{}
+	invalid := 0
+
+	for {
+		record, err := source.Next()
+
+		if err == io.EOF {
+			return records, invalid
+		}
+
+		var recordErr *importer.RecordError
+		if errors.As(err, &recordErr) {
+			invalid++
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		records = append(records, record)
+	}
+}
+
+func TestYourlsSqlSource(t *testing.T) {
+	dump := "-- MySQL dump\n" +
+		"/*!40101 SET NAMES utf8 */;\n" +
+		"CREATE TABLE `yourls_url` (`keyword` varchar(200) NOT NULL);\n" +
+		"INSERT INTO `yourls_options` VALUES (1,'version','1.9');\n" +
+		"INSERT INTO `yourls_url` VALUES ('ozh','http://ozh.org/','Ozh; \\'s blog','2020-01-02 03:04:05','127.0.0.1',12)," +
+		"('yourls','http://yourls.org/',NULL,'0000-00-00 00:00:00','127.0.0.1',0);\n" +
+		"INSERT INTO `yourls_url` (`url`, `keyword`, `clicks`) VALUES ('http://example.com/a,b','ex',3),('','broken',0);\n"
+
+	source, err := importer.NewSource(importer.SourceYourlsSQL, strings.NewReader(dump), "")
+	assert.Nil(t, err)
+
+	records, invalid := readAll(t, source)
+
+	assert.Equal(t, 1, invalid)
+	assert.Equal(t, 3, len(records))
+
+	assert.Equal(t, "ozh", records[0].URL.ShortUrlPath)
+	assert.Equal(t, "http://ozh.org/", records[0].URL.OriginalUrl)
+	assert.Equal(t, "Ozh; 's blog", records[0].URL.Title)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), records[0].URL.CreatedAt)
+	assert.Equal(t, int64(12), records[0].Clicks)
+
+	assert.Equal(t, "yourls", records[1].URL.ShortUrlPath)
+	assert.True(t, records[1].URL.CreatedAt.IsZero())
+
+	assert.Equal(t, "ex", records[2].URL.ShortUrlPath)
+	assert.Equal(t, "http://example.com/a,b", records[2].URL.OriginalUrl)
+	assert.Equal(t, int64(3), records[2].Clicks)
+}
+
+func TestYourlsCsvSource(t *testing.T) {
+	dump := "keyword,url,title,timestamp,ip,clicks\n" +
+		"ozh,http://ozh.org/,Ozh,2020-01-02 03:04:05,127.0.0.1,12\n" +
+		"bad,http://bad.org/,Bad,yesterday,127.0.0.1,1\n"
+
+	source, err := importer.NewSource(importer.SourceYourlsCSV, strings.NewReader(dump), "")
+	assert.Nil(t, err)
+
+	records, invalid := readAll(t, source)
+
+	assert.Equal(t, 1, invalid)
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, "ozh", records[0].URL.ShortUrlPath)
+	assert.Equal(t, 2, records[0].Line)
+}
+
+func TestBitlyCsvSource(t *testing.T) {
+	dump := "\ufeffTitle,Long URL,Bitlink,Created At,Tags,Total Clicks\n" +
+		"Launch,https://example.com/launch,bit.ly/launch24,2024-03-01 10:00:00,\"marketing,q1\",\"1,024\"\n" +
+		"Docs,https://example.com/docs,https://bit.ly/3xYz,2024-03-02,,\n" +
+		"Missing,,bit.ly/missing,,,\n"
+
+	source, err := importer.NewSource(importer.SourceBitlyCSV, strings.NewReader(dump), "")
+	assert.Nil(t, err)
+
+	records, invalid := readAll(t, source)
+
+	assert.Equal(t, 1, invalid)
+	assert.Equal(t, 2, len(records))
+
+	assert.Equal(t, "launch24", records[0].URL.ShortUrlPath)
+	assert.Equal(t, "https://example.com/launch", records[0].URL.OriginalUrl)
+	assert.Equal(t, "Launch", records[0].URL.Title)
+	assert.Equal(t, []string{"marketing", "q1"}, records[0].URL.Tags)
+	assert.Equal(t, int64(1024), records[0].Clicks)
+
+	assert.Equal(t, "3xYz", records[1].URL.ShortUrlPath)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), records[1].URL.CreatedAt)
+}
+
+func TestUnsupportedSource(t *testing.T) {
+	_, err := importer.NewSource("tinyurl", strings.NewReader(""), "")
+	assert.Equal(t, importer.ErrUnsupportedSource, err)
+}
@@ -0,0 +1,258 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var insertPattern = regexp.MustCompile("(?is)^INSERT\\s+(?:IGNORE\\s+)?INTO\\s+`?([\\w.]+)`?\\s*(?:\\(([^)]*)\\))?\\s*VALUES\\s*")
+
+// sqlStatementReader splits a SQL dump into statements without loading the
+// whole dump in memory. Only one statement is held at a time.
+type sqlStatementReader struct {
+	reader *bufio.Reader
+}
+
+func newSqlStatementReader(r io.Reader) *sqlStatementReader {
+	return &sqlStatementReader{reader: bufio.NewReader(r)}
+}
+
+// Next returns the next statement without its trailing semicolon. Comments
+// outside of string literals are dropped.
+func (s *sqlStatementReader) Next() (string, error) {
+	var statement strings.Builder
+	var quote rune
+
+	for {
+		char, _, err := s.reader.ReadRune()
+
+		if err == io.EOF {
+			if strings.TrimSpace(statement.String()) == "" {
+				return "", io.EOF
+			}
+
+			return strings.TrimSpace(statement.String()), nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if quote != 0 {
+			statement.WriteRune(char)
+
+			switch char {
+			case '\\':
+				escaped, _, err := s.reader.ReadRune()
+				if err != nil {
+					return "", fmt.Errorf("unterminated string literal: %w", err)
+				}
+				statement.WriteRune(escaped)
+			case quote:
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch char {
+		case '\'', '"', '`':
+			quote = char
+			statement.WriteRune(char)
+		case ';':
+			if strings.TrimSpace(statement.String()) != "" {
+				return strings.TrimSpace(statement.String()), nil
+			}
+		case '-':
+			next, _ := s.reader.Peek(1)
+			if len(next) == 1 && next[0] == '-' {
+				s.reader.ReadString('\n')
+				statement.WriteRune('\n')
+				continue
+			}
+			statement.WriteRune(char)
+		case '#':
+			s.reader.ReadString('\n')
+			statement.WriteRune('\n')
+		case '/':
+			next, _ := s.reader.Peek(1)
+			if len(next) == 1 && next[0] == '*' {
+				if err := s.skipBlockComment(); err != nil {
+					return "", err
+				}
+				continue
+			}
+			statement.WriteRune(char)
+		default:
+			statement.WriteRune(char)
+		}
+	}
+}
+
+func (s *sqlStatementReader) skipBlockComment() error {
+	s.reader.ReadRune()
+
+	previous := rune(0)
+	for {
+		char, _, err := s.reader.ReadRune()
+		if err != nil {
+			return fmt.Errorf("unterminated comment: %w", err)
+		}
+
+		if previous == '*' && char == '/' {
+			return nil
+		}
+
+		previous = char
+	}
+}
+
+// parseInsert parses an INSERT statement into its table, column list and
+// rows of values. NULL values are returned as empty strings.
+func parseInsert(statement string) (string, []string, [][]string, error) {
+	match := insertPattern.FindStringSubmatchIndex(statement)
+
+	if match == nil {
+		return "", nil, nil, errors.New("not an insert statement")
+	}
+
+	table := statement[match[2]:match[3]]
+
+	columns := []string{}
+	if match[4] >= 0 {
+		for _, column := range strings.Split(statement[match[4]:match[5]], ",") {
+			columns = append(columns, strings.Trim(strings.TrimSpace(column), "`\""))
+		}
+	}
+
+	rows, err := parseValues(statement[match[1]:])
+
+	return table, columns, rows, err
+}
+
+func parseValues(values string) ([][]string, error) {
+	rows := [][]string{}
+	position := 0
+
+	skipSpace := func() {
+		for position < len(values) && strings.ContainsRune(" \t\r\n,", rune(values[position])) {
+			position++
+		}
+	}
+
+	for {
+		skipSpace()
+
+		if position >= len(values) {
+			return rows, nil
+		}
+
+		if values[position] != '(' {
+			return nil, fmt.Errorf("expected '(' at offset %d", position)
+		}
+
+		position++
+		row := []string{}
+
+		for {
+			for position < len(values) && strings.ContainsRune(" \t\r\n", rune(values[position])) {
+				position++
+			}
+
+			if position >= len(values) {
+				return nil, errors.New("unterminated row")
+			}
+
+			var value string
+
+			if values[position] == '\'' || values[position] == '"' {
+				quote := values[position]
+				position++
+
+				var builder strings.Builder
+				for {
+					if position >= len(values) {
+						return nil, errors.New("unterminated string literal")
+					}
+
+					char := values[position]
+
+					if char == '\\' && position+1 < len(values) {
+						builder.WriteByte(unescape(values[position+1]))
+						position += 2
+						continue
+					}
+
+					if char == quote {
+						if position+1 < len(values) && values[position+1] == quote {
+							builder.WriteByte(quote)
+							position += 2
+							continue
+						}
+
+						position++
+						break
+					}
+
+					builder.WriteByte(char)
+					position++
+				}
+
+				value = builder.String()
+			} else {
+				start := position
+				for position < len(values) && values[position] != ',' && values[position] != ')' {
+					position++
+				}
+
+				value = strings.TrimSpace(values[start:position])
+				if strings.EqualFold(value, "NULL") {
+					value = ""
+				}
+			}
+
+			row = append(row, value)
+
+			for position < len(values) && strings.ContainsRune(" \t\r\n", rune(values[position])) {
+				position++
+			}
+
+			if position >= len(values) {
+				return nil, errors.New("unterminated row")
+			}
+
+			if values[position] == ',' {
+				position++
+				continue
+			}
+
+			if values[position] == ')' {
+				position++
+				break
+			}
+
+			return nil, fmt.Errorf("unexpected character %q at offset %d", values[position], position)
+		}
+
+		rows = append(rows, row)
+	}
+}
+
+func unescape(char byte) byte {
+	switch char {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case '0':
+		return 0
+	default:
+		return char
+	}
+}
@@ -0,0 +1,46 @@
+// Package metrics holds the Prometheus collectors of the database server. They
+// are registered with the default registry and served at /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// HTTPRequests counts handled requests by route template, method and
+	// status code.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests handled.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration observes how long requests take, with the same
+	// labels as HTTPRequests.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle HTTP requests.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// MongoOperationDuration observes Mongo calls by operation.
+	MongoOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mongo_operation_duration_seconds",
+		Help:    "Time taken by Mongo operations.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+
+	// KeyGenerationRetries counts generated short url paths that were
+	// already taken.
+	KeyGenerationRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "key_generation_retries_total",
+		Help: "Number of generated short url paths that were already taken.",
+	})
+
+	// LinksPurged counts deleted links removed for good after the retention
+	// window.
+	LinksPurged = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "links_purged_total",
+		Help: "Number of deleted links purged after the retention window.",
+	})
+)
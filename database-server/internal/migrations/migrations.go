@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// All are the migrations of the links collection. Append new ones with the
+// next version and never change or remove one that was released.
+var All = []Migration{
+	{
+		Version:     1,
+		Description: "Create the link and click indexes",
+		Steps: []Step{
+			CreateIndexes(Links,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "expiresat", Value: 1}},
+					Options: options.Index().SetExpireAfterSeconds(0),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "shorturlpath", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+				mongo.IndexModel{
+					Keys: bson.D{{Key: "tags", Value: 1}},
+				},
+				mongo.IndexModel{
+					Keys: bson.D{{Key: "createdat", Value: -1}, {Key: "shorturlpath", Value: -1}},
+				},
+				mongo.IndexModel{
+					Keys: bson.D{{Key: "owner", Value: 1}},
+				},
+				mongo.IndexModel{
+					Keys: bson.D{{Key: "title", Value: "text"}, {Key: "note", Value: "text"}, {Key: "originalurl", Value: "text"}},
+				},
+			),
+			CreateIndexes(Clicks,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "shorturlpath", Value: 1}, {Key: "day", Value: 1}},
+					Options: options.Index().SetUnique(true),
+				},
+			),
+		},
+	},
+	{
+		Version:     2,
+		Description: "Backfill the fields links created before them lack",
+		Steps: []Step{
+			Backfill(Links, "title", ""),
+			Backfill(Links, "note", ""),
+			Backfill(Links, "tags", bson.A{}),
+			Backfill(Links, "owner", ""),
+			BackfillFrom(Links, "createdat", bson.D{{Key: "$toDate", Value: "$_id"}}),
+		},
+	},
+	{
+		Version:     3,
+		Description: "Index the deletion time of deleted links for the purger",
+		Steps: []Step{
+			CreateIndexes(Links,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "deletedat", Value: 1}},
+					Options: options.Index().SetSparse(true),
+				},
+			),
+		},
+	},
+}
@@ -0,0 +1,127 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// lockId is the id of the lock document, kept next to the records of the
+	// applied migrations, whose ids are their versions.
+	lockId = "lock"
+
+	// staleLockAfter is how long a lock is held at most without being
+	// refreshed, after which the runner that took it is assumed to have died.
+	staleLockAfter = 15 * time.Minute
+)
+
+// mongoStore tells its lock from the lock of other runners by owner, a token
+// of its own kept in the lock document.
+type mongoStore struct {
+	collection *mongo.Collection
+	owner      string
+}
+
+// NewMongoStore records the applied migrations in collection.
+func NewMongoStore(collection *mongo.Collection) Store {
+	return &mongoStore{collection: collection, owner: uuid.New().String()}
+}
+
+// NewCollections returns the links collection and its clicks collection,
+// named after it unless analyticsCollectionName is set, along with the
+// collection of its migrations.
+func NewCollections(database *mongo.Database, collectionName string, analyticsCollectionName string) (Collections, *mongo.Collection) {
+	if analyticsCollectionName == "" {
+		analyticsCollectionName = collectionName + "-clicks"
+	}
+
+	collections := Collections{
+		Links:  database.Collection(collectionName),
+		Clicks: database.Collection(analyticsCollectionName),
+	}
+
+	return collections, database.Collection(collectionName + "-migrations")
+}
+
+// NewMongoRunner returns a runner of every migration of the links collection
+// collectionName.
+func NewMongoRunner(database *mongo.Database, collectionName string, analyticsCollectionName string, logger *zap.SugaredLogger) (*Runner, Collections, error) {
+	collections, migrations := NewCollections(database, collectionName, analyticsCollectionName)
+	runner, err := NewRunner(NewMongoStore(migrations), collections, All, logger)
+
+	return runner, collections, err
+}
+
+func (store *mongoStore) Applied(ctx context.Context) ([]Applied, error) {
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$type", Value: "number"}}}}
+	cursor, err := store.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []Applied{}
+
+	if err := cursor.All(ctx, &applied); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+func (store *mongoStore) Record(ctx context.Context, applied Applied) error {
+	_, err := store.collection.InsertOne(ctx, applied)
+	return err
+}
+
+// Lock takes the lock document if it is missing or stale. A fresh lock
+// document is not matched by the filter, so the upsert collides with it on
+// its id.
+func (store *mongoStore) Lock(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	filter := bson.D{
+		{Key: "_id", Value: lockId},
+		{Key: "lockedat", Value: bson.D{{Key: "$lt", Value: now.Add(-staleLockAfter)}}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "lockedat", Value: now}, {Key: "owner", Value: store.owner}}}}
+
+	_, err := store.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrLocked
+	}
+
+	return err
+}
+
+// Refresh moves the lock time of the lock document forward, as long as it is
+// still owned by this store.
+func (store *mongoStore) Refresh(ctx context.Context) error {
+	filter := bson.D{{Key: "_id", Value: lockId}, {Key: "owner", Value: store.owner}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "lockedat", Value: time.Now().UTC()}}}}
+
+	result, err := store.collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrLockLost
+	}
+
+	return nil
+}
+
+// Unlock removes the lock document unless another runner took it over.
+func (store *mongoStore) Unlock(ctx context.Context) error {
+	_, err := store.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: lockId}, {Key: "owner", Value: store.owner}})
+	return err
+}
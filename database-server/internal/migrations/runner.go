@@ -0,0 +1,189 @@
+// Package migrations changes the Mongo collections of the database service
+// between releases: it creates and drops their indexes and backfills fields
+// that documents written by older releases lack. Every migration is applied
+// once, in the order of its version, and recorded when done.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// lockRetryInterval is how often a runner tries again to take the lock held
+// by another runner, e.g. of another instance starting at the same time.
+const lockRetryInterval = time.Second
+
+var (
+	ErrLocked   = errors.New("migrations are being applied by another process")
+	ErrLockLost = errors.New("migration lock was taken over by another process")
+)
+
+// Collections are the collections the migrations change.
+type Collections struct {
+	Links  *mongo.Collection
+	Clicks *mongo.Collection
+}
+
+// Step is one change made by a migration. Steps must be safe to apply again,
+// as a migration that fails part way is retried from its first step.
+type Step struct {
+	Description string
+	Apply       func(ctx context.Context, collections Collections) error
+}
+
+type Migration struct {
+	Version     int
+	Description string
+	Steps       []Step
+}
+
+// Applied is the record of an applied migration.
+type Applied struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedat"`
+}
+
+// Store records the applied migrations. Lock keeps other runners from
+// applying migrations until Unlock and returns ErrLocked while another runner
+// holds it. A lock that is not refreshed may be taken over once it is stale;
+// Refresh then returns ErrLockLost, and Unlock leaves the new lock alone.
+type Store interface {
+	Applied(ctx context.Context) ([]Applied, error)
+	Record(ctx context.Context, applied Applied) error
+	Lock(ctx context.Context) error
+	Refresh(ctx context.Context) error
+	Unlock(ctx context.Context) error
+}
+
+type Runner struct {
+	store       Store
+	collections Collections
+	migrations  []Migration
+	logger      *zap.SugaredLogger
+}
+
+// NewRunner returns a runner of migrations, which must be sorted by version.
+func NewRunner(store Store, collections Collections, migrations []Migration, logger *zap.SugaredLogger) (*Runner, error) {
+	for i, migration := range migrations {
+		if migration.Version <= 0 {
+			return nil, fmt.Errorf("migration %q has version %d, versions start at 1", migration.Description, migration.Version)
+		}
+
+		if i > 0 && migration.Version <= migrations[i-1].Version {
+			return nil, fmt.Errorf("migration %d comes after migration %d, migrations must be sorted by version", migration.Version, migrations[i-1].Version)
+		}
+	}
+
+	return &Runner{
+		store:       store,
+		collections: collections,
+		migrations:  migrations,
+		logger:      logger,
+	}, nil
+}
+
+// Plan returns the migrations Run would apply, without applying them.
+func (runner *Runner) Plan(ctx context.Context) ([]Migration, error) {
+	applied, err := runner.store.Applied(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[int]bool, len(applied))
+	for _, record := range applied {
+		versions[record.Version] = true
+	}
+
+	pending := []Migration{}
+	known := 0
+
+	for _, migration := range runner.migrations {
+		if versions[migration.Version] {
+			known++
+		} else {
+			pending = append(pending, migration)
+		}
+	}
+
+	if known < len(applied) {
+		runner.logger.Warnw("Database has migrations applied that this release does not know of", zap.Int("applied", len(applied)), zap.Int("known", known))
+	}
+
+	return pending, nil
+}
+
+// Run applies the pending migrations in order and returns the ones it
+// applied. It waits for the lock while another runner holds it, refreshes it
+// before every step and stops at the first migration that fails, or when the
+// lock was taken over, leaving the migration pending.
+func (runner *Runner) Run(ctx context.Context) ([]Migration, error) {
+	if err := runner.lock(ctx); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := runner.store.Unlock(context.Background()); err != nil {
+			runner.logger.Errorw("Could not release migration lock", zap.Error(err))
+		}
+	}()
+
+	pending, err := runner.Plan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []Migration{}
+
+	for _, migration := range pending {
+		runner.logger.Infow("Applying migration", zap.Int("version", migration.Version), zap.String("description", migration.Description))
+
+		for _, step := range migration.Steps {
+			if err := runner.store.Refresh(ctx); err != nil {
+				runner.logger.Errorw("Could not refresh migration lock", zap.Int("version", migration.Version), zap.String("step", step.Description), zap.Error(err))
+				return applied, fmt.Errorf("migration %d: %s: %w", migration.Version, step.Description, err)
+			}
+
+			if err := step.Apply(ctx, runner.collections); err != nil {
+				runner.logger.Errorw("Migration failed", zap.Int("version", migration.Version), zap.String("step", step.Description), zap.Error(err))
+				return applied, fmt.Errorf("migration %d: %s: %w", migration.Version, step.Description, err)
+			}
+		}
+
+		record := Applied{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now().UTC()}
+
+		if err := runner.store.Record(ctx, record); err != nil {
+			runner.logger.Errorw("Could not record migration", zap.Int("version", migration.Version), zap.Error(err))
+			return applied, err
+		}
+
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+func (runner *Runner) lock(ctx context.Context) error {
+	for {
+		err := runner.store.Lock(ctx)
+
+		if !errors.Is(err, ErrLocked) {
+			return err
+		}
+
+		runner.logger.Infow("Waiting for another process to finish its migrations")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"url-shortner-database/internal/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+type memoryStore struct {
+	applied   []migrations.Applied
+	locked    bool
+	refreshes int
+	// lostAfter is the number of refreshes after which the lock is taken
+	// over by another runner, or 0 to keep it.
+	lostAfter int
+}
+
+func (store *memoryStore) Applied(ctx context.Context) ([]migrations.Applied, error) {
+	return store.applied, nil
+}
+
+func (store *memoryStore) Record(ctx context.Context, applied migrations.Applied) error {
+	store.applied = append(store.applied, applied)
+	return nil
+}
+
+func (store *memoryStore) Lock(ctx context.Context) error {
+	if store.locked {
+		return migrations.ErrLocked
+	}
+
+	store.locked = true
+	return nil
+}
+
+func (store *memoryStore) Refresh(ctx context.Context) error {
+	if store.refreshes++; store.lostAfter > 0 && store.refreshes > store.lostAfter {
+		return migrations.ErrLockLost
+	}
+
+	return nil
+}
+
+func (store *memoryStore) Unlock(ctx context.Context) error {
+	store.locked = false
+	return nil
+}
+
+// recording returns a migration whose single step appends its version to
+// applied, or fails with err.
+func recording(version int, applied *[]int, err error) migrations.Migration {
+	return migrations.Migration{
+		Version:     version,
+		Description: "test",
+		Steps: []migrations.Step{{
+			Description: "record",
+			Apply: func(ctx context.Context, collections migrations.Collections) error {
+				if err != nil {
+					return err
+				}
+
+				*applied = append(*applied, version)
+				return nil
+			},
+		}},
+	}
+}
+
+func TestNewRunner(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Versions      []int
+		ExpectedError bool
+	}{
+		{Name: "Sorted", Versions: []int{1, 2, 5}},
+		{Name: "Empty", Versions: []int{}},
+		{Name: "Unsorted", Versions: []int{2, 1}, ExpectedError: true},
+		{Name: "Duplicate", Versions: []int{1, 1}, ExpectedError: true},
+		{Name: "Zero", Versions: []int{0, 1}, ExpectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			list := []migrations.Migration{}
+			for _, version := range test.Versions {
+				list = append(list, migrations.Migration{Version: version})
+			}
+
+			_, err := migrations.NewRunner(&memoryStore{}, migrations.Collections{}, list, zap.NewNop().Sugar())
+			assert.Equal(t, test.ExpectedError, err != nil)
+		})
+	}
+}
+
+func TestAllMigrationsAreSorted(t *testing.T) {
+	_, err := migrations.NewRunner(&memoryStore{}, migrations.Collections{}, migrations.All, zap.NewNop().Sugar())
+	assert.Nil(t, err)
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+	applied := []int{}
+	store := &memoryStore{applied: []migrations.Applied{{Version: 1}}}
+
+	runner, err := migrations.NewRunner(store, migrations.Collections{}, []migrations.Migration{
+		recording(1, &applied, nil),
+		recording(2, &applied, nil),
+		recording(3, &applied, nil),
+	}, zap.NewNop().Sugar())
+	assert.Nil(t, err)
+
+	pending, err := runner.Plan(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, pending, 2)
+	assert.Empty(t, applied, "planning applies nothing")
+
+	run, err := runner.Run(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, run, 2)
+	assert.Equal(t, []int{2, 3}, applied)
+	assert.Len(t, store.applied, 3)
+	assert.False(t, store.locked, "the lock is released")
+	assert.Equal(t, 2, store.refreshes, "the lock is refreshed before every step")
+
+	run, err = runner.Run(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, run, "applied migrations are not applied again")
+	assert.Equal(t, []int{2, 3}, applied)
+}
+
+func TestRunnerStopsAtFailure(t *testing.T) {
+	ctx := context.Background()
+	applied := []int{}
+	store := &memoryStore{}
+	failure := errors.New("index build failed")
+
+	runner, err := migrations.NewRunner(store, migrations.Collections{}, []migrations.Migration{
+		recording(1, &applied, nil),
+		recording(2, &applied, failure),
+		recording(3, &applied, nil),
+	}, zap.NewNop().Sugar())
+	assert.Nil(t, err)
+
+	run, err := runner.Run(ctx)
+	assert.ErrorIs(t, err, failure)
+	assert.Len(t, run, 1)
+	assert.Equal(t, []int{1}, applied)
+	assert.Len(t, store.applied, 1, "the failed migration stays pending")
+	assert.False(t, store.locked, "the lock is released")
+
+	pending, err := runner.Plan(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, pending, 2)
+}
+
+func TestRunnerStopsWhenLockLost(t *testing.T) {
+	applied := []int{}
+	store := &memoryStore{lostAfter: 1}
+
+	runner, err := migrations.NewRunner(store, migrations.Collections{}, []migrations.Migration{
+		recording(1, &applied, nil),
+		recording(2, &applied, nil),
+	}, zap.NewNop().Sugar())
+	assert.Nil(t, err)
+
+	run, err := runner.Run(context.Background())
+	assert.ErrorIs(t, err, migrations.ErrLockLost)
+	assert.Len(t, run, 1)
+	assert.Equal(t, []int{1}, applied, "no step runs once another runner took the lock")
+}
+
+func TestRunnerWaitsForLock(t *testing.T) {
+	applied := []int{}
+	store := &memoryStore{locked: true}
+
+	runner, err := migrations.NewRunner(store, migrations.Collections{}, []migrations.Migration{recording(1, &applied, nil)}, zap.NewNop().Sugar())
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = runner.Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, applied)
+	assert.True(t, store.locked, "the lock of the other process is kept")
+}
+
+func TestIndexName(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Keys     bson.D
+		Expected string
+	}{
+		{Name: "Single", Keys: bson.D{{Key: "owner", Value: 1}}, Expected: "owner_1"},
+		{Name: "Compound", Keys: bson.D{{Key: "createdat", Value: -1}, {Key: "shorturlpath", Value: -1}}, Expected: "createdat_-1_shorturlpath_-1"},
+		{Name: "Text", Keys: bson.D{{Key: "title", Value: "text"}, {Key: "note", Value: "text"}}, Expected: "title_text_note_text"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, migrations.IndexName(test.Keys))
+		})
+	}
+}
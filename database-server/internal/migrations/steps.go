@@ -0,0 +1,107 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Error codes of the Mongo server for dropping what is not there.
+const (
+	namespaceNotFound = 26
+	indexNotFound     = 27
+)
+
+// Target is the collection a step changes.
+type Target string
+
+const (
+	Links  Target = "links"
+	Clicks Target = "clicks"
+)
+
+func (collections Collections) get(target Target) *mongo.Collection {
+	if target == Clicks {
+		return collections.Clicks
+	}
+
+	return collections.Links
+}
+
+// CreateIndexes creates indexes named the way Mongo names them by default,
+// so creating an index that exists with the same options does nothing.
+func CreateIndexes(target Target, indexes ...mongo.IndexModel) Step {
+	names := make([]string, len(indexes))
+	for i, index := range indexes {
+		names[i] = IndexName(index.Keys.(bson.D))
+	}
+
+	return Step{
+		Description: fmt.Sprintf("create indexes %s on %s", strings.Join(names, ", "), target),
+		Apply: func(ctx context.Context, collections Collections) error {
+			_, err := collections.get(target).Indexes().CreateMany(ctx, indexes)
+			return err
+		},
+	}
+}
+
+// DropIndex drops the index with the given name if it exists.
+func DropIndex(target Target, index string) Step {
+	return Step{
+		Description: fmt.Sprintf("drop index %s on %s", index, target),
+		Apply: func(ctx context.Context, collections Collections) error {
+			_, err := collections.get(target).Indexes().DropOne(ctx, index)
+
+			var commandErr mongo.CommandError
+			if errors.As(err, &commandErr) && (commandErr.Code == indexNotFound || commandErr.Code == namespaceNotFound) {
+				return nil
+			}
+
+			return err
+		},
+	}
+}
+
+// Backfill sets field to value on the documents without it.
+func Backfill(target Target, field string, value interface{}) Step {
+	return Step{
+		Description: fmt.Sprintf("set %s to %v on %s without it", field, value, target),
+		Apply: func(ctx context.Context, collections Collections) error {
+			filter := bson.D{{Key: field, Value: bson.D{{Key: "$exists", Value: false}}}}
+			update := bson.D{{Key: "$set", Value: bson.D{{Key: field, Value: value}}}}
+
+			_, err := collections.get(target).UpdateMany(ctx, filter, update)
+			return err
+		},
+	}
+}
+
+// BackfillFrom sets field on the documents without it to the result of an
+// aggregation expression evaluated on each document, e.g. another field.
+func BackfillFrom(target Target, field string, expression bson.D) Step {
+	return Step{
+		Description: fmt.Sprintf("set %s to %v on %s without it", field, expression, target),
+		Apply: func(ctx context.Context, collections Collections) error {
+			filter := bson.D{{Key: field, Value: bson.D{{Key: "$exists", Value: false}}}}
+			update := mongo.Pipeline{{{Key: "$set", Value: bson.D{{Key: field, Value: expression}}}}}
+
+			_, err := collections.get(target).UpdateMany(ctx, filter, update)
+			return err
+		},
+	}
+}
+
+// IndexName returns the name Mongo gives an index on keys by default.
+func IndexName(keys bson.D) string {
+	parts := make([]string, 0, 2*len(keys))
+
+	for _, key := range keys {
+		parts = append(parts, key.Key, fmt.Sprint(key.Value))
+	}
+
+	return strings.Join(parts, "_")
+}
@@ -1,26 +1,81 @@
 package models
 
-import "time"
+import (
+	"time"
 
+	api "url-shortner-api"
+)
+
+// URL is a stored link. The bson keys are the ones the driver derived from
+// the field names before they were spelled out, so existing documents still
+// decode.
 type URL struct {
-	OriginalUrl  string
-	ShortUrlPath string
-	ExpiresAt    time.Time
+	OriginalUrl  string    `bson:"originalurl"`
+	ShortUrlPath string    `bson:"shorturlpath"`
+	ExpiresAt    time.Time `bson:"expiresat"`
+	CreatedAt    time.Time `bson:"createdat"`
+	Title        string    `bson:"title"`
+	Note         string    `bson:"note"`
+	Tags         []string  `bson:"tags"`
+	Owner        string    `bson:"owner"`
+	DeletedAt    time.Time `bson:"deletedat,omitempty"`
 }
 
-type ShortenRequestModel struct {
-	Url       string    `json:"url"`
-	ExpiresAt time.Time `json:"expires_at"`
+// Deleted reports whether the link was deleted and waits to be restored or
+// purged.
+func (url URL) Deleted() bool {
+	return !url.DeletedAt.IsZero()
 }
 
-type ShortenResponseModel struct {
-	ShortUrlPath string `json:"shorturlpath"`
+type ClickBucket struct {
+	ShortUrlPath string    `bson:"shorturlpath"`
+	Day          time.Time `bson:"day"`
+	Count        int64     `bson:"count"`
 }
 
-type RedirectRequestModel struct {
-	ShortUrlPath string `json:"shorturlpath"`
-}
+// The request and response models are generated from the OpenAPI
+// specification in the api module and shared with the other services.
+type (
+	ShortenRequestModel      = api.ShortenRequestModel
+	ShortenResponseModel     = api.ShortenResponseModel
+	RedirectRequestModel     = api.RedirectRequestModel
+	RedirectResponseModel    = api.RedirectResponseModel
+	ListRequestModel         = api.ListRequestModel
+	LinkModel                = api.LinkModel
+	ListResponseModel        = api.ListResponseModel
+	TopLinksRequestModel     = api.TopLinksRequestModel
+	ExportRequestModel       = api.ExportRequestModel
+	ImportRequestModel       = api.ImportRequestModel
+	ImportResultModel        = api.ImportResultModel
+	ImportResponseModel      = api.ImportResponseModel
+	UpdateRequestModel       = api.UpdateRequestModel
+	RecordClicksRequestModel = api.RecordClicksRequestModel
+	DailyClicksModel         = api.DailyClicksModel
+	StatsResponseModel       = api.StatsResponseModel
+)
+
+const (
+	ImportStatusCreated  = api.ImportStatusCreated
+	ImportStatusConflict = api.ImportStatusConflict
+	ImportStatusInvalid  = api.ImportStatusInvalid
+	ImportStatusFailed   = api.ImportStatusFailed
+)
+
+func NewLinkModel(url URL) LinkModel {
+	tags := url.Tags
+
+	if tags == nil {
+		tags = []string{}
+	}
 
-type RedirectResponseModel struct {
-	Url string `json:"redirecturl"`
+	return LinkModel{
+		ShortUrlPath: url.ShortUrlPath,
+		Url:          url.OriginalUrl,
+		Title:        url.Title,
+		Note:         url.Note,
+		Tags:         tags,
+		Owner:        url.Owner,
+		CreatedAt:    url.CreatedAt,
+		ExpiresAt:    url.ExpiresAt,
+	}
 }
@@ -2,7 +2,11 @@ package utils
 
 import (
 	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
 	"math/rand"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +17,18 @@ const (
 	characterSet string = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 )
 
+var shortUrlPathPattern = regexp.MustCompile(`^[0-9A-Za-z_-]{1,64}$`)
+
+// reservedShortUrlPaths are served by the main service before its redirect
+// route, so links with these paths could never be followed.
+var reservedShortUrlPaths = map[string]bool{
+	"dashboard": true,
+	"shorten":   true,
+	"metrics":   true,
+	"healthz":   true,
+	"readyz":    true,
+}
+
 func toBase62(b int) string {
 	encoded := ""
 	for b > 0 {
@@ -54,3 +70,54 @@ func GetExpirationTime(expiryTime time.Time) time.Time {
 func GenerateRequestId() string {
 	return uuid.New().String()
 }
+
+type Cursor struct {
+	Value time.Time `json:"v"`
+	Key   string    `json:"k"`
+}
+
+func EncodeCursor(cursor Cursor) (string, error) {
+	jsonCursor, err := json.Marshal(cursor)
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(jsonCursor), nil
+}
+
+func DecodeCursor(encoded string) (Cursor, error) {
+	cursor := Cursor{}
+
+	jsonCursor, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return cursor, err
+	}
+
+	err = json.Unmarshal(jsonCursor, &cursor)
+
+	return cursor, err
+}
+
+func NormalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+func IsValidShortUrlPath(shortUrlPath string) bool {
+	return shortUrlPathPattern.MatchString(shortUrlPath) && !reservedShortUrlPaths[shortUrlPath]
+}
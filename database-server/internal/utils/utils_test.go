@@ -42,3 +42,69 @@ func TestGetExpirationTime(t *testing.T) {
 		assert.NotEqual(t, tests["Expiry Time is 0"].expiryTime, utils.GetExpirationTime(tests["Expiry Time is 0"].expiryTime), "GetExpirationTime failed")
 	})
 }
+
+func TestCursor(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		cursor := utils.Cursor{Value: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Key: "abc1234"}
+
+		encoded, err := utils.EncodeCursor(cursor)
+		assert.Nil(t, err, "EncodeCursor failed")
+
+		decoded, err := utils.DecodeCursor(encoded)
+		assert.Nil(t, err, "DecodeCursor failed")
+		assert.True(t, cursor.Value.Equal(decoded.Value), "Cursor value mismatch")
+		assert.Equal(t, cursor.Key, decoded.Key, "Cursor key mismatch")
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		_, err := utils.DecodeCursor("not a cursor!")
+		assert.NotNil(t, err, "DecodeCursor should fail")
+	})
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tests := map[string]struct {
+		tags     []string
+		expected []string
+	}{
+		"Nil Tags": {
+			tags:     nil,
+			expected: []string{},
+		},
+		"Mixed Case And Spaces": {
+			tags:     []string{" Marketing", "marketing ", "Q3", ""},
+			expected: []string{"marketing", "q3"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, utils.NormalizeTags(test.tags), "NormalizeTags failed")
+		})
+	}
+}
+
+func TestIsValidShortUrlPath(t *testing.T) {
+	tests := map[string]struct {
+		shortUrlPath string
+		expected     bool
+	}{
+		"Generated Key": {shortUrlPath: "aZ09xYq", expected: true},
+		"Keyword":       {shortUrlPath: "summer-sale_2024", expected: true},
+		"Empty":         {shortUrlPath: "", expected: false},
+		"With Slash":    {shortUrlPath: "a/b", expected: false},
+		"With Space":    {shortUrlPath: "a b", expected: false},
+		"Dashboard":     {shortUrlPath: "dashboard", expected: false},
+		"Shorten":       {shortUrlPath: "shorten", expected: false},
+		"Metrics":       {shortUrlPath: "metrics", expected: false},
+		"Healthz":       {shortUrlPath: "healthz", expected: false},
+		"Readyz":        {shortUrlPath: "readyz", expected: false},
+		"Reserved Case": {shortUrlPath: "Dashboard", expected: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, utils.IsValidShortUrlPath(test.shortUrlPath))
+		})
+	}
+}
@@ -1,20 +1,47 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 	"url-shortner-database/internal/config"
 	"url-shortner-database/internal/database"
+	"url-shortner-database/internal/grpcserver"
 	"url-shortner-database/internal/handlers"
 	"url-shortner-database/internal/logging"
+	"url-shortner-database/internal/metrics"
 	"url-shortner-database/internal/middlewares"
 
-	kafka "github.com/cursed-ninja/go-kafka-producer"
+	"url-shortner-api/health"
+	"url-shortner-api/httpmiddleware"
+	"url-shortner-api/kafkalog"
+	"url-shortner-api/pb"
+	"url-shortner-api/settings"
+	"url-shortner-api/tlsconfig"
+	"url-shortner-api/tracing"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// shutdownTimeout is how long in-flight requests may take to finish once the
+// service is asked to stop.
+const shutdownTimeout = 20 * time.Second
+
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	initialLogger, err := zap.NewDevelopment()
 
 	if err != nil {
@@ -23,12 +50,13 @@ func main() {
 
 	logger := initialLogger.Sugar()
 
-	config, err := config.NewConfig(logger)
+	cfg, source, err := config.Load(os.Args[1:])
 	if err != nil {
 		logger.Fatalw("Could not load config", zap.Error(err))
 	}
 
-	producer := kafka.NewKafkaProducer([]string{config.Get("KAFKA_SERVICE_BASE_URL")}, "database-server", true)
+	producer := kafkalog.NewWriter(cfg.KafkaBrokers, "database-server")
+	defer producer.Close()
 
 	logger, err = logging.NewLogger(producer)
 	if err != nil {
@@ -36,22 +64,129 @@ func main() {
 	}
 	defer logger.Sync()
 
-	MONGO_URI := config.Get("MONGO_URI")
-	DB_NAME := config.Get("DB_NAME")
-	COLLECTION_NAME := config.Get("COLLECTION_NAME")
+	logger.Infow("Loaded config", zap.Any("config", settings.Redacted(cfg)))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "database-server", cfg.OtlpEndpoint)
+	if err != nil {
+		logger.Fatalw("Could not set up tracing", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
 
-	mongoClient, err := database.NewDbConnection(logger, MONGO_URI, DB_NAME, COLLECTION_NAME)
+	tlsConfig, err := tlsconfig.Load(cfg.TlsFiles(), true, logger)
+	if err != nil {
+		logger.Fatalw("Could not load TLS certificates", zap.Error(err))
+	}
+	defer tlsConfig.Close()
+
+	repository, err := database.New(cfg, logger)
 	if err != nil {
 		logger.Panic("Could not connect to database", zap.Error(err))
 	}
-	defer mongoClient.Disconnect()
+	defer repository.Close()
+
+	if cfg.PurgeInterval > 0 {
+		go database.RunPurger(ctx, repository, cfg.DeletedRetention, cfg.PurgeInterval, logger)
+	}
+
+	handlers := handlers.NewBaseHandler(logger, repository, cfg.DeletedRetention)
+
+	var timeouts atomic.Pointer[httpmiddleware.Timeouts]
 
-	handlers := handlers.NewBaseHandler(logger, mongoClient)
+	initialTimeouts, err := parseTimeouts(cfg)
+	if err != nil {
+		logger.Fatalw("Invalid request timeouts", zap.Error(err))
+	}
+	timeouts.Store(&initialTimeouts)
+
+	source.Watch(logger, func(changed interface{}) {
+		reloadedTimeouts, err := parseTimeouts(changed.(*config.Config))
+		if err != nil {
+			logger.Errorw("Keeping the current request timeouts", zap.Error(err))
+			return
+		}
+		timeouts.Store(&reloadedTimeouts)
+	})
 
 	r := mux.NewRouter()
+	r.Use(httpmiddleware.TracingMiddleware, httpmiddleware.MetricsMiddleware(metrics.HTTPRequests, metrics.HTTPRequestDuration), httpmiddleware.TimeoutMiddleware(&timeouts))
 	r.HandleFunc("/shorten", handlers.HandleShorten).Methods(http.MethodPost)
 	r.HandleFunc("/redirect", handlers.HandleRedirect).Methods(http.MethodPost)
+	r.HandleFunc("/links", handlers.HandleListLinks).Methods(http.MethodPost)
+	r.HandleFunc("/links/top", handlers.HandleTopLinks).Methods(http.MethodPost)
+	r.HandleFunc("/links/export", handlers.HandleExportLinks).Methods(http.MethodPost)
+	r.HandleFunc("/links/import", handlers.HandleImportLinks).Methods(http.MethodPost)
+	r.HandleFunc("/links/{shorturlpath}", handlers.HandleGetLink).Methods(http.MethodGet)
+	r.HandleFunc("/links/{shorturlpath}", handlers.HandleUpdateLink).Methods(http.MethodPatch)
+	r.HandleFunc("/links/{shorturlpath}", handlers.HandleDeleteLink).Methods(http.MethodDelete)
+	r.HandleFunc("/links/{shorturlpath}/stats", handlers.HandleLinkStats).Methods(http.MethodGet)
+	r.HandleFunc("/links/{shorturlpath}/restore", handlers.HandleRestoreLink).Methods(http.MethodPost)
+	r.HandleFunc("/clicks", handlers.HandleRecordClicks).Methods(http.MethodPost)
+
+	checks := health.Checks{cfg.DbBackend: repository.Ping}
+
+	grpcServer := grpc.NewServer(tlsConfig.ServerOption(), grpc.StatsHandler(otelgrpc.NewServerHandler()), grpc.UnaryInterceptor(middlewares.LoggingInterceptor))
+	pb.RegisterDatabaseServiceServer(grpcServer, grpcserver.NewServer(logger, repository))
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewGrpcServer(checks))
+
+	listener, err := net.Listen("tcp", cfg.GrpcListenAddr)
+	if err != nil {
+		logger.Fatalw("Could not listen for gRPC", zap.Error(err))
+	}
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Errorw("gRPC server failed", zap.Error(err))
+			stop()
+		}
+	}()
+
+	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/healthz", health.Live)
+	http.HandleFunc("/readyz", health.Ready(checks))
+	http.Handle("/", tlsConfig.RequireClientCert(middlewares.LoggingMiddleware(r)))
+
+	server := &http.Server{Addr: cfg.ListenAddr}
+
+	go func() {
+		if err := tlsConfig.ListenAndServe(server); !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorw("HTTP server failed", zap.Error(err))
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Infow("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorw("Could not finish in-flight requests", zap.Error(err))
+	}
+
+	stopGrpc(shutdownCtx, grpcServer)
+}
+
+// stopGrpc waits for the in-flight calls to finish, and cancels the ones still
+// running once ctx is done.
+func stopGrpc(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
 
-	http.Handle("/", middlewares.LoggingMiddleware(r))
-	logger.Error(http.ListenAndServe(":8081", nil))
+// parseTimeouts reads the request timeouts of the config. Exports and imports
+// run for as long as there are links to stream, so they are not bounded unless
+// ROUTE_TIMEOUTS says otherwise.
+func parseTimeouts(config *config.Config) (httpmiddleware.Timeouts, error) {
+	return httpmiddleware.ParseTimeouts(config.RequestTimeout, "/links/export=0,/links/import=0,"+config.RouteTimeouts)
 }
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"url-shortner-api/settings"
+	"url-shortner-api/tlsconfig"
+)
+
+// Config is the configuration of the kafka service, read from config/app.env,
+// the environment and command line flags.
+type Config struct {
+	ListenAddr                   string   `config:"LISTEN_ADDR" default:":8083"`
+	TlsCertFile                  string   `config:"TLS_CERT_FILE"`
+	TlsKeyFile                   string   `config:"TLS_KEY_FILE"`
+	MongoUri                     string   `config:"MONGO_URI" required:"true" secret:"true"`
+	DbName                       string   `config:"DB_NAME" default:"url-shortener"`
+	MainServerCollectionName     string   `config:"MAIN_SERVER_COLLECTION_NAME" default:"main-server-logs"`
+	CacheServerCollectionName    string   `config:"CACHE_SERVER_COLLECTION_NAME" default:"cache-server-logs"`
+	DatabaseServerCollectionName string   `config:"DATABASE_SERVER_COLLECTION_NAME" default:"database-server-logs"`
+	KafkaBrokers                 []string `config:"KAFKA_SERVICE_BASE_URL" default:"localhost:29092"`
+}
+
+// Load reads and validates the config. args are the command line arguments
+// without the program name.
+func Load(args []string) (*Config, error) {
+	config := &Config{}
+
+	if _, err := settings.Load(config, args); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (config *Config) Validate() error {
+	return config.TlsFiles().Validate()
+}
+
+// TlsFiles are the certificate the metrics and health endpoints are served
+// with.
+func (config *Config) TlsFiles() tlsconfig.Files {
+	return tlsconfig.Files{CertFile: config.TlsCertFile, KeyFile: config.TlsKeyFile}
+}
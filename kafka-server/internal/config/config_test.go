@@ -5,36 +5,38 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"go.uber.org/zap"
 )
 
-func TestGet(t *testing.T) {
-	mockLogger := zap.NewNop().Sugar()
-	config, err := config.NewConfig(mockLogger)
-
-	if err != nil {
-		t.Fatalf("Error creating config: %v", err)
-	}
-
-	var tests = map[string]struct {
-		name     string
-		key      string
-		expected string
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		args          []string
+		ExpectedError string
 	}{
-		"NON_EXISTENT_KEY": {
-			key:      "ABC",
-			expected: "",
+		"Flags": {
+			args: []string{"--mongo-uri", "mongodb://localhost:27017", "--kafka-service-base-url", "kafka-1:9092,kafka-2:9092"},
 		},
-		"EXISTENT_KEY": {
-			key:      "KAFKA_SERVICE_BASE_URL",
-			expected: "localhost:29092",
+		"Missing Mongo Uri": {
+			args:          []string{"--mongo-uri", ""},
+			ExpectedError: "MONGO_URI is required",
+		},
+		"Certificate Without Key": {
+			args:          []string{"--mongo-uri", "mongodb://localhost:27017", "--tls-cert-file", "tls.crt"},
+			ExpectedError: "TLS_CERT_FILE and TLS_KEY_FILE must be set together",
 		},
 	}
 
-	for tc, test := range tests {
-		t.Run(tc, func(t *testing.T) {
-			actual := config.Get(test.key)
-			assert.Equal(t, test.expected, actual, "Expected and actual values do not match")
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			loaded, err := config.Load(test.args)
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, "mongodb://localhost:27017", loaded.MongoUri)
+			assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, loaded.KafkaBrokers)
 		})
 	}
 }
@@ -1,13 +1,17 @@
 package consumer
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"kafka-server/internal/config"
 	"kafka-server/internal/constants"
 	"kafka-server/internal/database"
+	"kafka-server/internal/metrics"
 	"log"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -30,8 +34,9 @@ type Consumer struct {
 	mongoMainServer     database.DBInterface
 	mongoCacheServer    database.DBInterface
 	mongoDatabaseServer database.DBInterface
-	config              config.ConfigInterface
+	config              *config.Config
 	logger              *zap.SugaredLogger
+	member              atomic.Bool
 }
 
 type Job struct {
@@ -60,12 +65,15 @@ func NewWorkerPool(numWorkers int) *WorkerPool {
 }
 
 func (pool *WorkerPool) Submit(job Job) {
+	metrics.WorkerQueueDepth.Inc()
 	pool.jobs <- job
 }
 
 func (pool *WorkerPool) worker() {
 	defer pool.wg.Done()
 	for job := range pool.jobs {
+		metrics.WorkerQueueDepth.Dec()
+
 		var document interface{}
 		var err error
 
@@ -92,6 +100,7 @@ func (pool *WorkerPool) worker() {
 		}
 
 		if err != nil {
+			metrics.InsertFailures.WithLabelValues(job.message.Topic).Inc()
 			log.Printf("Error inserting document: %v", err)
 			pool.results <- err
 		} else {
@@ -107,7 +116,7 @@ func (pool *WorkerPool) Shutdown() {
 	close(pool.results)
 }
 
-func NewConsumer(config config.ConfigInterface, mongoMainServer database.DBInterface, mongoCacheServer database.DBInterface, mongoDatabaseServer database.DBInterface, logger *zap.SugaredLogger) *Consumer {
+func NewConsumer(config *config.Config, mongoMainServer database.DBInterface, mongoCacheServer database.DBInterface, mongoDatabaseServer database.DBInterface, logger *zap.SugaredLogger) *Consumer {
 	return &Consumer{
 		mongoMainServer:     mongoMainServer,
 		mongoCacheServer:    mongoCacheServer,
@@ -121,33 +130,45 @@ func (consumer *Consumer) Setup(sarama.ConsumerGroupSession) error {
 	mongoCacheServer = consumer.mongoCacheServer
 	mongoDatabaseServer = consumer.mongoDatabaseServer
 	mongoMainServer = consumer.mongoMainServer
+	consumer.member.Store(true)
 	return nil
 }
 
+// Cleanup runs at the end of every session, after the claimed messages are
+// processed. The Mongo connections stay open for the next session after a
+// rebalance and are closed by main on shutdown.
 func (consumer *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
-	err := mongoCacheServer.Disconnect()
-	if err != nil {
-		return err
-	}
-
-	err = mongoDatabaseServer.Disconnect()
-	if err != nil {
-		return err
-	}
+	consumer.member.Store(false)
+	return nil
+}
 
-	err = mongoMainServer.Disconnect()
-	if err != nil {
-		return err
+// CheckMembership reports whether the consumer has joined the consumer group
+// and has been given its partitions.
+func (consumer *Consumer) CheckMembership(ctx context.Context) error {
+	if !consumer.member.Load() {
+		return errors.New("not a member of the consumer group")
 	}
 
 	return nil
 }
 
+// CheckDatabase pings the Mongo connection of every topic.
+func (consumer *Consumer) CheckDatabase(ctx context.Context) error {
+	return errors.Join(
+		consumer.mongoMainServer.Ping(ctx),
+		consumer.mongoCacheServer.Ping(ctx),
+		consumer.mongoDatabaseServer.Ping(ctx),
+	)
+}
+
 func (consumer *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	workerPool := NewWorkerPool(1000)
 
 	go func() {
 		for message := range claim.Messages() {
+			metrics.MessagesConsumed.WithLabelValues(message.Topic).Inc()
+			metrics.ConsumerLag.WithLabelValues(message.Topic, strconv.Itoa(int(message.Partition))).Set(float64(claim.HighWaterMarkOffset() - message.Offset - 1))
+
 			workerPool.Submit(Job{message: message, session: session})
 		}
 		workerPool.Shutdown()
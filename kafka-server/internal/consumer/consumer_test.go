@@ -0,0 +1,64 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"kafka-server/internal/consumer"
+	mock_database "kafka-server/internal/database/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestCheckMembership(t *testing.T) {
+	mongo := mock_database.NewMockDBInterface(gomock.NewController(t))
+
+	groupConsumer := consumer.NewConsumer(nil, mongo, mongo, mongo, zap.NewNop().Sugar())
+
+	assert.Error(t, groupConsumer.CheckMembership(context.Background()), "not a member before the first session")
+
+	assert.Nil(t, groupConsumer.Setup(nil))
+	assert.Nil(t, groupConsumer.CheckMembership(context.Background()))
+
+	assert.Nil(t, groupConsumer.Cleanup(nil))
+	assert.Error(t, groupConsumer.CheckMembership(context.Background()), "not a member after the session ended")
+}
+
+func TestCheckDatabase(t *testing.T) {
+	tests := map[string]struct {
+		err           error
+		ExpectedError bool
+	}{
+		"Connected": {},
+		"Disconnected": {
+			err:           errors.New("server selection timeout"),
+			ExpectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mainServer := mock_database.NewMockDBInterface(ctrl)
+			cacheServer := mock_database.NewMockDBInterface(ctrl)
+			databaseServer := mock_database.NewMockDBInterface(ctrl)
+
+			mainServer.EXPECT().Ping(gomock.Any()).Return(nil)
+			cacheServer.EXPECT().Ping(gomock.Any()).Return(test.err)
+			databaseServer.EXPECT().Ping(gomock.Any()).Return(nil)
+
+			groupConsumer := consumer.NewConsumer(nil, mainServer, cacheServer, databaseServer, zap.NewNop().Sugar())
+
+			err := groupConsumer.CheckDatabase(context.Background())
+
+			if test.ExpectedError {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
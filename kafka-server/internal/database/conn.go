@@ -6,11 +6,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.uber.org/zap"
 )
 
 type DBInterface interface {
 	InsertOne(document interface{}) error
+	Ping(ctx context.Context) error
 	Disconnect() error
 }
 
@@ -66,6 +68,11 @@ func (connection *dB) InsertOne(document interface{}) error {
 	return err
 }
 
+// Ping checks that the primary of the replica set can be reached.
+func (connection *dB) Ping(ctx context.Context) error {
+	return connection.client.Ping(ctx, readpref.Primary())
+}
+
 func (connection *dB) Disconnect() error {
 	err := connection.client.Disconnect(context.TODO())
 
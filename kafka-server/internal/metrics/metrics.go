@@ -0,0 +1,35 @@
+// Package metrics holds the Prometheus collectors of the kafka server. They
+// are registered with the default registry and served at /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// MessagesConsumed counts the messages read from each topic.
+	MessagesConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_messages_consumed_total",
+		Help: "Number of messages consumed.",
+	}, []string{"topic"})
+
+	// InsertFailures counts log entries that could not be written to Mongo.
+	InsertFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_insert_failures_total",
+		Help: "Number of consumed messages that could not be stored.",
+	}, []string{"topic"})
+
+	// WorkerQueueDepth is the number of messages waiting for a worker.
+	WorkerQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "kafka_worker_queue_depth",
+		Help: "Number of consumed messages waiting for a worker.",
+	})
+
+	// ConsumerLag is how many messages of a partition are not consumed yet,
+	// as of the last message read from it.
+	ConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_consumer_lag",
+		Help: "Number of messages behind the end of the partition.",
+	}, []string{"topic", "partition"})
+)
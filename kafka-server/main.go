@@ -2,19 +2,35 @@ package main
 
 import (
 	"context"
+	"errors"
 	appConfig "kafka-server/internal/config"
 	"kafka-server/internal/constants"
 	"kafka-server/internal/consumer"
 	database "kafka-server/internal/database"
 	"kafka-server/internal/logging"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"url-shortner-api/health"
+	"url-shortner-api/settings"
+	"url-shortner-api/tlsconfig"
 
 	"github.com/IBM/sarama"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
 )
 
+// shutdownTimeout is how long the metrics and health requests may take to
+// finish once the service is asked to stop.
+const shutdownTimeout = 5 * time.Second
+
 func main() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	config := sarama.NewConfig()
 	config.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
@@ -25,68 +41,106 @@ func main() {
 	if err != nil {
 		log.Fatalf("Error creating logger: %v", err)
 	}
+	defer logger.Sync()
 
-	appConfig, err := appConfig.NewConfig(logger)
+	appConfig, err := appConfig.Load(os.Args[1:])
 
 	if err != nil {
 		log.Fatalf("Error creating config: %v", err)
 	}
 
-	database_base_url := appConfig.Get("MONGO_URI")
-	db_name := appConfig.Get("DB_NAME")
-	main_server_collection_name := appConfig.Get("MAIN_SERVER_COLLECTION_NAME")
-	cache_server_collection_name := appConfig.Get("CACHE_SERVER_COLLECTION_NAME")
-	database_server_collection_name := appConfig.Get("DATABASE_SERVER_COLLECTION_NAME")
+	logger.Infow("Loaded config", zap.Any("config", settings.Redacted(appConfig)))
 
 	topics := []string{constants.TOPIC_MAIN_SERVER,
 		constants.TOPIC_CACHE_SERVER,
 		constants.TOPIC_DATABASE_SERVER,
 	}
 
-	consumerGroup, err := sarama.NewConsumerGroup([]string{appConfig.Get("KAFKA_SERVICE_BASE_URL")}, "example-group", config)
+	consumerGroup, err := sarama.NewConsumerGroup(appConfig.KafkaBrokers, "example-group", config)
 	if err != nil {
 		log.Fatalf("Error creating consumer group: %v", err)
 	}
-	defer consumerGroup.Close()
 
 	mongoMainServer, err := database.NewDbConnection(
 		logger,
-		database_base_url,
-		db_name,
-		main_server_collection_name,
+		appConfig.MongoUri,
+		appConfig.DbName,
+		appConfig.MainServerCollectionName,
 	)
 
 	if err != nil {
 		log.Fatalf("Error creating mongo main server connection: %v", err)
 	}
+	defer mongoMainServer.Disconnect()
 
 	mongoCacheServer, err := database.NewDbConnection(
 		logger,
-		database_base_url,
-		db_name,
-		cache_server_collection_name,
+		appConfig.MongoUri,
+		appConfig.DbName,
+		appConfig.CacheServerCollectionName,
 	)
 
 	if err != nil {
 		log.Fatalf("Error creating mongo cache server connection: %v", err)
 	}
+	defer mongoCacheServer.Disconnect()
 
 	mongoDatabaseServer, err := database.NewDbConnection(
 		logger,
-		database_base_url,
-		db_name,
-		database_server_collection_name,
+		appConfig.MongoUri,
+		appConfig.DbName,
+		appConfig.DatabaseServerCollectionName,
 	)
 
 	if err != nil {
 		log.Fatalf("Error creating mongo database server connection: %v", err)
 	}
+	defer mongoDatabaseServer.Disconnect()
+
+	tlsConfig, err := tlsconfig.Load(appConfig.TlsFiles(), false, logger)
+	if err != nil {
+		log.Fatalf("Error loading TLS certificates: %v", err)
+	}
+	defer tlsConfig.Close()
 
 	consumer := consumer.NewConsumer(appConfig, mongoMainServer, mongoCacheServer, mongoDatabaseServer, logger)
 
-	for {
+	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/healthz", health.Live)
+	http.HandleFunc("/readyz", health.Ready(health.Checks{
+		"consumer-group": consumer.CheckMembership,
+		"mongo":          consumer.CheckDatabase,
+	}))
+
+	server := &http.Server{Addr: appConfig.ListenAddr}
+
+	go func() {
+		if err := tlsConfig.ListenAndServe(server); !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorw("HTTP server failed", zap.Error(err))
+			stop()
+		}
+	}()
+
+	// Consume returns when a rebalance ends the session, once the claimed
+	// messages are stored and their offsets committed, and is called again
+	// to rejoin the group until the service is asked to stop.
+	for ctx.Err() == nil {
 		if err := consumerGroup.Consume(ctx, topics, consumer); err != nil {
-			log.Fatalf("Error consuming messages: %v", err)
+			logger.Errorw("Error consuming messages", zap.Error(err))
+			break
 		}
 	}
+
+	logger.Infow("Shutting down")
+
+	if err := consumerGroup.Close(); err != nil {
+		logger.Errorw("Could not leave the consumer group", zap.Error(err))
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorw("Could not finish in-flight requests", zap.Error(err))
+	}
 }
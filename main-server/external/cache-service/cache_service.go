@@ -1,86 +1,140 @@
 package cacheservice
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"main-server/internal/config"
 	"main-server/internal/models"
 	"net/http"
 
+	api "url-shortner-api"
+	"url-shortner-api/resilience"
+
 	"go.uber.org/zap"
 )
 
 type CacheServiceInterface interface {
-	HandleRedirect(body io.Reader, requestId string) (*models.RedirectResponseModel, error)
+	HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error)
+	HandleStore(ctx context.Context, link *models.CacheStoreRequestModel, requestId string) error
+	HandleEvict(ctx context.Context, shortUrlPath string, requestId string) error
+	HandleReady(ctx context.Context) error
 }
 
+// cacheService sends readiness probes with a separate client without the
+// breaker, so failed probes do not cut off the calls that serve requests.
 type cacheService struct {
-	config config.ConfigInterface
+	client *api.ClientWithResponses
+	probe  *api.ClientWithResponses
 	logger *zap.SugaredLogger
 }
 
-func NewCacheService(config config.ConfigInterface, logger *zap.SugaredLogger) *cacheService {
-	return &cacheService{
-		config: config,
-		logger: logger,
-	}
-}
+func NewCacheService(config *config.Config, clientTLS *tls.Config, logger *zap.SugaredLogger) (*cacheService, error) {
+	resilienceConfig := resilience.DefaultConfig("cache-service")
+	resilienceConfig.TLS = clientTLS
 
-func (c *cacheService) HandleRedirect(body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
-	reqUrl := c.config.Get("CACHE_SERVICE_BASE_URL") + "/redirect"
+	client, err := api.NewClientWithResponses(config.CacheServiceBaseUrl, api.WithHTTPClient(resilience.NewClient(resilienceConfig)))
 
-	c.logger.Infow("Sending request to cache service", zap.String("Request Id", requestId), zap.String("url", reqUrl))
+	if err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequest(http.MethodPost, reqUrl, body)
+	probe, err := api.NewClientWithResponses(config.CacheServiceBaseUrl, api.WithHTTPClient(resilience.NewProbeClient(resilienceConfig)))
 
 	if err != nil {
-		c.logger.Errorw("Error creating request at cache service", zap.String("Request Id", requestId), zap.Error(err))
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-request-id", requestId)
+	return &cacheService{
+		client: client,
+		probe:  probe,
+		logger: logger,
+	}, nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+func (c *cacheService) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
+	c.logger.Infow("Sending redirect request to cache service", zap.String("Request Id", requestId))
+
+	resp, err := c.client.LookupRedirectWithBodyWithResponse(resilience.Idempotent(ctx), "application/json", body, api.WithRequestId(requestId))
 
 	if err != nil {
 		c.logger.Errorw("Error sending request to cache service", zap.String("Request Id", requestId), zap.Error(err))
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		c.logger.Errorw("Request failed at cache service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode), zap.String("status", resp.Status))
-		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	if resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusGone {
+		c.logger.Errorw("Request failed at cache service", zap.String("Request Id", requestId), zap.String("status", resp.Status()))
+		return nil, errors.New(http.StatusText(resp.StatusCode()))
+	}
+
+	if resp.JSON200 == nil {
+		c.logger.Errorw("Request failed at cache service", zap.String("Request Id", requestId), zap.String("status", resp.Status()))
+		return nil, errors.New("request failed at cache service")
 	}
 
-	c.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("status", resp.Status))
+	c.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Any("response", resp.JSON200))
+
+	return resp.JSON200, nil
+}
+
+// HandleStore caches the redirect of a newly created link, so its first
+// redirect does not miss the cache.
+func (c *cacheService) HandleStore(ctx context.Context, link *models.CacheStoreRequestModel, requestId string) error {
+	c.logger.Infow("Sending store request to cache service", zap.String("Request Id", requestId), zap.String("shorturlpath", link.ShortUrlPath))
 
-	if resp.Body == nil {
-		c.logger.Errorw("Empty response body from cache service", zap.String("Request Id", requestId))
-		return nil, errors.New("empty response body")
+	resp, err := c.client.StoreCacheWithResponse(ctx, *link, api.WithRequestId(requestId))
+
+	if err != nil {
+		c.logger.Errorw("Error sending request to cache service", zap.String("Request Id", requestId), zap.Error(err))
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusOK {
+		c.logger.Errorw("Request failed at cache service", zap.String("Request Id", requestId), zap.String("status", resp.Status()))
+		return errors.New("request failed at cache service")
 	}
 
-	httpBody, err := io.ReadAll(resp.Body)
+	c.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("status", resp.Status()))
+
+	return nil
+}
+
+// HandleEvict removes a short url path from the cache service so the next
+// redirect reads it from the database service.
+func (c *cacheService) HandleEvict(ctx context.Context, shortUrlPath string, requestId string) error {
+	c.logger.Infow("Sending evict request to cache service", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	resp, err := c.client.EvictCacheWithResponse(ctx, shortUrlPath, api.WithRequestId(requestId))
 
 	if err != nil {
-		c.logger.Errorw("Error reading response body at cache service", zap.String("Request Id", requestId), zap.Error(err))
-		return nil, err
+		c.logger.Errorw("Error sending request to cache service", zap.String("Request Id", requestId), zap.Error(err))
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent && resp.StatusCode() != http.StatusOK {
+		c.logger.Errorw("Request failed at cache service", zap.String("Request Id", requestId), zap.String("status", resp.Status()))
+		return errors.New("request failed at cache service")
 	}
 
-	c.logger.Infow("Successfully read response body at cache service", zap.String("Request Id", requestId), zap.Any("response", httpBody))
+	c.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("status", resp.Status()))
 
-	unmarsheledBody := &models.RedirectResponseModel{}
+	return nil
+}
 
-	err = json.Unmarshal(httpBody, unmarsheledBody)
+// HandleReady checks that the cache service can serve redirects.
+func (c *cacheService) HandleReady(ctx context.Context) error {
+	resp, err := c.probe.CheckReadinessWithResponse(ctx)
 
 	if err != nil {
-		c.logger.Errorw("Error unmarshalling response body at database service", zap.String("Request Id", requestId), zap.Error(err))
-		return nil, err
+		c.logger.Errorw("Error sending request to cache service", zap.Error(err))
+		return err
 	}
 
-	c.logger.Infow("Successfully unmarshalled response body at database service", zap.String("Request Id", requestId), zap.Any("request", unmarsheledBody))
+	if resp.StatusCode() != http.StatusOK {
+		c.logger.Errorw("Cache service is not ready", zap.String("status", resp.Status()))
+		return errors.New("cache service is not ready")
+	}
 
-	return unmarsheledBody, nil
+	return nil
 }
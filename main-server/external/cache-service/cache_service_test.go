@@ -0,0 +1,252 @@
+package cacheservice_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	cacheservice "main-server/external/cache-service"
+	"main-server/internal/config"
+	"main-server/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"url-shortner-api/resilience"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestHandleRedirect(t *testing.T) {
+	tests := map[string]struct {
+		failures         int32
+		status           int
+		ExpectedError    string
+		ExpectedUrl      string
+		ExpectedAttempts int32
+	}{
+		"Success": {
+			ExpectedUrl:      "https://google.com",
+			ExpectedAttempts: 1,
+		},
+		"Retried": {
+			failures:         1,
+			status:           http.StatusServiceUnavailable,
+			ExpectedUrl:      "https://google.com",
+			ExpectedAttempts: 2,
+		},
+		"Not Found": {
+			failures:         1,
+			status:           http.StatusNotFound,
+			ExpectedError:    http.StatusText(http.StatusNotFound),
+			ExpectedAttempts: 1,
+		},
+		"Deleted": {
+			failures:         1,
+			status:           http.StatusGone,
+			ExpectedError:    http.StatusText(http.StatusGone),
+			ExpectedAttempts: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) <= test.failures {
+					w.WriteHeader(test.status)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"redirecturl":"https://google.com"}`))
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{CacheServiceBaseUrl: server.URL}
+
+			cacheService, err := cacheservice.NewCacheService(cfg, nil, zap.NewNop().Sugar())
+			assert.NoError(t, err)
+
+			resp, err := cacheService.HandleRedirect(context.Background(), bytes.NewBufferString(`{"shorturlpath":"abc1234"}`), "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.ExpectedUrl, resp.Url)
+			}
+
+			assert.Equal(t, test.ExpectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestHandleRedirectCircuitOpen(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{CacheServiceBaseUrl: server.URL}
+
+	cacheService, err := cacheservice.NewCacheService(cfg, nil, zap.NewNop().Sugar())
+	assert.NoError(t, err)
+
+	config := resilience.DefaultConfig("cache-service")
+
+	for i := 0; i < config.FailureThreshold; i++ {
+		_, err := cacheService.HandleRedirect(context.Background(), bytes.NewBufferString(`{"shorturlpath":"abc1234"}`), "abc")
+		assert.Error(t, err)
+	}
+
+	calls := atomic.LoadInt32(&attempts)
+	assert.Equal(t, int32(config.FailureThreshold*(config.MaxRetries+1)), calls)
+
+	_, err = cacheService.HandleRedirect(context.Background(), bytes.NewBufferString(`{"shorturlpath":"abc1234"}`), "abc")
+	assert.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	assert.Equal(t, calls, atomic.LoadInt32(&attempts), "an open circuit does not reach the cache service")
+}
+
+func TestHandleReadyKeepsCircuitClosed(t *testing.T) {
+	var redirects int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/readyz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		atomic.AddInt32(&redirects, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"redirecturl":"https://google.com"}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{CacheServiceBaseUrl: server.URL}
+
+	cacheService, err := cacheservice.NewCacheService(cfg, nil, zap.NewNop().Sugar())
+	assert.NoError(t, err)
+
+	for i := 0; i < 2*resilience.DefaultConfig("cache-service").FailureThreshold; i++ {
+		assert.Error(t, cacheService.HandleReady(context.Background()))
+	}
+
+	resp, err := cacheService.HandleRedirect(context.Background(), bytes.NewBufferString(`{"shorturlpath":"abc1234"}`), "abc")
+	assert.NoError(t, err, "failed probes do not open the circuit")
+	assert.Equal(t, "https://google.com", resp.Url)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&redirects))
+}
+
+func TestHandleStore(t *testing.T) {
+	tests := map[string]struct {
+		status        int
+		ExpectedError string
+	}{
+		"Stored": {
+			status: http.StatusNoContent,
+		},
+		"Failed": {
+			status:        http.StatusInternalServerError,
+			ExpectedError: "request failed at cache service",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/cache", r.URL.Path)
+
+				link := &models.CacheStoreRequestModel{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(link))
+				assert.Equal(t, "abc1234", link.ShortUrlPath)
+				assert.Equal(t, "https://google.com", link.Url)
+
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{CacheServiceBaseUrl: server.URL}
+
+			cacheService, err := cacheservice.NewCacheService(cfg, nil, zap.NewNop().Sugar())
+			assert.NoError(t, err)
+
+			err = cacheService.HandleStore(context.Background(), &models.CacheStoreRequestModel{ShortUrlPath: "abc1234", Url: "https://google.com"}, "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHandleReady(t *testing.T) {
+	tests := map[string]struct {
+		status        int
+		ExpectedError string
+	}{
+		"Ready": {
+			status: http.StatusOK,
+		},
+		"Not Ready": {
+			status:        http.StatusServiceUnavailable,
+			ExpectedError: "cache service is not ready",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/readyz", r.URL.Path)
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			cfg := &config.Config{CacheServiceBaseUrl: server.URL}
+
+			cacheService, err := cacheservice.NewCacheService(cfg, nil, zap.NewNop().Sugar())
+			assert.NoError(t, err)
+
+			err = cacheService.HandleReady(context.Background())
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHandleReadyTls(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{CacheServiceBaseUrl: server.URL}
+
+	t.Run("Trusted", func(t *testing.T) {
+		cacheService, err := cacheservice.NewCacheService(cfg, server.Client().Transport.(*http.Transport).TLSClientConfig, zap.NewNop().Sugar())
+		assert.NoError(t, err)
+
+		assert.NoError(t, cacheService.HandleReady(context.Background()))
+	})
+
+	t.Run("Untrusted", func(t *testing.T) {
+		cacheService, err := cacheservice.NewCacheService(cfg, nil, zap.NewNop().Sugar())
+		assert.NoError(t, err)
+
+		assert.Error(t, cacheService.HandleReady(context.Background()), "the test server is not signed by a system root")
+	})
+}
@@ -0,0 +1,99 @@
+package cacheservice
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"main-server/internal/config"
+	"main-server/internal/models"
+	"net/http"
+
+	"url-shortner-api/health"
+	"url-shortner-api/pb"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// grpcCacheService talks to the cache service over gRPC instead of JSON over
+// HTTP. It is selected with SERVICE_TRANSPORT=grpc; storing newly created
+// links, which has no gRPC counterpart, still goes over HTTP.
+type grpcCacheService struct {
+	*cacheService
+	client       pb.CacheServiceClient
+	healthClient grpc_health_v1.HealthClient
+}
+
+func NewGrpcCacheService(config *config.Config, clientTLS *tls.Config, logger *zap.SugaredLogger, conn grpc.ClientConnInterface) (*grpcCacheService, error) {
+	cacheService, err := NewCacheService(config, clientTLS, logger)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcCacheService{
+		cacheService: cacheService,
+		client:       pb.NewCacheServiceClient(conn),
+		healthClient: grpc_health_v1.NewHealthClient(conn),
+	}, nil
+}
+
+func (c *grpcCacheService) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
+	c.logger.Infow("Sending resolve rpc to cache service", zap.String("Request Id", requestId))
+
+	request := &models.RedirectRequestModel{}
+
+	if err := json.NewDecoder(body).Decode(request); err != nil {
+		c.logger.Errorw("Error decoding redirect request", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, err
+	}
+
+	resp, err := c.client.Resolve(pb.WithRequestId(ctx, requestId), &pb.ResolveRequest{ShortUrlPath: request.ShortUrlPath})
+
+	if err != nil {
+		c.logger.Errorw("Request failed at cache service", zap.String("Request Id", requestId), zap.Error(err))
+
+		if status.Code(err) == codes.NotFound {
+			return nil, errors.New(http.StatusText(http.StatusNotFound))
+		}
+
+		if status.Code(err) == pb.CodeGone {
+			return nil, errors.New(http.StatusText(http.StatusGone))
+		}
+
+		return nil, errors.New("request failed at cache service")
+	}
+
+	c.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("url", resp.GetUrl()))
+
+	return &models.RedirectResponseModel{Url: resp.GetUrl()}, nil
+}
+
+func (c *grpcCacheService) HandleEvict(ctx context.Context, shortUrlPath string, requestId string) error {
+	c.logger.Infow("Sending invalidate rpc to cache service", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	if _, err := c.client.Invalidate(pb.WithRequestId(ctx, requestId), &pb.InvalidateRequest{ShortUrlPath: shortUrlPath}); err != nil {
+		c.logger.Errorw("Request failed at cache service", zap.String("Request Id", requestId), zap.Error(err))
+		return errors.New("request failed at cache service")
+	}
+
+	c.logger.Infow("Request successful", zap.String("Request Id", requestId))
+
+	return nil
+}
+
+// HandleReady asks the gRPC health service of the cache service whether it
+// is serving.
+func (c *grpcCacheService) HandleReady(ctx context.Context) error {
+	if err := health.CheckGrpc(ctx, c.healthClient); err != nil {
+		c.logger.Errorw("Cache service is not ready", zap.Error(err))
+		return errors.New("cache service is not ready")
+	}
+
+	return nil
+}
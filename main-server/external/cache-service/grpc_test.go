@@ -0,0 +1,185 @@
+package cacheservice_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	cacheservice "main-server/external/cache-service"
+	"main-server/internal/config"
+	"net"
+	"net/http"
+	"testing"
+
+	"url-shortner-api/health"
+	"url-shortner-api/pb"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeCacheServer struct {
+	pb.UnimplementedCacheServiceServer
+	requestId   string
+	invalidated string
+	err         error
+	unready     error
+}
+
+func (f *fakeCacheServer) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveResponse, error) {
+	f.requestId = pb.RequestId(ctx)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &pb.ResolveResponse{Url: "https://google.com/" + req.GetShortUrlPath()}, nil
+}
+
+func (f *fakeCacheServer) Invalidate(ctx context.Context, req *pb.InvalidateRequest) (*pb.InvalidateResponse, error) {
+	f.requestId = pb.RequestId(ctx)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.invalidated = req.GetShortUrlPath()
+
+	return &pb.InvalidateResponse{}, nil
+}
+
+func newGrpcCacheService(t *testing.T, server *fakeCacheServer) cacheservice.CacheServiceInterface {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterCacheServiceServer(grpcServer, server)
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewGrpcServer(health.Checks{
+		"redis": func(ctx context.Context) error { return server.unready },
+	}))
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	cacheService, err := cacheservice.NewGrpcCacheService(&config.Config{}, nil, zap.NewNop().Sugar(), conn)
+	assert.NoError(t, err)
+
+	return cacheService
+}
+
+func TestGrpcHandleRedirect(t *testing.T) {
+	tests := map[string]struct {
+		server        *fakeCacheServer
+		ExpectedError string
+		ExpectedUrl   string
+	}{
+		"Not Found": {
+			server:        &fakeCacheServer{err: status.Error(codes.NotFound, "url not found")},
+			ExpectedError: http.StatusText(http.StatusNotFound),
+		},
+		"Deleted": {
+			server:        &fakeCacheServer{err: status.Error(pb.CodeGone, "url was deleted")},
+			ExpectedError: http.StatusText(http.StatusGone),
+		},
+		"Cache Service Fail": {
+			server:        &fakeCacheServer{err: status.Error(codes.Internal, "boom")},
+			ExpectedError: "request failed at cache service",
+		},
+		"Success": {
+			server:      &fakeCacheServer{},
+			ExpectedUrl: "https://google.com/abc1234",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cacheService := newGrpcCacheService(t, test.server)
+
+			resp, err := cacheService.HandleRedirect(context.Background(), bytes.NewBufferString(`{"shorturlpath":"abc1234"}`), "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedUrl, resp.Url)
+			assert.Equal(t, "abc", test.server.requestId)
+		})
+	}
+}
+
+func TestGrpcHandleEvict(t *testing.T) {
+	tests := map[string]struct {
+		server        *fakeCacheServer
+		ExpectedError string
+	}{
+		"Cache Service Fail": {
+			server:        &fakeCacheServer{err: status.Error(codes.Internal, "boom")},
+			ExpectedError: "request failed at cache service",
+		},
+		"Success": {
+			server: &fakeCacheServer{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cacheService := newGrpcCacheService(t, test.server)
+
+			err := cacheService.HandleEvict(context.Background(), "abc1234", "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "abc1234", test.server.invalidated)
+			assert.Equal(t, "abc", test.server.requestId)
+		})
+	}
+}
+
+func TestGrpcHandleReady(t *testing.T) {
+	tests := map[string]struct {
+		server        *fakeCacheServer
+		ExpectedError string
+	}{
+		"Not Serving": {
+			server:        &fakeCacheServer{unready: errors.New("connection refused")},
+			ExpectedError: "cache service is not ready",
+		},
+		"Serving": {
+			server: &fakeCacheServer{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cacheService := newGrpcCacheService(t, test.server)
+
+			err := cacheService.HandleReady(context.Background())
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
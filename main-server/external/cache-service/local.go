@@ -0,0 +1,111 @@
+package cacheservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"main-server/internal/lru"
+	"main-server/internal/metrics"
+	"main-server/internal/models"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// localCacheService serves the redirects of hot links from memory, in front
+// of the cache service. Short urls that do not exist are remembered too, for
+// negativeTTL, so repeated lookups of a bad link do not leave the process
+// either. Links changed through this instance are evicted right away; the
+// short TTLs bound how long a change made through another instance is missed.
+type localCacheService struct {
+	next        CacheServiceInterface
+	links       *lru.Cache[*models.RedirectResponseModel]
+	ttl         time.Duration
+	negativeTTL time.Duration
+	logger      *zap.SugaredLogger
+}
+
+// NewLocalCacheService keeps up to size links in front of next. A nil link
+// marks a short url path that was not found.
+func NewLocalCacheService(next CacheServiceInterface, size int, ttl, negativeTTL time.Duration, logger *zap.SugaredLogger) *localCacheService {
+	return &localCacheService{
+		next:        next,
+		links:       lru.New[*models.RedirectResponseModel](size),
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		logger:      logger,
+	}
+}
+
+func (c *localCacheService) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
+	payload, err := io.ReadAll(body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request := &models.RedirectRequestModel{}
+
+	if err := json.Unmarshal(payload, request); err != nil {
+		return c.next.HandleRedirect(ctx, bytes.NewReader(payload), requestId)
+	}
+
+	if link, ok := c.links.Get(request.ShortUrlPath); ok {
+		if link == nil {
+			metrics.LocalCacheLookups.WithLabelValues("negative_hit").Inc()
+			c.logger.Infow("Short url path not found in local cache", zap.String("Request Id", requestId))
+			return nil, errors.New(http.StatusText(http.StatusNotFound))
+		}
+
+		metrics.LocalCacheLookups.WithLabelValues("hit").Inc()
+		c.logger.Infow("Redirect served from local cache", zap.String("Request Id", requestId))
+
+		return &models.RedirectResponseModel{Url: link.Url}, nil
+	}
+
+	metrics.LocalCacheLookups.WithLabelValues("miss").Inc()
+
+	link, err := c.next.HandleRedirect(ctx, bytes.NewReader(payload), requestId)
+
+	if err != nil {
+		if err.Error() == http.StatusText(http.StatusNotFound) && c.negativeTTL > 0 {
+			c.store(request.ShortUrlPath, nil, c.negativeTTL)
+		}
+
+		return nil, err
+	}
+
+	c.store(request.ShortUrlPath, &models.RedirectResponseModel{Url: link.Url}, c.ttl)
+
+	return link, nil
+}
+
+// HandleStore drops the short url path from memory, which may remember it as
+// not found, before storing it in the cache service.
+func (c *localCacheService) HandleStore(ctx context.Context, link *models.CacheStoreRequestModel, requestId string) error {
+	c.links.Delete(link.ShortUrlPath)
+	metrics.LocalCacheEntries.Set(float64(c.links.Len()))
+
+	return c.next.HandleStore(ctx, link, requestId)
+}
+
+// HandleEvict drops the short url path from memory before evicting it from
+// the cache service.
+func (c *localCacheService) HandleEvict(ctx context.Context, shortUrlPath string, requestId string) error {
+	c.links.Delete(shortUrlPath)
+	metrics.LocalCacheEntries.Set(float64(c.links.Len()))
+
+	return c.next.HandleEvict(ctx, shortUrlPath, requestId)
+}
+
+func (c *localCacheService) HandleReady(ctx context.Context) error {
+	return c.next.HandleReady(ctx)
+}
+
+func (c *localCacheService) store(shortUrlPath string, link *models.RedirectResponseModel, ttl time.Duration) {
+	c.links.Set(shortUrlPath, link, ttl)
+	metrics.LocalCacheEntries.Set(float64(c.links.Len()))
+}
@@ -0,0 +1,128 @@
+package cacheservice_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	cacheservice "main-server/external/cache-service"
+	mock_cacheservice "main-server/external/cache-service/mocks"
+	"main-server/internal/models"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func redirect(cacheService cacheservice.CacheServiceInterface, shortUrlPath string) (*models.RedirectResponseModel, error) {
+	return cacheService.HandleRedirect(context.Background(), bytes.NewBufferString(`{"shorturlpath":"`+shortUrlPath+`"}`), "abc")
+}
+
+func TestLocalHandleRedirect(t *testing.T) {
+	tests := map[string]struct {
+		resp          *models.RedirectResponseModel
+		err           error
+		lookups       int
+		ExpectedCalls int
+		ExpectedUrl   string
+		ExpectedError string
+	}{
+		"Cached": {
+			resp:          &models.RedirectResponseModel{Url: "https://google.com"},
+			lookups:       3,
+			ExpectedCalls: 1,
+			ExpectedUrl:   "https://google.com",
+		},
+		"Not Found Cached": {
+			err:           errors.New(http.StatusText(http.StatusNotFound)),
+			lookups:       3,
+			ExpectedCalls: 1,
+			ExpectedError: http.StatusText(http.StatusNotFound),
+		},
+		"Failure Not Cached": {
+			err:           errors.New("request failed at cache service"),
+			lookups:       3,
+			ExpectedCalls: 3,
+			ExpectedError: "request failed at cache service",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			next := mock_cacheservice.NewMockCacheServiceInterface(gomock.NewController(t))
+			next.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(test.resp, test.err).Times(test.ExpectedCalls)
+
+			cacheService := cacheservice.NewLocalCacheService(next, 10, time.Minute, time.Minute, zap.NewNop().Sugar())
+
+			for i := 0; i < test.lookups; i++ {
+				resp, err := redirect(cacheService, "abc1234")
+
+				if test.ExpectedError != "" {
+					assert.EqualError(t, err, test.ExpectedError)
+					continue
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, test.ExpectedUrl, resp.Url)
+			}
+		})
+	}
+}
+
+func TestLocalHandleRedirectExpiry(t *testing.T) {
+	next := mock_cacheservice.NewMockCacheServiceInterface(gomock.NewController(t))
+	next.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(nil, errors.New(http.StatusText(http.StatusNotFound)))
+	next.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(&models.RedirectResponseModel{Url: "https://google.com"}, nil)
+
+	cacheService := cacheservice.NewLocalCacheService(next, 10, time.Minute, 10*time.Millisecond, zap.NewNop().Sugar())
+
+	_, err := redirect(cacheService, "abc1234")
+	assert.Error(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+
+	resp, err := redirect(cacheService, "abc1234")
+	assert.NoError(t, err, "the not found entry expired")
+	assert.Equal(t, "https://google.com", resp.Url)
+}
+
+func TestLocalHandleEvict(t *testing.T) {
+	next := mock_cacheservice.NewMockCacheServiceInterface(gomock.NewController(t))
+	next.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(&models.RedirectResponseModel{Url: "https://google.com"}, nil)
+	next.EXPECT().HandleEvict(gomock.Any(), "abc1234", "abc").Return(nil)
+	next.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(&models.RedirectResponseModel{Url: "https://example.com"}, nil)
+
+	cacheService := cacheservice.NewLocalCacheService(next, 10, time.Minute, time.Minute, zap.NewNop().Sugar())
+
+	resp, err := redirect(cacheService, "abc1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://google.com", resp.Url)
+
+	assert.NoError(t, cacheService.HandleEvict(context.Background(), "abc1234", "abc"))
+
+	resp, err = redirect(cacheService, "abc1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", resp.Url, "the evicted link is looked up again")
+}
+
+func TestLocalHandleStore(t *testing.T) {
+	link := &models.CacheStoreRequestModel{ShortUrlPath: "abc1234", Url: "https://google.com"}
+
+	next := mock_cacheservice.NewMockCacheServiceInterface(gomock.NewController(t))
+	next.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(nil, errors.New(http.StatusText(http.StatusNotFound)))
+	next.EXPECT().HandleStore(gomock.Any(), link, "abc").Return(nil)
+	next.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), "abc").Return(&models.RedirectResponseModel{Url: "https://google.com"}, nil)
+
+	cacheService := cacheservice.NewLocalCacheService(next, 10, time.Minute, time.Minute, zap.NewNop().Sugar())
+
+	_, err := redirect(cacheService, "abc1234")
+	assert.Error(t, err)
+
+	assert.NoError(t, cacheService.HandleStore(context.Background(), link, "abc"))
+
+	resp, err := redirect(cacheService, "abc1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://google.com", resp.Url, "a link created after it was not found is looked up again")
+}
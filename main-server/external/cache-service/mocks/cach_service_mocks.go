@@ -1,10 +1,11 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: D:\Programming\Go\url-shortner\main-server\external\cache-service\cache_service.go
+// Source: external/cache-service/cache_service.go
 
 // Package mock_cacheservice is a generated GoMock package.
 package mock_cacheservice
 
 import (
+	context "context"
 	io "io"
 	models "main-server/internal/models"
 	reflect "reflect"
@@ -35,17 +36,59 @@ func (m *MockCacheServiceInterface) EXPECT() *MockCacheServiceInterfaceMockRecor
 	return m.recorder
 }
 
+// HandleEvict mocks base method.
+func (m *MockCacheServiceInterface) HandleEvict(ctx context.Context, shortUrlPath, requestId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvict", ctx, shortUrlPath, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvict indicates an expected call of HandleEvict.
+func (mr *MockCacheServiceInterfaceMockRecorder) HandleEvict(ctx, shortUrlPath, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvict", reflect.TypeOf((*MockCacheServiceInterface)(nil).HandleEvict), ctx, shortUrlPath, requestId)
+}
+
+// HandleReady mocks base method.
+func (m *MockCacheServiceInterface) HandleReady(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleReady", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleReady indicates an expected call of HandleReady.
+func (mr *MockCacheServiceInterfaceMockRecorder) HandleReady(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleReady", reflect.TypeOf((*MockCacheServiceInterface)(nil).HandleReady), ctx)
+}
+
 // HandleRedirect mocks base method.
-func (m *MockCacheServiceInterface) HandleRedirect(body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
+func (m *MockCacheServiceInterface) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleRedirect", body, requestId)
+	ret := m.ctrl.Call(m, "HandleRedirect", ctx, body, requestId)
 	ret0, _ := ret[0].(*models.RedirectResponseModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HandleRedirect indicates an expected call of HandleRedirect.
-func (mr *MockCacheServiceInterfaceMockRecorder) HandleRedirect(body, requestId interface{}) *gomock.Call {
+func (mr *MockCacheServiceInterfaceMockRecorder) HandleRedirect(ctx, body, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRedirect", reflect.TypeOf((*MockCacheServiceInterface)(nil).HandleRedirect), ctx, body, requestId)
+}
+
+// HandleStore mocks base method.
+func (m *MockCacheServiceInterface) HandleStore(ctx context.Context, link *models.CacheStoreRequestModel, requestId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleStore", ctx, link, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleStore indicates an expected call of HandleStore.
+func (mr *MockCacheServiceInterfaceMockRecorder) HandleStore(ctx, link, requestId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRedirect", reflect.TypeOf((*MockCacheServiceInterface)(nil).HandleRedirect), body, requestId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleStore", reflect.TypeOf((*MockCacheServiceInterface)(nil).HandleStore), ctx, link, requestId)
 }
@@ -1,50 +1,131 @@
 package databaseservice
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"main-server/internal/config"
 	"main-server/internal/models"
 	"net/http"
 
+	api "url-shortner-api"
+	"url-shortner-api/resilience"
+
 	"go.uber.org/zap"
 )
 
 type DatabaseServiceInterface interface {
-	HandleShorten(body io.Reader, requestId string) (*models.ShortenResponseModel, error)
-	HandleRedirect(body io.Reader, requestId string) (*models.RedirectResponseModel, error)
+	HandleShorten(ctx context.Context, body io.Reader, requestId string) (*models.ShortenResponseModel, error)
+	HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error)
+	HandleListLinks(ctx context.Context, body io.Reader, requestId string) (*models.ListResponseModel, error)
+	HandleExportLinks(ctx context.Context, body io.Reader, requestId string) (io.ReadCloser, error)
+	HandleImportLinks(ctx context.Context, body io.Reader, requestId string) (*models.ImportResponseModel, error)
+	HandleGetLink(ctx context.Context, shortUrlPath string, requestId string) (*models.LinkModel, error)
+	HandleUpdateLink(ctx context.Context, shortUrlPath string, body io.Reader, requestId string) (*models.LinkModel, error)
+	HandleDeleteLink(ctx context.Context, shortUrlPath string, requestId string) error
+	HandleRestoreLink(ctx context.Context, shortUrlPath string, requestId string) (*models.LinkModel, error)
+	HandleLinkStats(ctx context.Context, shortUrlPath string, days int, requestId string) (*models.StatsResponseModel, error)
+	HandleRecordClicks(ctx context.Context, body io.Reader, requestId string) error
+	HandleReady(ctx context.Context) error
 }
 
+// databaseService sends readiness probes with a separate client without the
+// breaker, so failed probes do not cut off the calls that serve requests.
 type databaseService struct {
-	config config.ConfigInterface
+	client *api.ClientWithResponses
+	probe  *api.ClientWithResponses
 	logger *zap.SugaredLogger
 }
 
-func NewDatabaseService(config config.ConfigInterface, logger *zap.SugaredLogger) *databaseService {
+func NewDatabaseService(config *config.Config, clientTLS *tls.Config, logger *zap.SugaredLogger) (*databaseService, error) {
+	resilienceConfig := resilience.DefaultConfig("database-service")
+	resilienceConfig.TLS = clientTLS
+
+	client, err := api.NewClientWithResponses(config.DatabaseServiceBaseUrl, api.WithHTTPClient(resilience.NewClient(resilienceConfig)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	probe, err := api.NewClientWithResponses(config.DatabaseServiceBaseUrl, api.WithHTTPClient(resilience.NewProbeClient(resilienceConfig)))
+
+	if err != nil {
+		return nil, err
+	}
+
 	return &databaseService{
-		config: config,
+		client: client,
+		probe:  probe,
 		logger: logger,
+	}, nil
+}
+
+func (d *databaseService) HandleShorten(ctx context.Context, body io.Reader, requestId string) (*models.ShortenResponseModel, error) {
+	d.logger.Infow("Sending shorten request to database service", zap.String("Request Id", requestId))
+
+	resp, err := d.client.ShortenWithBodyWithResponse(ctx, "application/json", body, api.WithRequestId(requestId))
+
+	if err != nil {
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, err
+	}
+
+	if resp.JSON200 == nil {
+		return nil, d.statusError(resp.HTTPResponse, requestId)
 	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Any("response", resp.JSON200))
+
+	return resp.JSON200, nil
 }
 
-func (d *databaseService) HandleShorten(body io.Reader, requestId string) (*models.ShortenResponseModel, error) {
-	reqUrl := d.config.Get("DATABASE_SERVICE_BASE_URL") + "/shorten"
+func (d *databaseService) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
+	d.logger.Infow("Sending redirect request to database service", zap.String("Request Id", requestId))
 
-	d.logger.Infow("Sending request to database service", zap.String("Request Id", requestId), zap.String("url", reqUrl))
+	resp, err := d.client.LookupRedirectWithBodyWithResponse(resilience.Idempotent(ctx), "application/json", body, api.WithRequestId(requestId))
 
-	req, err := http.NewRequest(http.MethodPost, reqUrl, body)
+	if err != nil {
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, err
+	}
+
+	if resp.JSON200 == nil {
+		return nil, d.statusError(resp.HTTPResponse, requestId)
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Any("response", resp.JSON200))
+
+	return resp.JSON200, nil
+}
+
+func (d *databaseService) HandleListLinks(ctx context.Context, body io.Reader, requestId string) (*models.ListResponseModel, error) {
+	d.logger.Infow("Sending list links request to database service", zap.String("Request Id", requestId))
+
+	resp, err := d.client.QueryLinksWithBodyWithResponse(resilience.Idempotent(ctx), "application/json", body, api.WithRequestId(requestId))
 
 	if err != nil {
-		d.logger.Errorw("Error creating request at database service", zap.String("Request Id", requestId), zap.Error(err))
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-request-id", requestId)
+	if resp.JSON200 == nil {
+		return nil, d.statusError(resp.HTTPResponse, requestId)
+	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Int("count", len(resp.JSON200.Links)))
+
+	return resp.JSON200, nil
+}
+
+// HandleExportLinks returns the NDJSON stream of links from the database
+// service. The caller is responsible for closing it.
+func (d *databaseService) HandleExportLinks(ctx context.Context, body io.Reader, requestId string) (io.ReadCloser, error) {
+	d.logger.Infow("Sending export links request to database service", zap.String("Request Id", requestId))
+
+	// The plain client is used so the stream is not read into memory, and
+	// without a deadline as large exports take a while.
+	resp, err := d.client.ClientInterface.StreamLinksWithBody(resilience.WithTimeout(ctx, 0), "application/json", body, api.WithRequestId(requestId))
 
 	if err != nil {
 		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
@@ -52,99 +133,180 @@ func (d *databaseService) HandleShorten(body io.Reader, requestId string) (*mode
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode), zap.String("status", resp.Status))
-		return nil, errors.New("request failed at database service")
+		resp.Body.Close()
+		return nil, d.statusError(resp, requestId)
 	}
 
 	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("status", resp.Status))
 
-	if resp.Body == nil {
-		d.logger.Errorw("Empty response body from database service", zap.String("Request Id", requestId))
-		return nil, errors.New("empty response body")
-	}
+	return resp.Body, nil
+}
 
-	httpBody, err := io.ReadAll(resp.Body)
+func (d *databaseService) HandleImportLinks(ctx context.Context, body io.Reader, requestId string) (*models.ImportResponseModel, error) {
+	d.logger.Infow("Sending import links request to database service", zap.String("Request Id", requestId))
+
+	resp, err := d.client.InsertLinksWithBodyWithResponse(ctx, "application/json", body, api.WithRequestId(requestId))
 
 	if err != nil {
-		d.logger.Errorw("Error reading response body at database service", zap.String("Request Id", requestId), zap.Error(err))
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
 		return nil, err
 	}
 
-	d.logger.Infow("Successfully read response body at database service", zap.String("Request Id", requestId), zap.Any("response", httpBody))
+	if resp.JSON200 == nil {
+		return nil, d.statusError(resp.HTTPResponse, requestId)
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Int("count", len(resp.JSON200.Results)))
+
+	return resp.JSON200, nil
+}
 
-	unmarsheledBody := &models.ShortenResponseModel{}
+func (d *databaseService) HandleGetLink(ctx context.Context, shortUrlPath string, requestId string) (*models.LinkModel, error) {
+	d.logger.Infow("Sending get link request to database service", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
 
-	err = json.Unmarshal(httpBody, unmarsheledBody)
+	resp, err := d.client.FindLinkWithResponse(ctx, shortUrlPath, api.WithRequestId(requestId))
 
 	if err != nil {
-		d.logger.Errorw("Error unmarshalling response body at database service", zap.String("Request Id", requestId), zap.Error(err))
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
 		return nil, err
 	}
 
-	d.logger.Infow("Successfully unmarshalled response body at database service", zap.String("Request Id", requestId), zap.Any("request", unmarsheledBody))
+	if resp.JSON200 == nil {
+		return nil, d.statusError(resp.HTTPResponse, requestId)
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Any("response", resp.JSON200))
 
-	return unmarsheledBody, nil
+	return resp.JSON200, nil
 }
 
-func (d *databaseService) HandleRedirect(body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
-	reqUrl := d.config.Get("DATABASE_SERVICE_BASE_URL") + "/redirect"
-
-	d.logger.Infow("Sending request to database service", zap.String("Request Id", requestId), zap.String("url", reqUrl))
+func (d *databaseService) HandleUpdateLink(ctx context.Context, shortUrlPath string, body io.Reader, requestId string) (*models.LinkModel, error) {
+	d.logger.Infow("Sending update link request to database service", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
 
-	req, err := http.NewRequest(http.MethodPost, reqUrl, body)
+	resp, err := d.client.PatchLinkWithBodyWithResponse(ctx, shortUrlPath, "application/json", body, api.WithRequestId(requestId))
 
 	if err != nil {
-		d.logger.Errorw("Error creating request at database service", zap.String("Request Id", requestId), zap.Error(err))
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-request-id", requestId)
+	if resp.JSON200 == nil {
+		return nil, d.statusError(resp.HTTPResponse, requestId)
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Any("response", resp.JSON200))
+
+	return resp.JSON200, nil
+}
+
+func (d *databaseService) HandleDeleteLink(ctx context.Context, shortUrlPath string, requestId string) error {
+	d.logger.Infow("Sending delete link request to database service", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := d.client.RemoveLinkWithResponse(ctx, shortUrlPath, api.WithRequestId(requestId))
 
 	if err != nil {
 		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
-		return nil, err
+		return err
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode), zap.String("status", resp.Status))
-		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	if resp.StatusCode() != http.StatusNoContent {
+		return d.statusError(resp.HTTPResponse, requestId)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Int("status", resp.StatusCode), zap.String("status", resp.Status))
-		return nil, errors.New("request failed at database service")
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("status", resp.Status()))
+
+	return nil
+}
+
+// HandleRestoreLink revives a deleted link that is still within the
+// retention window of the database service.
+func (d *databaseService) HandleRestoreLink(ctx context.Context, shortUrlPath string, requestId string) (*models.LinkModel, error) {
+	d.logger.Infow("Sending restore link request to database service", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	resp, err := d.client.ReviveLinkWithResponse(ctx, shortUrlPath, api.WithRequestId(requestId))
+
+	if err != nil {
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, err
 	}
 
-	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("status", resp.Status))
+	if resp.JSON200 == nil {
+		return nil, d.statusError(resp.HTTPResponse, requestId)
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Any("response", resp.JSON200))
 
-	if resp.Body == nil {
-		d.logger.Errorw("Empty response body from database service", zap.String("Request Id", requestId))
-		return nil, errors.New("empty response body")
+	return resp.JSON200, nil
+}
+
+func (d *databaseService) HandleLinkStats(ctx context.Context, shortUrlPath string, days int, requestId string) (*models.StatsResponseModel, error) {
+	d.logger.Infow("Sending link stats request to database service", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	params := &api.FindLinkStatsParams{}
+	if days > 0 {
+		params.Days = &days
 	}
 
-	httpBody, err := io.ReadAll(resp.Body)
+	resp, err := d.client.FindLinkStatsWithResponse(ctx, shortUrlPath, params, api.WithRequestId(requestId))
 
 	if err != nil {
-		d.logger.Errorw("Error reading response body at database service", zap.String("Request Id", requestId), zap.Error(err))
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
 		return nil, err
 	}
 
-	d.logger.Infow("Successfully read response body at database service", zap.String("Request Id", requestId), zap.Any("response", httpBody))
+	if resp.JSON200 == nil {
+		return nil, d.statusError(resp.HTTPResponse, requestId)
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.Int64("total", resp.JSON200.TotalClicks))
 
-	unmarsheledBody := &models.RedirectResponseModel{}
+	return resp.JSON200, nil
+}
+
+func (d *databaseService) HandleRecordClicks(ctx context.Context, body io.Reader, requestId string) error {
+	d.logger.Infow("Sending record clicks request to database service", zap.String("Request Id", requestId))
 
-	err = json.Unmarshal(httpBody, unmarsheledBody)
+	resp, err := d.client.RecordClicksWithBodyWithResponse(ctx, "application/json", body, api.WithRequestId(requestId))
 
 	if err != nil {
-		d.logger.Errorw("Error unmarshalling response body at database service", zap.String("Request Id", requestId), zap.Error(err))
-		return nil, err
+		d.logger.Errorw("Error sending request to database service", zap.String("Request Id", requestId), zap.Error(err))
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return d.statusError(resp.HTTPResponse, requestId)
 	}
 
-	d.logger.Infow("Successfully unmarshalled response body at database service", zap.String("Request Id", requestId), zap.Any("request", unmarsheledBody))
+	return nil
+}
+
+// HandleReady checks that the database service can serve requests.
+func (d *databaseService) HandleReady(ctx context.Context) error {
+	resp, err := d.probe.CheckReadinessWithResponse(ctx)
+
+	if err != nil {
+		d.logger.Errorw("Error sending request to database service", zap.Error(err))
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		d.logger.Errorw("Database service is not ready", zap.String("status", resp.Status()))
+		return errors.New("database service is not ready")
+	}
+
+	return nil
+}
+
+// statusError turns an unexpected response into an error. A 404, 400, 409 or
+// 410 is returned as an error carrying the status text, anything else as a
+// generic failure.
+func (d *databaseService) statusError(resp *http.Response, requestId string) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusBadRequest, http.StatusConflict, http.StatusGone:
+		d.logger.Errorw("Request rejected by database service", zap.String("Request Id", requestId), zap.String("status", resp.Status))
+		return errors.New(http.StatusText(resp.StatusCode))
+	}
 
-	return unmarsheledBody, nil
+	d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.String("status", resp.Status))
+	return errors.New("request failed at database service")
 }
@@ -0,0 +1,126 @@
+package databaseservice
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"main-server/internal/config"
+	"main-server/internal/models"
+	"net/http"
+
+	"url-shortner-api/health"
+	"url-shortner-api/pb"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcDatabaseService sends shorten and redirect requests to the database
+// service over gRPC. It is selected with SERVICE_TRANSPORT=grpc; the link
+// management requests, which have no gRPC counterpart, still go over HTTP.
+type grpcDatabaseService struct {
+	*databaseService
+	client       pb.DatabaseServiceClient
+	healthClient grpc_health_v1.HealthClient
+}
+
+func NewGrpcDatabaseService(config *config.Config, clientTLS *tls.Config, logger *zap.SugaredLogger, conn grpc.ClientConnInterface) (*grpcDatabaseService, error) {
+	databaseService, err := NewDatabaseService(config, clientTLS, logger)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcDatabaseService{
+		databaseService: databaseService,
+		client:          pb.NewDatabaseServiceClient(conn),
+		healthClient:    grpc_health_v1.NewHealthClient(conn),
+	}, nil
+}
+
+func (d *grpcDatabaseService) HandleShorten(ctx context.Context, body io.Reader, requestId string) (*models.ShortenResponseModel, error) {
+	d.logger.Infow("Sending shorten rpc to database service", zap.String("Request Id", requestId))
+
+	request := &models.ShortenRequestModel{}
+
+	if err := json.NewDecoder(body).Decode(request); err != nil {
+		d.logger.Errorw("Error decoding shorten request", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, err
+	}
+
+	shortenRequest := &pb.ShortenRequest{
+		Url:   request.Url,
+		Title: request.Title,
+		Note:  request.Note,
+		Tags:  request.Tags,
+		Owner: request.Owner,
+	}
+
+	if !request.ExpiresAt.IsZero() {
+		shortenRequest.ExpiresAt = timestamppb.New(request.ExpiresAt)
+	}
+
+	resp, err := d.client.Shorten(pb.WithRequestId(ctx, requestId), shortenRequest)
+
+	if err != nil {
+		return nil, d.rpcError(err, requestId)
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("shorturlpath", resp.GetShortUrlPath()))
+
+	return &models.ShortenResponseModel{ShortUrlPath: resp.GetShortUrlPath()}, nil
+}
+
+func (d *grpcDatabaseService) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
+	d.logger.Infow("Sending resolve rpc to database service", zap.String("Request Id", requestId))
+
+	request := &models.RedirectRequestModel{}
+
+	if err := json.NewDecoder(body).Decode(request); err != nil {
+		d.logger.Errorw("Error decoding redirect request", zap.String("Request Id", requestId), zap.Error(err))
+		return nil, err
+	}
+
+	resp, err := d.client.Resolve(pb.WithRequestId(ctx, requestId), &pb.ResolveRequest{ShortUrlPath: request.ShortUrlPath})
+
+	if err != nil {
+		return nil, d.rpcError(err, requestId)
+	}
+
+	d.logger.Infow("Request successful", zap.String("Request Id", requestId), zap.String("url", resp.GetUrl()))
+
+	return &models.RedirectResponseModel{Url: resp.GetUrl()}, nil
+}
+
+// HandleReady checks both transports, as the link management requests still
+// go over HTTP.
+func (d *grpcDatabaseService) HandleReady(ctx context.Context) error {
+	if err := health.CheckGrpc(ctx, d.healthClient); err != nil {
+		d.logger.Errorw("Database service is not ready", zap.Error(err))
+		return errors.New("database service is not ready")
+	}
+
+	return d.databaseService.HandleReady(ctx)
+}
+
+// rpcError maps a failed call to the same errors the HTTP transport returns.
+func (d *grpcDatabaseService) rpcError(err error, requestId string) error {
+	d.logger.Errorw("Request failed at database service", zap.String("Request Id", requestId), zap.Error(err))
+
+	switch status.Code(err) {
+	case codes.NotFound:
+		return errors.New(http.StatusText(http.StatusNotFound))
+	case codes.InvalidArgument:
+		return errors.New(http.StatusText(http.StatusBadRequest))
+	case pb.CodeGone:
+		return errors.New(http.StatusText(http.StatusGone))
+	default:
+		return errors.New("request failed at database service")
+	}
+}
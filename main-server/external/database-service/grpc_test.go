@@ -0,0 +1,223 @@
+package databaseservice_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	databaseservice "main-server/external/database-service"
+	"main-server/internal/config"
+	"main-server/internal/models"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"url-shortner-api/health"
+	"url-shortner-api/pb"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeDatabaseServer struct {
+	pb.UnimplementedDatabaseServiceServer
+	requestId string
+	shorten   *pb.ShortenRequest
+	err       error
+	unready   error
+}
+
+func (f *fakeDatabaseServer) Shorten(ctx context.Context, req *pb.ShortenRequest) (*pb.ShortenResponse, error) {
+	f.requestId = pb.RequestId(ctx)
+	f.shorten = req
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &pb.ShortenResponse{ShortUrlPath: "abc1234"}, nil
+}
+
+func (f *fakeDatabaseServer) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.ResolveResponse, error) {
+	f.requestId = pb.RequestId(ctx)
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &pb.ResolveResponse{Url: "https://google.com/" + req.GetShortUrlPath()}, nil
+}
+
+func newGrpcDatabaseService(t *testing.T, server *fakeDatabaseServer) databaseservice.DatabaseServiceInterface {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterDatabaseServiceServer(grpcServer, server)
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewGrpcServer(health.Checks{
+		"mongo": func(ctx context.Context) error { return server.unready },
+	}))
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	// The HTTP side of the database service is always ready.
+	httpServer := httptest.NewServer(http.HandlerFunc(health.Live))
+	t.Cleanup(httpServer.Close)
+
+	cfg := &config.Config{DatabaseServiceBaseUrl: httpServer.URL}
+
+	databaseService, err := databaseservice.NewGrpcDatabaseService(cfg, nil, zap.NewNop().Sugar(), conn)
+	assert.NoError(t, err)
+
+	return databaseService
+}
+
+func TestGrpcHandleShorten(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := map[string]struct {
+		server            *fakeDatabaseServer
+		requestBody       *models.ShortenRequestModel
+		ExpectedError     string
+		ExpectedExpiresAt *time.Time
+	}{
+		"Invalid Argument": {
+			server:        &fakeDatabaseServer{err: status.Error(codes.InvalidArgument, "empty url")},
+			requestBody:   &models.ShortenRequestModel{},
+			ExpectedError: http.StatusText(http.StatusBadRequest),
+		},
+		"Database Service Fail": {
+			server:        &fakeDatabaseServer{err: status.Error(codes.Internal, "boom")},
+			requestBody:   &models.ShortenRequestModel{Url: "https://google.com"},
+			ExpectedError: "request failed at database service",
+		},
+		"Default Expiry": {
+			server:      &fakeDatabaseServer{},
+			requestBody: &models.ShortenRequestModel{Url: "https://google.com", Tags: []string{"a"}},
+		},
+		"Success": {
+			server:            &fakeDatabaseServer{},
+			requestBody:       &models.ShortenRequestModel{Url: "https://google.com", ExpiresAt: expiresAt, Title: "Search", Tags: []string{"a"}, Owner: "alice"},
+			ExpectedExpiresAt: &expiresAt,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			databaseService := newGrpcDatabaseService(t, test.server)
+
+			body, err := json.Marshal(test.requestBody)
+			assert.NoError(t, err)
+
+			resp, err := databaseService.HandleShorten(context.Background(), bytes.NewBuffer(body), "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "abc1234", resp.ShortUrlPath)
+			assert.Equal(t, "abc", test.server.requestId)
+			assert.Equal(t, test.requestBody.Url, test.server.shorten.GetUrl())
+			assert.Equal(t, test.requestBody.Title, test.server.shorten.GetTitle())
+			assert.Equal(t, test.requestBody.Tags, test.server.shorten.GetTags())
+			assert.Equal(t, test.requestBody.Owner, test.server.shorten.GetOwner())
+
+			if test.ExpectedExpiresAt == nil {
+				assert.Nil(t, test.server.shorten.GetExpiresAt())
+			} else {
+				assert.Equal(t, *test.ExpectedExpiresAt, test.server.shorten.GetExpiresAt().AsTime())
+			}
+		})
+	}
+}
+
+func TestGrpcHandleRedirect(t *testing.T) {
+	tests := map[string]struct {
+		server        *fakeDatabaseServer
+		ExpectedError string
+		ExpectedUrl   string
+	}{
+		"Not Found": {
+			server:        &fakeDatabaseServer{err: status.Error(codes.NotFound, "not found")},
+			ExpectedError: http.StatusText(http.StatusNotFound),
+		},
+		"Deleted": {
+			server:        &fakeDatabaseServer{err: status.Error(pb.CodeGone, "link was deleted")},
+			ExpectedError: http.StatusText(http.StatusGone),
+		},
+		"Database Service Fail": {
+			server:        &fakeDatabaseServer{err: status.Error(codes.Unavailable, "down")},
+			ExpectedError: "request failed at database service",
+		},
+		"Success": {
+			server:      &fakeDatabaseServer{},
+			ExpectedUrl: "https://google.com/abc1234",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			databaseService := newGrpcDatabaseService(t, test.server)
+
+			resp, err := databaseService.HandleRedirect(context.Background(), bytes.NewBufferString(`{"shorturlpath":"abc1234"}`), "abc")
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.ExpectedUrl, resp.Url)
+			assert.Equal(t, "abc", test.server.requestId)
+		})
+	}
+}
+
+func TestGrpcHandleReady(t *testing.T) {
+	tests := map[string]struct {
+		server        *fakeDatabaseServer
+		ExpectedError string
+	}{
+		"Not Serving": {
+			server:        &fakeDatabaseServer{unready: errors.New("server selection timeout")},
+			ExpectedError: "database service is not ready",
+		},
+		"Serving": {
+			server: &fakeDatabaseServer{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			databaseService := newGrpcDatabaseService(t, test.server)
+
+			err := databaseService.HandleReady(context.Background())
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
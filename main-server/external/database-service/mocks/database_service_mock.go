@@ -1,10 +1,11 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: D:\Programming\Go\url-shortner\main-server\database-service\database-service.go
+// Source: external/database-service/database_service.go
 
 // Package mock_databaseservice is a generated GoMock package.
 package mock_databaseservice
 
 import (
+	context "context"
 	io "io"
 	models "main-server/internal/models"
 	reflect "reflect"
@@ -35,32 +36,179 @@ func (m *MockDatabaseServiceInterface) EXPECT() *MockDatabaseServiceInterfaceMoc
 	return m.recorder
 }
 
+// HandleDeleteLink mocks base method.
+func (m *MockDatabaseServiceInterface) HandleDeleteLink(ctx context.Context, shortUrlPath, requestId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDeleteLink", ctx, shortUrlPath, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleDeleteLink indicates an expected call of HandleDeleteLink.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleDeleteLink(ctx, shortUrlPath, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeleteLink", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleDeleteLink), ctx, shortUrlPath, requestId)
+}
+
+// HandleExportLinks mocks base method.
+func (m *MockDatabaseServiceInterface) HandleExportLinks(ctx context.Context, body io.Reader, requestId string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleExportLinks", ctx, body, requestId)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleExportLinks indicates an expected call of HandleExportLinks.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleExportLinks(ctx, body, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleExportLinks", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleExportLinks), ctx, body, requestId)
+}
+
+// HandleGetLink mocks base method.
+func (m *MockDatabaseServiceInterface) HandleGetLink(ctx context.Context, shortUrlPath, requestId string) (*models.LinkModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleGetLink", ctx, shortUrlPath, requestId)
+	ret0, _ := ret[0].(*models.LinkModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleGetLink indicates an expected call of HandleGetLink.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleGetLink(ctx, shortUrlPath, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleGetLink", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleGetLink), ctx, shortUrlPath, requestId)
+}
+
+// HandleImportLinks mocks base method.
+func (m *MockDatabaseServiceInterface) HandleImportLinks(ctx context.Context, body io.Reader, requestId string) (*models.ImportResponseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleImportLinks", ctx, body, requestId)
+	ret0, _ := ret[0].(*models.ImportResponseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleImportLinks indicates an expected call of HandleImportLinks.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleImportLinks(ctx, body, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleImportLinks", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleImportLinks), ctx, body, requestId)
+}
+
+// HandleLinkStats mocks base method.
+func (m *MockDatabaseServiceInterface) HandleLinkStats(ctx context.Context, shortUrlPath string, days int, requestId string) (*models.StatsResponseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleLinkStats", ctx, shortUrlPath, days, requestId)
+	ret0, _ := ret[0].(*models.StatsResponseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleLinkStats indicates an expected call of HandleLinkStats.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleLinkStats(ctx, shortUrlPath, days, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLinkStats", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleLinkStats), ctx, shortUrlPath, days, requestId)
+}
+
+// HandleListLinks mocks base method.
+func (m *MockDatabaseServiceInterface) HandleListLinks(ctx context.Context, body io.Reader, requestId string) (*models.ListResponseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleListLinks", ctx, body, requestId)
+	ret0, _ := ret[0].(*models.ListResponseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleListLinks indicates an expected call of HandleListLinks.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleListLinks(ctx, body, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleListLinks", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleListLinks), ctx, body, requestId)
+}
+
+// HandleReady mocks base method.
+func (m *MockDatabaseServiceInterface) HandleReady(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleReady", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleReady indicates an expected call of HandleReady.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleReady(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleReady", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleReady), ctx)
+}
+
+// HandleRecordClicks mocks base method.
+func (m *MockDatabaseServiceInterface) HandleRecordClicks(ctx context.Context, body io.Reader, requestId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRecordClicks", ctx, body, requestId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleRecordClicks indicates an expected call of HandleRecordClicks.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleRecordClicks(ctx, body, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRecordClicks", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleRecordClicks), ctx, body, requestId)
+}
+
 // HandleRedirect mocks base method.
-func (m *MockDatabaseServiceInterface) HandleRedirect(body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
+func (m *MockDatabaseServiceInterface) HandleRedirect(ctx context.Context, body io.Reader, requestId string) (*models.RedirectResponseModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleRedirect", body, requestId)
+	ret := m.ctrl.Call(m, "HandleRedirect", ctx, body, requestId)
 	ret0, _ := ret[0].(*models.RedirectResponseModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HandleRedirect indicates an expected call of HandleRedirect.
-func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleRedirect(body, requestId interface{}) *gomock.Call {
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleRedirect(ctx, body, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRedirect", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleRedirect), ctx, body, requestId)
+}
+
+// HandleRestoreLink mocks base method.
+func (m *MockDatabaseServiceInterface) HandleRestoreLink(ctx context.Context, shortUrlPath, requestId string) (*models.LinkModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRestoreLink", ctx, shortUrlPath, requestId)
+	ret0, _ := ret[0].(*models.LinkModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleRestoreLink indicates an expected call of HandleRestoreLink.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleRestoreLink(ctx, shortUrlPath, requestId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRedirect", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleRedirect), body, requestId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRestoreLink", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleRestoreLink), ctx, shortUrlPath, requestId)
 }
 
 // HandleShorten mocks base method.
-func (m *MockDatabaseServiceInterface) HandleShorten(body io.Reader, requestId string) (*models.ShortenResponseModel, error) {
+func (m *MockDatabaseServiceInterface) HandleShorten(ctx context.Context, body io.Reader, requestId string) (*models.ShortenResponseModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleShorten", body, requestId)
+	ret := m.ctrl.Call(m, "HandleShorten", ctx, body, requestId)
 	ret0, _ := ret[0].(*models.ShortenResponseModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HandleShorten indicates an expected call of HandleShorten.
-func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleShorten(body, requestId interface{}) *gomock.Call {
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleShorten(ctx, body, requestId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleShorten", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleShorten), ctx, body, requestId)
+}
+
+// HandleUpdateLink mocks base method.
+func (m *MockDatabaseServiceInterface) HandleUpdateLink(ctx context.Context, shortUrlPath string, body io.Reader, requestId string) (*models.LinkModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleUpdateLink", ctx, shortUrlPath, body, requestId)
+	ret0, _ := ret[0].(*models.LinkModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleUpdateLink indicates an expected call of HandleUpdateLink.
+func (mr *MockDatabaseServiceInterfaceMockRecorder) HandleUpdateLink(ctx, shortUrlPath, body, requestId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleShorten", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleShorten), body, requestId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdateLink", reflect.TypeOf((*MockDatabaseServiceInterface)(nil).HandleUpdateLink), ctx, shortUrlPath, body, requestId)
 }
@@ -0,0 +1,116 @@
+package apikeys
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type StoreInterface interface {
+	Verify(key string) (string, bool)
+	Has(name string) bool
+}
+
+// store holds the SHA-256 hashes of the API keys, so the plain keys never
+// appear in the config files.
+type store struct {
+	mu     sync.RWMutex
+	hashes map[string][]byte
+}
+
+// NewStore parses API_KEYS, a comma separated list of name:sha256hex entries,
+// e.g. "alice:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae".
+func NewStore(value string) (*store, error) {
+	s := &store{}
+
+	if err := s.Replace(value); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Replace swaps the keys for the ones in a new API_KEYS value. The current
+// keys are kept when the value is invalid.
+func (s *store) Replace(value string) error {
+	hashes, err := parse(value)
+
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hashes = hashes
+
+	return nil
+}
+
+// Verify returns the name of the key matching the given plain key.
+func (s *store) Verify(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+
+	hash := sha256.Sum256([]byte(key))
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for name, expected := range s.hashes {
+		if subtle.ConstantTimeCompare(hash[:], expected) == 1 {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// Has tells whether a key with the given name is configured, e.g. to end the
+// sessions of a key removed from API_KEYS.
+func (s *store) Has(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.hashes[name]
+
+	return ok
+}
+
+func parse(value string) (map[string][]byte, error) {
+	hashes := map[string][]byte{}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+
+		if entry == "" {
+			continue
+		}
+
+		name, hash, ok := strings.Cut(entry, ":")
+
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid api key entry %q, expected name:sha256", entry)
+		}
+
+		decoded, err := hex.DecodeString(hash)
+
+		if err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("invalid api key hash for %q", name)
+		}
+
+		hashes[name] = decoded
+	}
+
+	return hashes, nil
+}
+
+// Hash returns the value to put in API_KEYS for a plain key.
+func Hash(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
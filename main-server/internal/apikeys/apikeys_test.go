@@ -0,0 +1,75 @@
+package apikeys_test
+
+import (
+	"main-server/internal/apikeys"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStore(t *testing.T) {
+	tests := map[string]struct {
+		value       string
+		expectError bool
+	}{
+		"Empty":        {value: ""},
+		"Valid":        {value: "alice:" + apikeys.Hash("secret") + ", bob:" + apikeys.Hash("other")},
+		"Missing Name": {value: ":" + apikeys.Hash("secret"), expectError: true},
+		"Missing Hash": {value: "alice", expectError: true},
+		"Invalid Hash": {value: "alice:abc", expectError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := apikeys.NewStore(test.value)
+			assert.Equal(t, test.expectError, err != nil, err)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	store, err := apikeys.NewStore("alice:" + apikeys.Hash("secret") + ",bob:" + apikeys.Hash("other"))
+	assert.Nil(t, err)
+
+	tests := map[string]struct {
+		key          string
+		expectedName string
+		expectedOk   bool
+	}{
+		"Alice":   {key: "secret", expectedName: "alice", expectedOk: true},
+		"Bob":     {key: "other", expectedName: "bob", expectedOk: true},
+		"Unknown": {key: "nope"},
+		"Empty":   {key: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualName, ok := store.Verify(test.key)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedName, actualName)
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	store, err := apikeys.NewStore("alice:" + apikeys.Hash("secret"))
+	assert.Nil(t, err)
+
+	assert.Nil(t, store.Replace("bob:"+apikeys.Hash("other")))
+
+	_, ok := store.Verify("secret")
+	assert.False(t, ok, "the replaced key is rejected")
+
+	name, ok := store.Verify("other")
+	assert.True(t, ok)
+	assert.Equal(t, "bob", name)
+
+	assert.False(t, store.Has("alice"))
+	assert.True(t, store.Has("bob"))
+
+	assert.Error(t, store.Replace("carol:abc"))
+
+	_, ok = store.Verify("other")
+	assert.True(t, ok, "the keys are kept when the new value is invalid")
+	assert.False(t, store.Has("carol"))
+}
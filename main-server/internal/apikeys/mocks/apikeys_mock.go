@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/apikeys/apikeys.go
+
+// Package mock_apikeys is a generated GoMock package.
+package mock_apikeys
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStoreInterface is a mock of StoreInterface interface.
+type MockStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreInterfaceMockRecorder
+}
+
+// MockStoreInterfaceMockRecorder is the mock recorder for MockStoreInterface.
+type MockStoreInterfaceMockRecorder struct {
+	mock *MockStoreInterface
+}
+
+// NewMockStoreInterface creates a new mock instance.
+func NewMockStoreInterface(ctrl *gomock.Controller) *MockStoreInterface {
+	mock := &MockStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreInterface) EXPECT() *MockStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Has mocks base method.
+func (m *MockStoreInterface) Has(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Has", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Has indicates an expected call of Has.
+func (mr *MockStoreInterfaceMockRecorder) Has(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Has", reflect.TypeOf((*MockStoreInterface)(nil).Has), name)
+}
+
+// Verify mocks base method.
+func (m *MockStoreInterface) Verify(key string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockStoreInterfaceMockRecorder) Verify(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockStoreInterface)(nil).Verify), key)
+}
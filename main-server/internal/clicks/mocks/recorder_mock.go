@@ -0,0 +1,46 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/clicks/recorder.go
+
+// Package mock_clicks is a generated GoMock package.
+package mock_clicks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRecorderInterface is a mock of RecorderInterface interface.
+type MockRecorderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecorderInterfaceMockRecorder
+}
+
+// MockRecorderInterfaceMockRecorder is the mock recorder for MockRecorderInterface.
+type MockRecorderInterfaceMockRecorder struct {
+	mock *MockRecorderInterface
+}
+
+// NewMockRecorderInterface creates a new mock instance.
+func NewMockRecorderInterface(ctrl *gomock.Controller) *MockRecorderInterface {
+	mock := &MockRecorderInterface{ctrl: ctrl}
+	mock.recorder = &MockRecorderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecorderInterface) EXPECT() *MockRecorderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockRecorderInterface) Record(shortUrlPath string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Record", shortUrlPath)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockRecorderInterfaceMockRecorder) Record(shortUrlPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockRecorderInterface)(nil).Record), shortUrlPath)
+}
@@ -0,0 +1,91 @@
+package clicks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	databaseservice "main-server/external/database-service"
+	"main-server/internal/models"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type RecorderInterface interface {
+	Record(shortUrlPath string)
+}
+
+// recorder counts redirects in memory and periodically sends the totals to the
+// database service, so a redirect never waits on a write.
+type recorder struct {
+	databaseservice databaseservice.DatabaseServiceInterface
+	logger          *zap.SugaredLogger
+	mu              sync.Mutex
+	counts          map[string]int64
+}
+
+func NewRecorder(databaseservice databaseservice.DatabaseServiceInterface, logger *zap.SugaredLogger) *recorder {
+	return &recorder{
+		databaseservice: databaseservice,
+		logger:          logger,
+		counts:          map[string]int64{},
+	}
+}
+
+func (r *recorder) Record(shortUrlPath string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counts[shortUrlPath]++
+}
+
+// Run flushes the recorded clicks every interval until ctx is done. The
+// clicks recorded after the last tick are left for a final Flush.
+func (r *recorder) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Flush()
+		}
+	}
+}
+
+// Flush sends the clicks recorded since the last flush. When the database
+// service cannot be reached the counts are kept for the next flush.
+func (r *recorder) Flush() {
+	r.mu.Lock()
+	counts := r.counts
+	r.counts = map[string]int64{}
+	r.mu.Unlock()
+
+	if len(counts) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(&models.RecordClicksRequestModel{Clicks: counts})
+
+	if err != nil {
+		r.logger.Errorw("Error marshalling clicks", zap.Error(err))
+		return
+	}
+
+	if err := r.databaseservice.HandleRecordClicks(context.Background(), bytes.NewBuffer(body), ""); err != nil {
+		r.logger.Errorw("Error recording clicks", zap.Int("links", len(counts)), zap.Error(err))
+
+		r.mu.Lock()
+		for shortUrlPath, count := range counts {
+			r.counts[shortUrlPath] += count
+		}
+		r.mu.Unlock()
+
+		return
+	}
+
+	r.logger.Infow("Recorded clicks", zap.Int("links", len(counts)))
+}
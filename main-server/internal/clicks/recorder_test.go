@@ -0,0 +1,81 @@
+package clicks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	mock_databaseservice "main-server/external/database-service/mocks"
+	"main-server/internal/models"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRecorderFlush(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	mockCtrl := gomock.NewController(t)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+
+	recorder := NewRecorder(mockDbService, logger)
+
+	sent := []map[string]int64{}
+	capture := func(ctx context.Context, body io.Reader, requestId string) error {
+		request := models.RecordClicksRequestModel{}
+		assert.Nil(t, json.NewDecoder(body).Decode(&request))
+		sent = append(sent, request.Clicks)
+		return nil
+	}
+
+	gomock.InOrder(
+		mockDbService.EXPECT().HandleRecordClicks(gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError),
+		mockDbService.EXPECT().HandleRecordClicks(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(capture),
+	)
+
+	recorder.Flush()
+
+	recorder.Record("abc")
+	recorder.Record("abc")
+	recorder.Record("def")
+	recorder.Flush()
+
+	recorder.Record("abc")
+	recorder.Flush()
+
+	assert.Equal(t, []map[string]int64{{"abc": 3, "def": 1}}, sent)
+	assert.Empty(t, recorder.counts)
+}
+
+func TestRecorderRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+	mockDbService.EXPECT().HandleRecordClicks(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MinTimes(1)
+
+	recorder := NewRecorder(mockDbService, zap.NewNop().Sugar())
+	recorder.Record("abc")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		recorder.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		return len(recorder.counts) == 0
+	}, time.Second, time.Millisecond)
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
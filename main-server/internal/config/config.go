@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"url-shortner-api/settings"
+	"url-shortner-api/tlsconfig"
+)
+
+// Config is the configuration of the main service, read from config/app.env,
+// the environment and command line flags.
+type Config struct {
+	ListenAddr              string        `config:"LISTEN_ADDR" default:":8080"`
+	TlsCertFile             string        `config:"TLS_CERT_FILE"`
+	TlsKeyFile              string        `config:"TLS_KEY_FILE"`
+	TlsCaFile               string        `config:"TLS_CA_FILE"`
+	BaseUrl                 string        `config:"BASE_URL" default:"http://localhost:8080"`
+	DatabaseServiceBaseUrl  string        `config:"DATABASE_SERVICE_BASE_URL" default:"http://localhost:8081"`
+	CacheServiceBaseUrl     string        `config:"CACHE_SERVICE_BASE_URL" default:"http://localhost:8082"`
+	KafkaBrokers            []string      `config:"KAFKA_SERVICE_BASE_URL" default:"localhost:29092"`
+	ApiKeys                 string        `config:"API_KEYS" secret:"true" reload:"true"`
+	SessionSecret           string        `config:"SESSION_SECRET" secret:"true"`
+	ServiceTransport        string        `config:"SERVICE_TRANSPORT" default:"http"`
+	DatabaseServiceGrpcAddr string        `config:"DATABASE_SERVICE_GRPC_ADDR" default:"localhost:9081"`
+	CacheServiceGrpcAddr    string        `config:"CACHE_SERVICE_GRPC_ADDR" default:"localhost:9082"`
+	LocalCacheSize          int           `config:"LOCAL_CACHE_SIZE" default:"10000"`
+	LocalCacheTtl           time.Duration `config:"LOCAL_CACHE_TTL" default:"10s"`
+	LocalCacheNegativeTtl   time.Duration `config:"LOCAL_CACHE_NEGATIVE_TTL" default:"2s"`
+	RequestTimeout          time.Duration `config:"REQUEST_TIMEOUT" default:"10s" reload:"true"`
+	RouteTimeouts           string        `config:"ROUTE_TIMEOUTS" reload:"true"`
+	OtlpEndpoint            string        `config:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	DeletedPageFile         string        `config:"DELETED_PAGE_FILE"`
+}
+
+// Load reads and validates the config. args are the command line arguments
+// without the program name.
+func Load(args []string) (*Config, *settings.Source, error) {
+	config := &Config{}
+	source, err := settings.Load(config, args)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, source, nil
+}
+
+func (config *Config) Validate() error {
+	for key, value := range map[string]string{
+		"BASE_URL":                  config.BaseUrl,
+		"DATABASE_SERVICE_BASE_URL": config.DatabaseServiceBaseUrl,
+		"CACHE_SERVICE_BASE_URL":    config.CacheServiceBaseUrl,
+	} {
+		if parsed, err := url.Parse(value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("%s must be an absolute url, got %q", key, value)
+		}
+	}
+
+	if config.ServiceTransport != "http" && config.ServiceTransport != "grpc" {
+		return fmt.Errorf("SERVICE_TRANSPORT must be http or grpc, got %q", config.ServiceTransport)
+	}
+
+	if config.LocalCacheSize < 0 || config.LocalCacheTtl < 0 || config.LocalCacheNegativeTtl < 0 {
+		return errors.New("LOCAL_CACHE_SIZE, LOCAL_CACHE_TTL and LOCAL_CACHE_NEGATIVE_TTL must not be negative")
+	}
+
+	return config.TlsFiles().Validate()
+}
+
+// DeletedPage reads the HTML page redirects of deleted links are answered
+// with, or returns nil when DELETED_PAGE_FILE is not set.
+func (config *Config) DeletedPage() ([]byte, error) {
+	if config.DeletedPageFile == "" {
+		return nil, nil
+	}
+
+	return os.ReadFile(config.DeletedPageFile)
+}
+
+// TlsFiles are the certificate of the service and the CA of the database and
+// cache services.
+func (config *Config) TlsFiles() tlsconfig.Files {
+	return tlsconfig.Files{CertFile: config.TlsCertFile, KeyFile: config.TlsKeyFile, CAFile: config.TlsCaFile}
+}
@@ -2,39 +2,71 @@ package config_test
 
 import (
 	"main-server/internal/config"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"go.uber.org/zap"
 )
 
-func TestGet(t *testing.T) {
-	mockLogger := zap.NewNop().Sugar()
-	config, err := config.NewConfig(mockLogger)
-
-	if err != nil {
-		t.Fatalf("Error creating config: %v", err)
-	}
-
-	var tests = map[string]struct {
-		name     string
-		key      string
-		expected string
+func TestLoad(t *testing.T) {
+	tests := map[string]struct {
+		args          []string
+		ExpectedError string
 	}{
-		"NON_EXISTENT_KEY": {
-			key:      "ABC",
-			expected: "",
+		"Flags": {
+			args: []string{"--base-url", "https://sho.rt", "--service-transport", "grpc"},
+		},
+		"Invalid Base Url": {
+			args:          []string{"--base-url", "sho.rt"},
+			ExpectedError: `BASE_URL must be an absolute url, got "sho.rt"`,
+		},
+		"Invalid Transport": {
+			args:          []string{"--service-transport", "udp"},
+			ExpectedError: `SERVICE_TRANSPORT must be http or grpc, got "udp"`,
+		},
+		"Negative Local Cache Size": {
+			args:          []string{"--local-cache-size", "-1"},
+			ExpectedError: "LOCAL_CACHE_SIZE, LOCAL_CACHE_TTL and LOCAL_CACHE_NEGATIVE_TTL must not be negative",
+		},
+		"Certificate Without Key": {
+			args:          []string{"--tls-cert-file", "tls.crt"},
+			ExpectedError: "TLS_CERT_FILE and TLS_KEY_FILE must be set together",
 		},
-		"EXISTENT_KEY": {
-			key:      "BASE_URL",
-			expected: "http://localhost:8080",
+		"Invalid Timeout": {
+			args:          []string{"--request-timeout", "soon"},
+			ExpectedError: `REQUEST_TIMEOUT: time: invalid duration "soon"`,
 		},
 	}
 
-	for tc, test := range tests {
-		t.Run(tc, func(t *testing.T) {
-			actual := config.Get(test.key)
-			assert.Equal(t, test.expected, actual, "Expected and actual values do not match")
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			loaded, _, err := config.Load(test.args)
+
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, "https://sho.rt", loaded.BaseUrl)
+			assert.Equal(t, "grpc", loaded.ServiceTransport)
 		})
 	}
 }
+
+func TestDeletedPage(t *testing.T) {
+	page, err := (&config.Config{}).DeletedPage()
+	assert.Nil(t, err)
+	assert.Nil(t, page)
+
+	file := filepath.Join(t.TempDir(), "deleted.html")
+	assert.Nil(t, os.WriteFile(file, []byte("<h1>Deleted</h1>"), 0o644))
+
+	page, err = (&config.Config{DeletedPageFile: file}).DeletedPage()
+	assert.Nil(t, err)
+	assert.Equal(t, "<h1>Deleted</h1>", string(page))
+
+	_, err = (&config.Config{DeletedPageFile: file + ".missing"}).DeletedPage()
+	assert.NotNil(t, err)
+}
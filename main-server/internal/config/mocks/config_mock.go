@@ -1,48 +0,0 @@
-// Code generated by MockGen. DO NOT EDIT.
-// Source: D:\Programming\Go\url-shortner\main-server\internal\config\viper.go
-
-// Package mock_config is a generated GoMock package.
-package mock_config
-
-import (
-	reflect "reflect"
-
-	gomock "github.com/golang/mock/gomock"
-)
-
-// MockConfigInterface is a mock of ConfigInterface interface.
-type MockConfigInterface struct {
-	ctrl     *gomock.Controller
-	recorder *MockConfigInterfaceMockRecorder
-}
-
-// MockConfigInterfaceMockRecorder is the mock recorder for MockConfigInterface.
-type MockConfigInterfaceMockRecorder struct {
-	mock *MockConfigInterface
-}
-
-// NewMockConfigInterface creates a new mock instance.
-func NewMockConfigInterface(ctrl *gomock.Controller) *MockConfigInterface {
-	mock := &MockConfigInterface{ctrl: ctrl}
-	mock.recorder = &MockConfigInterfaceMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockConfigInterface) EXPECT() *MockConfigInterfaceMockRecorder {
-	return m.recorder
-}
-
-// Get mocks base method.
-func (m *MockConfigInterface) Get(key string) string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", key)
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
-// Get indicates an expected call of Get.
-func (mr *MockConfigInterfaceMockRecorder) Get(key interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockConfigInterface)(nil).Get), key)
-}
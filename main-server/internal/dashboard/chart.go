@@ -0,0 +1,61 @@
+package dashboard
+
+import (
+	"main-server/internal/models"
+)
+
+const (
+	chartHeight   = 120
+	chartBarWidth = 14
+	chartBarGap   = 4
+)
+
+type chartBar struct {
+	X      int
+	Y      int
+	Height int
+	Label  string
+	Count  int64
+}
+
+type chart struct {
+	Width  int
+	Height int
+	Bars   []chartBar
+	Max    int64
+}
+
+// newChart lays out the daily clicks as SVG bars scaled to the busiest day.
+func newChart(daily []models.DailyClicksModel) chart {
+	c := chart{
+		Width:  len(daily) * (chartBarWidth + chartBarGap),
+		Height: chartHeight,
+	}
+
+	for _, day := range daily {
+		if day.Count > c.Max {
+			c.Max = day.Count
+		}
+	}
+
+	for i, day := range daily {
+		height := 0
+		if c.Max > 0 {
+			height = int(day.Count * chartHeight / c.Max)
+		}
+
+		if day.Count > 0 && height == 0 {
+			height = 1
+		}
+
+		c.Bars = append(c.Bars, chartBar{
+			X:      i * (chartBarWidth + chartBarGap),
+			Y:      chartHeight - height,
+			Height: height,
+			Label:  day.Day.Format("2006-01-02"),
+			Count:  day.Count,
+		})
+	}
+
+	return c
+}
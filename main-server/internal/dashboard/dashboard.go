@@ -0,0 +1,492 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"embed"
+	"encoding/json"
+	"html/template"
+	"io/fs"
+	cacheservice "main-server/external/cache-service"
+	databaseservice "main-server/external/database-service"
+	"main-server/internal/apikeys"
+	"main-server/internal/config"
+	"main-server/internal/models"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	UrlVerifier "github.com/davidmytton/url-verifier"
+	"github.com/gorilla/mux"
+	qrcode "github.com/skip2/go-qrcode"
+	"go.uber.org/zap"
+)
+
+const (
+	// dateTimeLayout is the format of datetime-local inputs. Values are read
+	// and shown in UTC.
+	dateTimeLayout = "2006-01-02T15:04"
+	statsDays      = 30
+	pageSize       = 25
+	qrCodeSize     = 256
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+type DashboardInterface interface {
+	HandleLoginPage(w http.ResponseWriter, r *http.Request)
+	HandleLogin(w http.ResponseWriter, r *http.Request)
+	HandleLogout(w http.ResponseWriter, r *http.Request)
+	HandleLinks(w http.ResponseWriter, r *http.Request)
+	HandleNewLink(w http.ResponseWriter, r *http.Request)
+	HandleCreateLink(w http.ResponseWriter, r *http.Request)
+	HandleLink(w http.ResponseWriter, r *http.Request)
+	HandleUpdateLink(w http.ResponseWriter, r *http.Request)
+	HandleQRCode(w http.ResponseWriter, r *http.Request)
+	RequireSession(next http.HandlerFunc) http.HandlerFunc
+	StaticHandler() http.Handler
+}
+
+type dashboard struct {
+	logger          *zap.SugaredLogger
+	databaseservice databaseservice.DatabaseServiceInterface
+	cacheservice    cacheservice.CacheServiceInterface
+	config          *config.Config
+	keys            apikeys.StoreInterface
+	sessions        *sessions
+	templates       map[string]*template.Template
+}
+
+// NewDashboard parses the embedded templates. Sessions are signed with
+// SESSION_SECRET; without it a random secret is used and every restart logs
+// all users out.
+func NewDashboard(logger *zap.SugaredLogger, databaseservice databaseservice.DatabaseServiceInterface, cacheservice cacheservice.CacheServiceInterface, config *config.Config, keys apikeys.StoreInterface) (*dashboard, error) {
+	templates := map[string]*template.Template{}
+
+	functions := template.FuncMap{
+		"formatTime": formatTime,
+		"join":       strings.Join,
+	}
+
+	for _, name := range []string{"login.html", "links.html", "new.html", "link.html"} {
+		parsed, err := template.New(name).Funcs(functions).ParseFS(templateFS, "templates/layout.html", "templates/"+name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		templates[name] = parsed
+	}
+
+	secret := []byte(config.SessionSecret)
+
+	if len(secret) == 0 {
+		logger.Warnw("SESSION_SECRET is not set, dashboard sessions will not survive a restart")
+
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dashboard{
+		logger:          logger,
+		databaseservice: databaseservice,
+		cacheservice:    cacheservice,
+		config:          config,
+		keys:            keys,
+		sessions:        newSessions(secret),
+		templates:       templates,
+	}, nil
+}
+
+func (d *dashboard) StaticHandler() http.Handler {
+	static, _ := fs.Sub(staticFS, "static")
+	return http.StripPrefix("/dashboard/static/", http.FileServer(http.FS(static)))
+}
+
+// RequireSession redirects to the login page unless the request carries a
+// valid session cookie of a key that is still configured.
+func (d *dashboard) RequireSession(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := d.session(r); !ok {
+			http.Redirect(w, r, "/dashboard/login", http.StatusSeeOther)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// session returns the API key name of a valid session whose key is still
+// configured, so removing a key from API_KEYS also ends its sessions.
+func (d *dashboard) session(r *http.Request) (string, bool) {
+	name, ok := d.sessions.get(r)
+
+	if !ok || !d.keys.Has(name) {
+		return "", false
+	}
+
+	return name, true
+}
+
+func (d *dashboard) HandleLoginPage(w http.ResponseWriter, r *http.Request) {
+	if _, ok := d.session(r); ok {
+		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	d.render(w, r, "login.html", http.StatusOK, &page{})
+}
+
+func (d *dashboard) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	name, ok := d.keys.Verify(r.PostFormValue("key"))
+
+	if !ok {
+		d.logger.Infow("Dashboard login rejected", zap.String("Request Id", requestId))
+		d.render(w, r, "login.html", http.StatusUnauthorized, &page{Error: "Invalid API key"})
+		return
+	}
+
+	d.logger.Infow("Dashboard login", zap.String("Request Id", requestId), zap.String("user", name))
+
+	d.sessions.set(w, r, name)
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+func (d *dashboard) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	d.sessions.clear(w)
+	http.Redirect(w, r, "/dashboard/login", http.StatusSeeOther)
+}
+
+func (d *dashboard) HandleLinks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	query := r.URL.Query()
+
+	data := &linksPage{
+		Query: query.Get("q"),
+		Tag:   query.Get("tag"),
+	}
+
+	listRequestModelJson, err := json.Marshal(&models.ListRequestModel{
+		Tag:    data.Tag,
+		Query:  data.Query,
+		Cursor: query.Get("cursor"),
+		Limit:  pageSize,
+	})
+
+	if err != nil {
+		d.logger.Errorw("Error marshalling list request model", zap.String("Request Id", requestId), zap.Error(err))
+		d.render(w, r, "links.html", http.StatusInternalServerError, data.withError("Something went wrong!"))
+		return
+	}
+
+	listResponseModel, err := d.databaseservice.HandleListLinks(r.Context(), bytes.NewBuffer(listRequestModelJson), requestId)
+
+	if err != nil {
+		d.logger.Errorw("Error listing links", zap.String("Request Id", requestId), zap.Error(err))
+		d.render(w, r, "links.html", http.StatusBadGateway, data.withError("Could not load links"))
+		return
+	}
+
+	for i := range listResponseModel.Links {
+		listResponseModel.Links[i].ShortUrl = d.shortUrl(listResponseModel.Links[i].ShortUrlPath)
+	}
+
+	data.Links = listResponseModel.Links
+	data.NextCursor = listResponseModel.NextCursor
+
+	d.render(w, r, "links.html", http.StatusOK, data)
+}
+
+func (d *dashboard) HandleNewLink(w http.ResponseWriter, r *http.Request) {
+	d.render(w, r, "new.html", http.StatusOK, &newLinkPage{})
+}
+
+func (d *dashboard) HandleCreateLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	user, _ := d.session(r)
+
+	form := readLinkForm(r)
+	data := &newLinkPage{Form: form}
+
+	if !isValidUrl(form.Url) {
+		d.render(w, r, "new.html", http.StatusBadRequest, data.withError("Invalid URL"))
+		return
+	}
+
+	expiresAt, err := parseDateTime(form.ExpiresAt)
+
+	if err != nil {
+		d.render(w, r, "new.html", http.StatusBadRequest, data.withError("Invalid expiry"))
+		return
+	}
+
+	shortenRequestModelJson, err := json.Marshal(&models.ShortenRequestModel{
+		Url:       form.Url,
+		ExpiresAt: expiresAt,
+		Title:     form.Title,
+		Note:      form.Note,
+		Tags:      splitTags(form.Tags),
+		Owner:     user,
+	})
+
+	if err != nil {
+		d.logger.Errorw("Error marshalling shorten request model", zap.String("Request Id", requestId), zap.Error(err))
+		d.render(w, r, "new.html", http.StatusInternalServerError, data.withError("Something went wrong!"))
+		return
+	}
+
+	shortenResponseModel, err := d.databaseservice.HandleShorten(r.Context(), bytes.NewBuffer(shortenRequestModelJson), requestId)
+
+	if err != nil {
+		d.logger.Errorw("Error creating link", zap.String("Request Id", requestId), zap.Error(err))
+		d.render(w, r, "new.html", http.StatusBadGateway, data.withError("Could not create the link"))
+		return
+	}
+
+	link := &models.CacheStoreRequestModel{ShortUrlPath: shortenResponseModel.ShortUrlPath, Url: form.Url, ExpiresAt: expiresAt}
+
+	if err := d.cacheservice.HandleStore(context.WithoutCancel(r.Context()), link, requestId); err != nil {
+		d.logger.Errorw("Error storing link in cache", zap.String("Request Id", requestId), zap.String("shorturlpath", link.ShortUrlPath), zap.Error(err))
+	}
+
+	d.logger.Infow("Created link from dashboard", zap.String("Request Id", requestId), zap.String("user", user), zap.String("shorturlpath", shortenResponseModel.ShortUrlPath))
+
+	http.Redirect(w, r, "/dashboard/links/"+url.PathEscape(shortenResponseModel.ShortUrlPath)+"?flash=created", http.StatusSeeOther)
+}
+
+func (d *dashboard) HandleLink(w http.ResponseWriter, r *http.Request) {
+	data, status := d.loadLink(r)
+
+	switch r.URL.Query().Get("flash") {
+	case "created":
+		data.Flash = "Link created"
+	case "saved":
+		data.Flash = "Changes saved"
+	}
+
+	d.render(w, r, "link.html", status, data)
+}
+
+func (d *dashboard) HandleUpdateLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["url"]
+	form := readLinkForm(r)
+
+	fail := func(status int, message string) {
+		data, loadStatus := d.loadLink(r)
+
+		if loadStatus == http.StatusOK {
+			data.Form = form
+			data.Error = message
+			loadStatus = status
+		}
+
+		d.render(w, r, "link.html", loadStatus, data)
+	}
+
+	if !isValidUrl(form.Url) {
+		fail(http.StatusBadRequest, "Invalid URL")
+		return
+	}
+
+	expiresAt, err := parseDateTime(form.ExpiresAt)
+
+	if err != nil || expiresAt.IsZero() {
+		fail(http.StatusBadRequest, "Invalid expiry")
+		return
+	}
+
+	tags := splitTags(form.Tags)
+
+	updateRequestModelJson, err := json.Marshal(&models.UpdateRequestModel{
+		Url:       &form.Url,
+		ExpiresAt: &expiresAt,
+		Title:     &form.Title,
+		Note:      &form.Note,
+		Tags:      &tags,
+	})
+
+	if err != nil {
+		d.logger.Errorw("Error marshalling update request model", zap.String("Request Id", requestId), zap.Error(err))
+		fail(http.StatusInternalServerError, "Something went wrong!")
+		return
+	}
+
+	if _, err := d.databaseservice.HandleUpdateLink(r.Context(), shortUrlPath, bytes.NewBuffer(updateRequestModelJson), requestId); err != nil {
+		d.logger.Errorw("Error updating link", zap.String("Request Id", requestId), zap.Error(err))
+		fail(http.StatusBadGateway, "Could not save the changes")
+		return
+	}
+
+	if err := d.cacheservice.HandleEvict(context.WithoutCancel(r.Context()), shortUrlPath, requestId); err != nil {
+		d.logger.Errorw("Error evicting link from cache", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath), zap.Error(err))
+	}
+
+	http.Redirect(w, r, "/dashboard/links/"+url.PathEscape(shortUrlPath)+"?flash=saved", http.StatusSeeOther)
+}
+
+func (d *dashboard) HandleQRCode(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["url"]
+
+	link, err := d.databaseservice.HandleGetLink(r.Context(), shortUrlPath, requestId)
+
+	if err != nil {
+		switch err.Error() {
+		case http.StatusText(http.StatusNotFound):
+			http.Error(w, "Link not found", http.StatusNotFound)
+		case http.StatusText(http.StatusGone):
+			http.Error(w, "Link was deleted", http.StatusGone)
+		default:
+			d.logger.Errorw("Error loading link", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Could not load the link", http.StatusBadGateway)
+		}
+		return
+	}
+
+	png, err := qrcode.Encode(d.shortUrl(link.ShortUrlPath), qrcode.Medium, qrCodeSize)
+
+	if err != nil {
+		d.logger.Errorw("Error encoding QR code", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+
+	if r.URL.Query().Get("download") != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": link.ShortUrlPath + ".png"}))
+	}
+
+	w.Write(png)
+}
+
+// loadLink fetches the link and its stats for the link page. Missing stats do
+// not fail the page.
+func (d *dashboard) loadLink(r *http.Request) (*linkPage, int) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["url"]
+	data := &linkPage{}
+
+	link, err := d.databaseservice.HandleGetLink(r.Context(), shortUrlPath, requestId)
+
+	if err != nil {
+		if err.Error() == http.StatusText(http.StatusNotFound) {
+			data.Error = "Link not found"
+			return data, http.StatusNotFound
+		}
+
+		if err.Error() == http.StatusText(http.StatusGone) {
+			data.Error = "Link was deleted"
+			return data, http.StatusGone
+		}
+
+		d.logger.Errorw("Error loading link", zap.String("Request Id", requestId), zap.Error(err))
+		data.Error = "Could not load the link"
+		return data, http.StatusBadGateway
+	}
+
+	link.ShortUrl = d.shortUrl(link.ShortUrlPath)
+
+	data.Link = link
+	data.Form = linkForm{
+		Url:       link.Url,
+		Title:     link.Title,
+		Note:      link.Note,
+		Tags:      strings.Join(link.Tags, ", "),
+		ExpiresAt: link.ExpiresAt.UTC().Format(dateTimeLayout),
+	}
+
+	stats, err := d.databaseservice.HandleLinkStats(r.Context(), shortUrlPath, statsDays, requestId)
+
+	if err != nil {
+		d.logger.Errorw("Error loading link stats", zap.String("Request Id", requestId), zap.Error(err))
+		return data, http.StatusOK
+	}
+
+	data.Stats = stats
+	data.Chart = newChart(stats.Daily)
+
+	return data, http.StatusOK
+}
+
+func (d *dashboard) render(w http.ResponseWriter, r *http.Request, name string, status int, data renderable) {
+	if user, ok := d.session(r); ok {
+		data.setUser(user)
+	}
+
+	var body bytes.Buffer
+
+	if err := d.templates[name].ExecuteTemplate(&body, "layout", data); err != nil {
+		d.logger.Errorw("Error rendering dashboard template", zap.String("Request Id", r.Header.Get("X-request-id")), zap.String("template", name), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body.Bytes())
+}
+
+func (d *dashboard) shortUrl(shortUrlPath string) string {
+	return d.config.BaseUrl + "/" + shortUrlPath
+}
+
+func readLinkForm(r *http.Request) linkForm {
+	return linkForm{
+		Url:       strings.TrimSpace(r.PostFormValue("url")),
+		Title:     strings.TrimSpace(r.PostFormValue("title")),
+		Note:      strings.TrimSpace(r.PostFormValue("note")),
+		Tags:      r.PostFormValue("tags"),
+		ExpiresAt: r.PostFormValue("expires_at"),
+	}
+}
+
+func isValidUrl(value string) bool {
+	result, err := UrlVerifier.NewVerifier().Verify(value)
+	return err == nil && result != nil && result.IsURL
+}
+
+func parseDateTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(dateTimeLayout, value)
+}
+
+func splitTags(value string) []string {
+	tags := []string{}
+
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return "-"
+	}
+
+	return value.UTC().Format("2006-01-02 15:04")
+}
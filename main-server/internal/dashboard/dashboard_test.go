@@ -0,0 +1,466 @@
+package dashboard_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	mock_cacheservice "main-server/external/cache-service/mocks"
+	mock_databaseservice "main-server/external/database-service/mocks"
+	"main-server/internal/apikeys"
+	"main-server/internal/config"
+	"main-server/internal/dashboard"
+	"main-server/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mocks struct {
+	dbService    *mock_databaseservice.MockDatabaseServiceInterface
+	cacheService *mock_cacheservice.MockCacheServiceInterface
+}
+
+func newDashboard(t *testing.T) (dashboard.DashboardInterface, *mocks) {
+	logger := zap.NewNop().Sugar()
+
+	mockCtrl := gomock.NewController(t)
+	m := &mocks{
+		dbService:    mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl),
+		cacheService: mock_cacheservice.NewMockCacheServiceInterface(mockCtrl),
+	}
+
+	cfg := &config.Config{BaseUrl: "http://localhost:8080", SessionSecret: "test-secret"}
+
+	keys, err := apikeys.NewStore("alice:" + apikeys.Hash("secret"))
+	assert.Nil(t, err)
+
+	d, err := dashboard.NewDashboard(logger, m.dbService, m.cacheService, cfg, keys)
+	assert.Nil(t, err)
+
+	return d, m
+}
+
+func login(t *testing.T, d dashboard.DashboardInterface) *http.Cookie {
+	req := httptest.NewRequest("POST", "/dashboard/login", strings.NewReader(url.Values{"key": {"secret"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp := httptest.NewRecorder()
+	d.HandleLogin(resp, req)
+
+	cookies := resp.Result().Cookies()
+	assert.Equal(t, 1, len(cookies))
+
+	return cookies[0]
+}
+
+func form(method string, target string, values url.Values, cookie *http.Cookie, vars map[string]string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	if vars != nil {
+		req = mux.SetURLVars(req, vars)
+	}
+
+	return req
+}
+
+func TestHandleLogin(t *testing.T) {
+	tests := map[string]struct {
+		key                string
+		ExpectedStatusCode int
+		ExpectedCookie     bool
+	}{
+		"Invalid Key": {
+			key:                "wrong",
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		"Empty Key": {
+			key:                "",
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		"Success": {
+			key:                "secret",
+			ExpectedStatusCode: http.StatusSeeOther,
+			ExpectedCookie:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, _ := newDashboard(t)
+
+			resp := httptest.NewRecorder()
+			d.HandleLogin(resp, form("POST", "/dashboard/login", url.Values{"key": {test.key}}, nil, nil))
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+			assert.Equal(t, test.ExpectedCookie, len(resp.Result().Cookies()) == 1)
+		})
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	d, _ := newDashboard(t)
+
+	next := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	tests := map[string]struct {
+		cookie             *http.Cookie
+		ExpectedStatusCode int
+	}{
+		"No Session": {
+			ExpectedStatusCode: http.StatusSeeOther,
+		},
+		"Forged Session": {
+			cookie:             &http.Cookie{Name: "dashboard_session", Value: "YWxpY2V8OTk5OTk5OTk5OQ.forged"},
+			ExpectedStatusCode: http.StatusSeeOther,
+		},
+		"Valid Session": {
+			cookie:             login(t, d),
+			ExpectedStatusCode: http.StatusTeapot,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			d.RequireSession(next)(resp, form("GET", "/dashboard", nil, test.cookie, nil))
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+		})
+	}
+}
+
+func TestRequireSessionRemovedKey(t *testing.T) {
+	keys, err := apikeys.NewStore("alice:" + apikeys.Hash("secret") + ",bob:" + apikeys.Hash("other"))
+	assert.Nil(t, err)
+
+	d, err := dashboard.NewDashboard(zap.NewNop().Sugar(), nil, nil, &config.Config{SessionSecret: "test-secret"}, keys)
+	assert.Nil(t, err)
+
+	cookie := login(t, d)
+
+	next := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	resp := httptest.NewRecorder()
+	d.RequireSession(next)(resp, form("GET", "/dashboard", nil, cookie, nil))
+	assert.Equal(t, http.StatusTeapot, resp.Code)
+
+	assert.Nil(t, keys.Replace("bob:"+apikeys.Hash("other")))
+
+	resp = httptest.NewRecorder()
+	d.RequireSession(next)(resp, form("GET", "/dashboard", nil, cookie, nil))
+	assert.Equal(t, http.StatusSeeOther, resp.Code, "the session of a removed key ends")
+}
+
+func TestHandleLinks(t *testing.T) {
+	tests := map[string]struct {
+		HandleListLinksReturn      *models.ListResponseModel
+		HandleListLinksReturnError error
+		ExpectedStatusCode         int
+		ExpectedBody               []string
+	}{
+		"Database Service Failed": {
+			HandleListLinksReturnError: assert.AnError,
+			ExpectedStatusCode:         http.StatusBadGateway,
+			ExpectedBody:               []string{"Could not load links"},
+		},
+		"Success": {
+			HandleListLinksReturn: &models.ListResponseModel{
+				Links:      []models.LinkModel{{ShortUrlPath: "abc", Url: "https://example.com", Tags: []string{"go"}}},
+				NextCursor: "next",
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedBody: []string{
+				`<a href="/dashboard/links/abc">http://localhost:8080/abc</a>`,
+				`href="/dashboard?tag=go"`,
+				`cursor=next`,
+				`alice`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, m := newDashboard(t)
+
+			var sent models.ListRequestModel
+			m.dbService.EXPECT().HandleListLinks(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, body io.Reader, requestId string) (*models.ListResponseModel, error) {
+				json.NewDecoder(body).Decode(&sent)
+				return test.HandleListLinksReturn, test.HandleListLinksReturnError
+			})
+
+			resp := httptest.NewRecorder()
+			d.HandleLinks(resp, form("GET", "/dashboard?q=search&tag=go", nil, login(t, d), nil))
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+			assert.Equal(t, "search", sent.Query)
+			assert.Equal(t, "go", sent.Tag)
+
+			for _, expected := range test.ExpectedBody {
+				assert.Contains(t, resp.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestHandleCreateLink(t *testing.T) {
+	tests := map[string]struct {
+		values                   url.Values
+		HandleShortenReturnError error
+		HandleShortenCallTimes   int
+		HandleStoreReturnError   error
+		HandleStoreCallTimes     int
+		ExpectedStatusCode       int
+		ExpectedLocation         string
+	}{
+		"Invalid Url": {
+			values:             url.Values{"url": {"/test"}},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Invalid Expiry": {
+			values:             url.Values{"url": {"https://example.com"}, "expires_at": {"tomorrow"}},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Database Service Failed": {
+			values:                   url.Values{"url": {"https://example.com"}},
+			HandleShortenReturnError: assert.AnError,
+			HandleShortenCallTimes:   1,
+			ExpectedStatusCode:       http.StatusBadGateway,
+		},
+		"Cache Service Failed": {
+			values:                 url.Values{"url": {"https://example.com"}, "tags": {"go, docs"}, "expires_at": {"2030-01-02T15:04"}},
+			HandleShortenCallTimes: 1,
+			HandleStoreReturnError: assert.AnError,
+			HandleStoreCallTimes:   1,
+			ExpectedStatusCode:     http.StatusSeeOther,
+			ExpectedLocation:       "/dashboard/links/abc?flash=created",
+		},
+		"Success": {
+			values:                 url.Values{"url": {"https://example.com"}, "tags": {"go, docs"}, "expires_at": {"2030-01-02T15:04"}},
+			HandleShortenCallTimes: 1,
+			HandleStoreCallTimes:   1,
+			ExpectedStatusCode:     http.StatusSeeOther,
+			ExpectedLocation:       "/dashboard/links/abc?flash=created",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, m := newDashboard(t)
+
+			var sent models.ShortenRequestModel
+			m.dbService.EXPECT().HandleShorten(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, body io.Reader, requestId string) (*models.ShortenResponseModel, error) {
+				json.NewDecoder(body).Decode(&sent)
+				return &models.ShortenResponseModel{ShortUrlPath: "abc"}, test.HandleShortenReturnError
+			}).Times(test.HandleShortenCallTimes)
+
+			var stored models.CacheStoreRequestModel
+			m.cacheService.EXPECT().HandleStore(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, link *models.CacheStoreRequestModel, requestId string) error {
+				stored = *link
+				return test.HandleStoreReturnError
+			}).Times(test.HandleStoreCallTimes)
+
+			resp := httptest.NewRecorder()
+			d.HandleCreateLink(resp, form("POST", "/dashboard/links", test.values, login(t, d), nil))
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+			assert.Equal(t, test.ExpectedLocation, resp.Header().Get("Location"))
+
+			if test.ExpectedStatusCode == http.StatusSeeOther {
+				assert.Equal(t, "alice", sent.Owner)
+				assert.Equal(t, []string{"go", "docs"}, sent.Tags)
+				assert.Equal(t, time.Date(2030, 1, 2, 15, 4, 0, 0, time.UTC), sent.ExpiresAt)
+				assert.Equal(t, models.CacheStoreRequestModel{ShortUrlPath: "abc", Url: "https://example.com", ExpiresAt: sent.ExpiresAt}, stored)
+			}
+		})
+	}
+}
+
+func TestHandleLink(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	tests := map[string]struct {
+		HandleGetLinkReturnError   error
+		HandleLinkStatsReturnError error
+		HandleLinkStatsCallTimes   int
+		ExpectedStatusCode         int
+		ExpectedBody               []string
+	}{
+		"Not Found": {
+			HandleGetLinkReturnError: errors.New(http.StatusText(http.StatusNotFound)),
+			ExpectedStatusCode:       http.StatusNotFound,
+			ExpectedBody:             []string{"Link not found"},
+		},
+		"Deleted": {
+			HandleGetLinkReturnError: errors.New(http.StatusText(http.StatusGone)),
+			ExpectedStatusCode:       http.StatusGone,
+			ExpectedBody:             []string{"Link was deleted"},
+		},
+		"Stats Failed": {
+			HandleLinkStatsReturnError: assert.AnError,
+			HandleLinkStatsCallTimes:   1,
+			ExpectedStatusCode:         http.StatusOK,
+			ExpectedBody:               []string{"not available"},
+		},
+		"Success": {
+			HandleLinkStatsCallTimes: 1,
+			ExpectedStatusCode:       http.StatusOK,
+			ExpectedBody: []string{
+				`value="2030-01-02T15:04"`,
+				`/dashboard/links/abc/qr.png?download=1`,
+				`<title>` + today.Format("2006-01-02") + `: 4</title>`,
+				`height="120"`,
+				`height="60"`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, m := newDashboard(t)
+
+			m.dbService.EXPECT().HandleGetLink(gomock.Any(), "abc", gomock.Any()).Return(&models.LinkModel{
+				ShortUrlPath: "abc",
+				Url:          "https://example.com",
+				ExpiresAt:    time.Date(2030, 1, 2, 15, 4, 0, 0, time.UTC),
+			}, test.HandleGetLinkReturnError)
+			m.dbService.EXPECT().HandleLinkStats(gomock.Any(), "abc", 30, gomock.Any()).Return(&models.StatsResponseModel{
+				ShortUrlPath: "abc",
+				TotalClicks:  6,
+				Daily: []models.DailyClicksModel{
+					{Day: today.AddDate(0, 0, -1), Count: 2},
+					{Day: today, Count: 4},
+				},
+			}, test.HandleLinkStatsReturnError).Times(test.HandleLinkStatsCallTimes)
+
+			resp := httptest.NewRecorder()
+			d.HandleLink(resp, form("GET", "/dashboard/links/abc", nil, login(t, d), map[string]string{"url": "abc"}))
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+
+			for _, expected := range test.ExpectedBody {
+				assert.Contains(t, resp.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestHandleUpdateLink(t *testing.T) {
+	tests := map[string]struct {
+		values                      url.Values
+		HandleUpdateLinkReturnError error
+		HandleUpdateLinkCallTimes   int
+		HandleEvictCallTimes        int
+		ExpectedStatusCode          int
+	}{
+		"Missing Expiry": {
+			values:             url.Values{"url": {"https://example.com"}},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Database Service Failed": {
+			values:                      url.Values{"url": {"https://example.com"}, "expires_at": {"2030-01-02T15:04"}},
+			HandleUpdateLinkReturnError: assert.AnError,
+			HandleUpdateLinkCallTimes:   1,
+			ExpectedStatusCode:          http.StatusBadGateway,
+		},
+		"Success": {
+			values:                    url.Values{"url": {"https://example.com/new"}, "expires_at": {"2030-01-02T15:04"}},
+			HandleUpdateLinkCallTimes: 1,
+			HandleEvictCallTimes:      1,
+			ExpectedStatusCode:        http.StatusSeeOther,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, m := newDashboard(t)
+
+			m.dbService.EXPECT().HandleUpdateLink(gomock.Any(), "abc", gomock.Any(), gomock.Any()).Return(&models.LinkModel{ShortUrlPath: "abc"}, test.HandleUpdateLinkReturnError).Times(test.HandleUpdateLinkCallTimes)
+			m.dbService.EXPECT().HandleGetLink(gomock.Any(), "abc", gomock.Any()).Return(&models.LinkModel{ShortUrlPath: "abc"}, nil).AnyTimes()
+			m.dbService.EXPECT().HandleLinkStats(gomock.Any(), "abc", gomock.Any(), gomock.Any()).Return(&models.StatsResponseModel{}, nil).AnyTimes()
+			m.cacheService.EXPECT().HandleEvict(gomock.Any(), "abc", gomock.Any()).Return(nil).Times(test.HandleEvictCallTimes)
+
+			resp := httptest.NewRecorder()
+			d.HandleUpdateLink(resp, form("POST", "/dashboard/links/abc", test.values, login(t, d), map[string]string{"url": "abc"}))
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+		})
+	}
+}
+
+func TestHandleQRCode(t *testing.T) {
+	tests := map[string]struct {
+		shortUrlPath               string
+		HandleGetLinkReturnError   error
+		ExpectedStatusCode         int
+		ExpectedContentDisposition string
+	}{
+		"Download": {
+			shortUrlPath:               "abc",
+			ExpectedStatusCode:         http.StatusOK,
+			ExpectedContentDisposition: "attachment; filename=abc.png",
+		},
+		"Quoted Filename": {
+			shortUrlPath:               `a"b`,
+			ExpectedStatusCode:         http.StatusOK,
+			ExpectedContentDisposition: `attachment; filename="a\"b.png"`,
+		},
+		"Not Found": {
+			shortUrlPath:             "abc",
+			HandleGetLinkReturnError: errors.New(http.StatusText(http.StatusNotFound)),
+			ExpectedStatusCode:       http.StatusNotFound,
+		},
+		"Deleted": {
+			shortUrlPath:             "abc",
+			HandleGetLinkReturnError: errors.New(http.StatusText(http.StatusGone)),
+			ExpectedStatusCode:       http.StatusGone,
+		},
+		"Database Service Fail": {
+			shortUrlPath:             "abc",
+			HandleGetLinkReturnError: assert.AnError,
+			ExpectedStatusCode:       http.StatusBadGateway,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, m := newDashboard(t)
+
+			var link *models.LinkModel
+			if test.HandleGetLinkReturnError == nil {
+				link = &models.LinkModel{ShortUrlPath: test.shortUrlPath}
+			}
+
+			m.dbService.EXPECT().HandleGetLink(gomock.Any(), test.shortUrlPath, gomock.Any()).Return(link, test.HandleGetLinkReturnError).Times(1)
+
+			resp := httptest.NewRecorder()
+			d.HandleQRCode(resp, form("GET", "/dashboard/links/qr.png?download=1", nil, login(t, d), map[string]string{"url": test.shortUrlPath}))
+
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+
+			if test.ExpectedStatusCode != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, "image/png", resp.Header().Get("Content-Type"))
+			assert.Equal(t, test.ExpectedContentDisposition, resp.Header().Get("Content-Disposition"))
+			assert.True(t, strings.HasPrefix(resp.Body.String(), "\x89PNG"))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/dashboard/dashboard.go
+
+// Package mock_dashboard is a generated GoMock package.
+package mock_dashboard
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDashboardInterface is a mock of DashboardInterface interface.
+type MockDashboardInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDashboardInterfaceMockRecorder
+}
+
+// MockDashboardInterfaceMockRecorder is the mock recorder for MockDashboardInterface.
+type MockDashboardInterfaceMockRecorder struct {
+	mock *MockDashboardInterface
+}
+
+// NewMockDashboardInterface creates a new mock instance.
+func NewMockDashboardInterface(ctrl *gomock.Controller) *MockDashboardInterface {
+	mock := &MockDashboardInterface{ctrl: ctrl}
+	mock.recorder = &MockDashboardInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDashboardInterface) EXPECT() *MockDashboardInterfaceMockRecorder {
+	return m.recorder
+}
+
+// HandleCreateLink mocks base method.
+func (m *MockDashboardInterface) HandleCreateLink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleCreateLink", w, r)
+}
+
+// HandleCreateLink indicates an expected call of HandleCreateLink.
+func (mr *MockDashboardInterfaceMockRecorder) HandleCreateLink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleCreateLink", reflect.TypeOf((*MockDashboardInterface)(nil).HandleCreateLink), w, r)
+}
+
+// HandleLink mocks base method.
+func (m *MockDashboardInterface) HandleLink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleLink", w, r)
+}
+
+// HandleLink indicates an expected call of HandleLink.
+func (mr *MockDashboardInterfaceMockRecorder) HandleLink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLink", reflect.TypeOf((*MockDashboardInterface)(nil).HandleLink), w, r)
+}
+
+// HandleLinks mocks base method.
+func (m *MockDashboardInterface) HandleLinks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleLinks", w, r)
+}
+
+// HandleLinks indicates an expected call of HandleLinks.
+func (mr *MockDashboardInterfaceMockRecorder) HandleLinks(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLinks", reflect.TypeOf((*MockDashboardInterface)(nil).HandleLinks), w, r)
+}
+
+// HandleLogin mocks base method.
+func (m *MockDashboardInterface) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleLogin", w, r)
+}
+
+// HandleLogin indicates an expected call of HandleLogin.
+func (mr *MockDashboardInterfaceMockRecorder) HandleLogin(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLogin", reflect.TypeOf((*MockDashboardInterface)(nil).HandleLogin), w, r)
+}
+
+// HandleLoginPage mocks base method.
+func (m *MockDashboardInterface) HandleLoginPage(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleLoginPage", w, r)
+}
+
+// HandleLoginPage indicates an expected call of HandleLoginPage.
+func (mr *MockDashboardInterfaceMockRecorder) HandleLoginPage(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLoginPage", reflect.TypeOf((*MockDashboardInterface)(nil).HandleLoginPage), w, r)
+}
+
+// HandleLogout mocks base method.
+func (m *MockDashboardInterface) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleLogout", w, r)
+}
+
+// HandleLogout indicates an expected call of HandleLogout.
+func (mr *MockDashboardInterfaceMockRecorder) HandleLogout(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLogout", reflect.TypeOf((*MockDashboardInterface)(nil).HandleLogout), w, r)
+}
+
+// HandleNewLink mocks base method.
+func (m *MockDashboardInterface) HandleNewLink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleNewLink", w, r)
+}
+
+// HandleNewLink indicates an expected call of HandleNewLink.
+func (mr *MockDashboardInterfaceMockRecorder) HandleNewLink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleNewLink", reflect.TypeOf((*MockDashboardInterface)(nil).HandleNewLink), w, r)
+}
+
+// HandleQRCode mocks base method.
+func (m *MockDashboardInterface) HandleQRCode(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleQRCode", w, r)
+}
+
+// HandleQRCode indicates an expected call of HandleQRCode.
+func (mr *MockDashboardInterfaceMockRecorder) HandleQRCode(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleQRCode", reflect.TypeOf((*MockDashboardInterface)(nil).HandleQRCode), w, r)
+}
+
+// HandleUpdateLink mocks base method.
+func (m *MockDashboardInterface) HandleUpdateLink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleUpdateLink", w, r)
+}
+
+// HandleUpdateLink indicates an expected call of HandleUpdateLink.
+func (mr *MockDashboardInterfaceMockRecorder) HandleUpdateLink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdateLink", reflect.TypeOf((*MockDashboardInterface)(nil).HandleUpdateLink), w, r)
+}
+
+// RequireSession mocks base method.
+func (m *MockDashboardInterface) RequireSession(next http.HandlerFunc) http.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequireSession", next)
+	ret0, _ := ret[0].(http.HandlerFunc)
+	return ret0
+}
+
+// RequireSession indicates an expected call of RequireSession.
+func (mr *MockDashboardInterfaceMockRecorder) RequireSession(next interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequireSession", reflect.TypeOf((*MockDashboardInterface)(nil).RequireSession), next)
+}
+
+// StaticHandler mocks base method.
+func (m *MockDashboardInterface) StaticHandler() http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaticHandler")
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// StaticHandler indicates an expected call of StaticHandler.
+func (mr *MockDashboardInterfaceMockRecorder) StaticHandler() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaticHandler", reflect.TypeOf((*MockDashboardInterface)(nil).StaticHandler))
+}
@@ -0,0 +1,58 @@
+package dashboard
+
+import "main-server/internal/models"
+
+type page struct {
+	User  string
+	Error string
+	Flash string
+}
+
+type linksPage struct {
+	page
+	Query      string
+	Tag        string
+	Links      []models.LinkModel
+	NextCursor string
+}
+
+type linkForm struct {
+	Url       string
+	Title     string
+	Note      string
+	Tags      string
+	ExpiresAt string
+}
+
+type newLinkPage struct {
+	page
+	Form linkForm
+}
+
+type linkPage struct {
+	page
+	Link  *models.LinkModel
+	Form  linkForm
+	Stats *models.StatsResponseModel
+	Chart chart
+}
+
+// renderable is the data of any dashboard page; render fills in the logged in
+// user for the header.
+type renderable interface {
+	setUser(user string)
+}
+
+func (p *page) setUser(user string) {
+	p.User = user
+}
+
+func (p *linksPage) withError(message string) *linksPage {
+	p.Error = message
+	return p
+}
+
+func (p *newLinkPage) withError(message string) *newLinkPage {
+	p.Error = message
+	return p
+}
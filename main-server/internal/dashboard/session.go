@@ -0,0 +1,102 @@
+package dashboard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sessionCookieName = "dashboard_session"
+	sessionDuration   = 12 * time.Hour
+)
+
+// sessions issues and checks signed session cookies. The cookie holds the API
+// key name and expiry, so no server side state is needed and sessions survive
+// restarts as long as the secret stays the same.
+type sessions struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func newSessions(secret []byte) *sessions {
+	return &sessions{secret: secret, now: time.Now}
+}
+
+func (s *sessions) set(w http.ResponseWriter, r *http.Request, name string) {
+	expiresAt := s.now().Add(sessionDuration)
+	payload := name + "|" + strconv.FormatInt(expiresAt.Unix(), 10)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + s.sign(payload),
+		Path:     "/dashboard",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func (s *sessions) clear(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/dashboard",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// get returns the API key name of a valid, unexpired session.
+func (s *sessions) get(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(sessionCookieName)
+
+	if err != nil {
+		return "", false
+	}
+
+	encoded, signature, ok := strings.Cut(cookie.Value, ".")
+
+	if !ok {
+		return "", false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return "", false
+	}
+
+	payload := string(decoded)
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return "", false
+	}
+
+	name, expiry, ok := strings.Cut(payload, "|")
+
+	if !ok {
+		return "", false
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+
+	if err != nil || s.now().Unix() >= expiresAt {
+		return "", false
+	}
+
+	return name, true
+}
+
+func (s *sessions) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
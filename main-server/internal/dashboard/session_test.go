@@ -0,0 +1,66 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessions(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	s := newSessions([]byte("secret"))
+	s.now = func() time.Time { return now }
+
+	resp := httptest.NewRecorder()
+	s.set(resp, httptest.NewRequest("POST", "/dashboard/login", nil), "alice")
+	cookie := resp.Result().Cookies()[0]
+
+	tests := map[string]struct {
+		cookie       *http.Cookie
+		secret       string
+		at           time.Time
+		expectedName string
+		expectedOk   bool
+	}{
+		"Valid": {
+			cookie:       cookie,
+			secret:       "secret",
+			at:           now.Add(time.Hour),
+			expectedName: "alice",
+			expectedOk:   true,
+		},
+		"Expired": {
+			cookie: cookie,
+			secret: "secret",
+			at:     now.Add(sessionDuration),
+		},
+		"Other Secret": {
+			cookie: cookie,
+			secret: "other",
+			at:     now,
+		},
+		"Malformed": {
+			cookie: &http.Cookie{Name: sessionCookieName, Value: "abc"},
+			secret: "secret",
+			at:     now,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			checker := newSessions([]byte(test.secret))
+			checker.now = func() time.Time { return test.at }
+
+			req := httptest.NewRequest("GET", "/dashboard", nil)
+			req.AddCookie(test.cookie)
+
+			actualName, ok := checker.get(req)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedName, actualName)
+		})
+	}
+}
@@ -2,13 +2,21 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	cacheservice "main-server/external/cache-service"
 	databaseservice "main-server/external/database-service"
+	"main-server/internal/clicks"
 	"main-server/internal/config"
+	"main-server/internal/linkformat"
 	"main-server/internal/models"
 	"net/http"
+	"strconv"
+	"time"
+
+	api "url-shortner-api"
 
 	UrlVerifier "github.com/davidmytton/url-verifier"
 	"github.com/gorilla/mux"
@@ -16,24 +24,43 @@ import (
 	"go.uber.org/zap"
 )
 
+// importBatchSize is the number of rows sent to the database service in a
+// single import request.
+const importBatchSize = 500
+
 type HandlerInterface interface {
 	HandleShorten(w http.ResponseWriter, r *http.Request)
 	HandleRedirect(w http.ResponseWriter, r *http.Request)
+	HandleListLinks(w http.ResponseWriter, r *http.Request)
+	HandleExportLinks(w http.ResponseWriter, r *http.Request)
+	HandleImportLinks(w http.ResponseWriter, r *http.Request)
+	HandleGetLink(w http.ResponseWriter, r *http.Request)
+	HandleUpdateLink(w http.ResponseWriter, r *http.Request)
+	HandleDeleteLink(w http.ResponseWriter, r *http.Request)
+	HandleRestoreLink(w http.ResponseWriter, r *http.Request)
+	HandleLinkStats(w http.ResponseWriter, r *http.Request)
+	HandleOpenAPISpec(w http.ResponseWriter, r *http.Request)
 }
 
 type handler struct {
 	logger          *zap.SugaredLogger
 	databaseservice databaseservice.DatabaseServiceInterface
-	config          config.ConfigInterface
+	config          *config.Config
 	cacheservice    cacheservice.CacheServiceInterface
+	recorder        clicks.RecorderInterface
+	deletedPage     []byte
 }
 
-func NewBaseHandler(logger *zap.SugaredLogger, databaseservice databaseservice.DatabaseServiceInterface, config config.ConfigInterface, cacheservice cacheservice.CacheServiceInterface) *handler {
+// NewBaseHandler answers redirects of deleted links with deletedPage, or with
+// a plain message when it is nil.
+func NewBaseHandler(logger *zap.SugaredLogger, databaseservice databaseservice.DatabaseServiceInterface, config *config.Config, cacheservice cacheservice.CacheServiceInterface, recorder clicks.RecorderInterface, deletedPage []byte) *handler {
 	return &handler{
 		logger:          logger,
 		databaseservice: databaseservice,
 		config:          config,
 		cacheservice:    cacheservice,
+		recorder:        recorder,
+		deletedPage:     deletedPage,
 	}
 }
 
@@ -84,6 +111,10 @@ func (h *handler) HandleShorten(w http.ResponseWriter, r *http.Request) {
 	shortenRequestModel := &models.ShortenRequestModel{
 		Url:       unmarsheledBody.Url,
 		ExpiresAt: unmarsheledBody.ExpiresAt,
+		Title:     unmarsheledBody.Title,
+		Note:      unmarsheledBody.Note,
+		Tags:      unmarsheledBody.Tags,
+		Owner:     unmarsheledBody.Owner,
 	}
 
 	h.logger.Infow("Shorten Request Model", zap.String("Request Id", requestId), zap.Any("model", shortenRequestModel))
@@ -98,7 +129,7 @@ func (h *handler) HandleShorten(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Infow("Successfully marshalled shorten request model", zap.String("Request Id", requestId), zap.Any("model", shortenRequestModel))
 
-	shortenResponseModel, err := h.databaseservice.HandleShorten(bytes.NewBuffer(shortenRequestModelJson), requestId)
+	shortenResponseModel, err := h.databaseservice.HandleShorten(r.Context(), bytes.NewBuffer(shortenRequestModelJson), requestId)
 
 	if err != nil {
 		h.logger.Errorw("Error processing shorten request", zap.String("Request Id", requestId), zap.Error(err))
@@ -106,8 +137,15 @@ func (h *handler) HandleShorten(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.store(r.Context(), &models.CacheStoreRequestModel{
+		ShortUrlPath: shortenResponseModel.ShortUrlPath,
+		Url:          shortenRequestModel.Url,
+		ExpiresAt:    shortenRequestModel.ExpiresAt,
+	}, requestId)
+
 	responseModel := &models.ResponseModel{
-		Url: h.config.Get("BASE_URL") + "/" + shortenResponseModel.ShortUrlPath,
+		ShortUrlPath: shortenResponseModel.ShortUrlPath,
+		Url:          h.config.BaseUrl + "/" + shortenResponseModel.ShortUrlPath,
 	}
 
 	h.logger.Infow("Response Model", zap.String("Request Id", requestId), zap.Any("model", responseModel))
@@ -155,7 +193,7 @@ func (h *handler) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Infow("Successfully marshalled redirect request model", zap.String("Request Id", requestId), zap.Any("model", redirectRequestModelJson))
 
-	redirectResponseModel, err := h.cacheservice.HandleRedirect(bytes.NewBuffer(redirectRequestModelJson), requestId)
+	redirectResponseModel, err := h.cacheservice.HandleRedirect(r.Context(), bytes.NewBuffer(redirectRequestModelJson), requestId)
 
 	if err != nil {
 		if err.Error() == http.StatusText(http.StatusNotFound) {
@@ -164,7 +202,12 @@ func (h *handler) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		redirectResponseModel, err = h.databaseservice.HandleRedirect(bytes.NewBuffer(redirectRequestModelJson), requestId)
+		if err.Error() == http.StatusText(http.StatusGone) {
+			h.writeDeleted(w, requestId, err)
+			return
+		}
+
+		redirectResponseModel, err = h.databaseservice.HandleRedirect(r.Context(), bytes.NewBuffer(redirectRequestModelJson), requestId)
 		if err != nil {
 			if err.Error() == http.StatusText(http.StatusNotFound) {
 				h.logger.Errorw("URL not found", zap.String("Request Id", requestId), zap.Error(err))
@@ -172,12 +215,536 @@ func (h *handler) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
+			if err.Error() == http.StatusText(http.StatusGone) {
+				h.writeDeleted(w, requestId, err)
+				return
+			}
+
 			h.logger.Errorw("Error processing redirect request", zap.String("Request Id", requestId), zap.Error(err))
 			http.Error(w, "Something went wrong!", http.StatusInternalServerError)
 			return
 		}
 	}
 
-	http.Redirect(w, r, redirectResponseModel.Url, http.StatusMovedPermanently)
+	h.recorder.Record(vars["url"])
+
+	http.Redirect(w, r, redirectResponseModel.Url, http.StatusFound)
 	h.logger.Infow("Successfully handled redirect request", zap.String("Request Id", requestId))
 }
+
+func (h *handler) HandleListLinks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	query := r.URL.Query()
+
+	h.logger.Infow("Handling list links request", zap.String("Request Id", requestId), zap.Any("query", query))
+
+	listRequestModel := &models.ListRequestModel{
+		Tag:    query.Get("tag"),
+		Query:  query.Get("q"),
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+	}
+
+	if createdAfter := query.Get("created_after"); createdAfter != "" {
+		parsed, err := time.Parse(time.RFC3339, createdAfter)
+
+		if err != nil {
+			h.logger.Errorw("Invalid created_after parameter", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Invalid created_after, expected RFC3339 timestamp", http.StatusBadRequest)
+			return
+		}
+
+		listRequestModel.CreatedAfter = parsed
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+
+		if err != nil || parsed <= 0 {
+			h.logger.Errorw("Invalid limit parameter", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+
+		listRequestModel.Limit = parsed
+	}
+
+	listRequestModelJson, err := json.Marshal(listRequestModel)
+
+	if err != nil {
+		h.logger.Errorw("Error marshalling list request model", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	listResponseModel, err := h.databaseservice.HandleListLinks(r.Context(), bytes.NewBuffer(listRequestModelJson), requestId)
+
+	if err != nil {
+		if err.Error() == http.StatusText(http.StatusBadRequest) {
+			h.logger.Errorw("Invalid list request", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Invalid list request", http.StatusBadRequest)
+			return
+		}
+
+		h.logger.Errorw("Error processing list links request", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	for i := range listResponseModel.Links {
+		listResponseModel.Links[i].ShortUrl = h.config.BaseUrl + "/" + listResponseModel.Links[i].ShortUrlPath
+	}
+
+	jsonBody, err := json.Marshal(listResponseModel)
+
+	if err != nil {
+		h.logger.Errorw("Error marshalling list response model", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonBody)
+
+	h.logger.Infow("Successfully handled list links request", zap.String("Request Id", requestId))
+}
+
+func (h *handler) HandleExportLinks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	query := r.URL.Query()
+
+	h.logger.Infow("Handling export links request", zap.String("Request Id", requestId), zap.Any("query", query))
+
+	format := query.Get("format")
+	if format == "" {
+		format = linkformat.FormatNDJSON
+	}
+
+	if format != linkformat.FormatCSV && format != linkformat.FormatNDJSON {
+		h.logger.Errorw("Unsupported export format", zap.String("Request Id", requestId), zap.String("format", format))
+		http.Error(w, "Unsupported format, expected csv or ndjson", http.StatusBadRequest)
+		return
+	}
+
+	exportRequestModel := &models.ExportRequestModel{
+		Tag:   query.Get("tag"),
+		Owner: query.Get("owner"),
+	}
+
+	exportRequestModelJson, err := json.Marshal(exportRequestModel)
+
+	if err != nil {
+		h.logger.Errorw("Error marshalling export request model", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	stream, err := h.databaseservice.HandleExportLinks(r.Context(), bytes.NewBuffer(exportRequestModelJson), requestId)
+
+	if err != nil {
+		h.logger.Errorw("Error processing export links request", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", linkformat.ContentType(format))
+	w.Header().Set("Content-Disposition", "attachment; filename=\"links."+format+"\"")
+
+	writer, err := linkformat.NewWriter(format, w)
+
+	if err != nil {
+		h.logger.Errorw("Error creating export writer", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	decoder := json.NewDecoder(stream)
+	count := 0
+
+	// The status is sent with the first links, so a failure past that point
+	// aborts the response: the client sees a broken download rather than a
+	// complete looking file missing the remaining links.
+	for {
+		link := models.LinkModel{}
+
+		if err := decoder.Decode(&link); err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			h.logger.Errorw("Error decoding export stream", zap.String("Request Id", requestId), zap.Int("count", count), zap.Error(err))
+			panic(http.ErrAbortHandler)
+		}
+
+		if err := writer.Write(link); err != nil {
+			h.logger.Errorw("Error writing exported link", zap.String("Request Id", requestId), zap.Int("count", count), zap.Error(err))
+			panic(http.ErrAbortHandler)
+		}
+
+		count++
+
+		if count%100 == 0 {
+			writer.Flush()
+
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		h.logger.Errorw("Error flushing export writer", zap.String("Request Id", requestId), zap.Error(err))
+		panic(http.ErrAbortHandler)
+	}
+
+	h.logger.Infow("Successfully handled export links request", zap.String("Request Id", requestId), zap.Int("count", count))
+}
+
+func (h *handler) HandleImportLinks(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = linkformat.FormatNDJSON
+	}
+
+	h.logger.Infow("Handling import links request", zap.String("Request Id", requestId), zap.String("format", format))
+
+	if r.Body == nil {
+		h.logger.Errorw("Empty request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty request body", http.StatusBadRequest)
+		return
+	}
+
+	reader, err := linkformat.NewReader(format, r.Body)
+
+	if err != nil {
+		h.logger.Errorw("Error creating import reader", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	summary := &models.ImportSummaryModel{
+		Rows: []models.ImportRowModel{},
+	}
+
+	batch := []models.LinkModel{}
+	batchRows := []int{}
+	row := 0
+
+	sendBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		defer func() {
+			batch = batch[:0]
+			batchRows = batchRows[:0]
+		}()
+
+		importRequestModelJson, err := json.Marshal(&models.ImportRequestModel{Links: batch})
+
+		if err != nil {
+			return err
+		}
+
+		importResponseModel, err := h.databaseservice.HandleImportLinks(r.Context(), bytes.NewBuffer(importRequestModelJson), requestId)
+
+		if err != nil {
+			for i, link := range batch {
+				summary.AddRow(batchRows[i], link.ShortUrlPath, models.ImportStatusFailed, err.Error())
+			}
+
+			return err
+		}
+
+		for _, result := range importResponseModel.Results {
+			if result.Index < 0 || result.Index >= len(batchRows) {
+				continue
+			}
+
+			summary.AddRow(batchRows[result.Index], result.ShortUrlPath, result.Status, result.Error)
+		}
+
+		return nil
+	}
+
+	for {
+		link, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		row++
+
+		var rowErr *linkformat.RowError
+		if errors.As(err, &rowErr) {
+			summary.AddRow(row, link.ShortUrlPath, models.ImportStatusInvalid, rowErr.Error())
+			continue
+		}
+
+		if err != nil {
+			h.logger.Errorw("Error reading import body", zap.String("Request Id", requestId), zap.Int("row", row), zap.Error(err))
+			writeJSON(w, summary, http.StatusBadRequest)
+			return
+		}
+
+		batch = append(batch, link)
+		batchRows = append(batchRows, row)
+
+		if len(batch) >= importBatchSize {
+			if err := sendBatch(); err != nil {
+				h.logger.Errorw("Error importing batch", zap.String("Request Id", requestId), zap.Int("row", row), zap.Error(err))
+				writeJSON(w, summary, http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	if err := sendBatch(); err != nil {
+		h.logger.Errorw("Error importing batch", zap.String("Request Id", requestId), zap.Int("row", row), zap.Error(err))
+		writeJSON(w, summary, http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, summary, http.StatusOK)
+
+	h.logger.Infow("Successfully handled import links request", zap.String("Request Id", requestId), zap.Int("rows", row), zap.Int("created", summary.Created))
+}
+
+func (h *handler) HandleGetLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["url"]
+
+	h.logger.Infow("Handling get link request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	link, err := h.databaseservice.HandleGetLink(r.Context(), shortUrlPath, requestId)
+
+	if err != nil {
+		h.writeLinkError(w, requestId, err)
+		return
+	}
+
+	link.ShortUrl = h.config.BaseUrl + "/" + link.ShortUrlPath
+
+	writeJSON(w, link, http.StatusOK)
+
+	h.logger.Infow("Successfully handled get link request", zap.String("Request Id", requestId))
+}
+
+func (h *handler) HandleUpdateLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["url"]
+
+	h.logger.Infow("Handling update link request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	if r.Body == nil {
+		h.logger.Errorw("Empty request body", zap.String("Request Id", requestId))
+		http.Error(w, "Empty request body", http.StatusBadRequest)
+		return
+	}
+
+	updateRequestModel := &models.UpdateRequestModel{}
+
+	if err := json.NewDecoder(r.Body).Decode(updateRequestModel); err != nil {
+		h.logger.Errorw("Error unmarshalling request body", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Invalid Request Body", http.StatusBadRequest)
+		return
+	}
+
+	if updateRequestModel.Url != nil {
+		result, err := UrlVerifier.NewVerifier().Verify(*updateRequestModel.Url)
+
+		if err != nil || result == nil || !result.IsURL {
+			h.logger.Errorw("Invalid URL", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Invalid URL", http.StatusBadRequest)
+			return
+		}
+	}
+
+	updateRequestModelJson, err := json.Marshal(updateRequestModel)
+
+	if err != nil {
+		h.logger.Errorw("Error marshalling update request model", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	link, err := h.databaseservice.HandleUpdateLink(r.Context(), shortUrlPath, bytes.NewBuffer(updateRequestModelJson), requestId)
+
+	if err != nil {
+		h.writeLinkError(w, requestId, err)
+		return
+	}
+
+	h.evict(r.Context(), shortUrlPath, requestId)
+
+	link.ShortUrl = h.config.BaseUrl + "/" + link.ShortUrlPath
+
+	writeJSON(w, link, http.StatusOK)
+
+	h.logger.Infow("Successfully handled update link request", zap.String("Request Id", requestId))
+}
+
+func (h *handler) HandleDeleteLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["url"]
+
+	h.logger.Infow("Handling delete link request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	if err := h.databaseservice.HandleDeleteLink(r.Context(), shortUrlPath, requestId); err != nil {
+		h.writeLinkError(w, requestId, err)
+		return
+	}
+
+	h.evict(r.Context(), shortUrlPath, requestId)
+
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Infow("Successfully handled delete link request", zap.String("Request Id", requestId))
+}
+
+// HandleRestoreLink revives a deleted link, as long as the database service
+// has not purged it yet.
+func (h *handler) HandleRestoreLink(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["url"]
+
+	h.logger.Infow("Handling restore link request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	link, err := h.databaseservice.HandleRestoreLink(r.Context(), shortUrlPath, requestId)
+
+	if err != nil {
+		h.writeLinkError(w, requestId, err)
+		return
+	}
+
+	// The cache may still remember the link as deleted.
+	h.evict(r.Context(), shortUrlPath, requestId)
+
+	link.ShortUrl = h.config.BaseUrl + "/" + link.ShortUrlPath
+
+	writeJSON(w, link, http.StatusOK)
+
+	h.logger.Infow("Successfully handled restore link request", zap.String("Request Id", requestId))
+}
+
+func (h *handler) HandleLinkStats(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	shortUrlPath := mux.Vars(r)["url"]
+
+	h.logger.Infow("Handling link stats request", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath))
+
+	days := 0
+
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+
+		if err != nil || parsed <= 0 {
+			h.logger.Errorw("Invalid days parameter", zap.String("Request Id", requestId), zap.Error(err))
+			http.Error(w, "Invalid days", http.StatusBadRequest)
+			return
+		}
+
+		days = parsed
+	}
+
+	stats, err := h.databaseservice.HandleLinkStats(r.Context(), shortUrlPath, days, requestId)
+
+	if err != nil {
+		h.writeLinkError(w, requestId, err)
+		return
+	}
+
+	writeJSON(w, stats, http.StatusOK)
+
+	h.logger.Infow("Successfully handled link stats request", zap.String("Request Id", requestId))
+}
+
+// HandleOpenAPISpec serves the OpenAPI specification shared by all services.
+func (h *handler) HandleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	requestId := r.Header.Get("X-request-id")
+
+	h.logger.Infow("Handling OpenAPI spec request", zap.String("Request Id", requestId))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(api.Spec)
+}
+
+// store caches a newly created link before it is handed out, so its first
+// redirect is a cache hit. A link without an expiry gets the database
+// service's default, which outlasts the cache TTL. A failure is only logged:
+// the link is already stored and is cached on its first redirect instead.
+func (h *handler) store(ctx context.Context, link *models.CacheStoreRequestModel, requestId string) {
+	if err := h.cacheservice.HandleStore(context.WithoutCancel(ctx), link, requestId); err != nil {
+		h.logger.Errorw("Error storing link in cache", zap.String("Request Id", requestId), zap.String("shorturlpath", link.ShortUrlPath), zap.Error(err))
+	}
+}
+
+// evict drops a changed link from the cache. A failure is only logged: the
+// change is already stored and the cached entry expires on its own. The
+// eviction is not cancelled with the request, as the change already is.
+func (h *handler) evict(ctx context.Context, shortUrlPath string, requestId string) {
+	if err := h.cacheservice.HandleEvict(context.WithoutCancel(ctx), shortUrlPath, requestId); err != nil {
+		h.logger.Errorw("Error evicting link from cache", zap.String("Request Id", requestId), zap.String("shorturlpath", shortUrlPath), zap.Error(err))
+	}
+}
+
+func (h *handler) writeLinkError(w http.ResponseWriter, requestId string, err error) {
+	switch err.Error() {
+	case http.StatusText(http.StatusNotFound):
+		h.logger.Errorw("URL not found", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "URL not found", http.StatusNotFound)
+	case http.StatusText(http.StatusBadRequest):
+		h.logger.Errorw("Request rejected by database service", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Invalid Request Body", http.StatusBadRequest)
+	case http.StatusText(http.StatusGone):
+		h.logger.Errorw("URL was deleted", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Link was deleted", http.StatusGone)
+	case http.StatusText(http.StatusConflict):
+		h.logger.Errorw("URL is not deleted", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Link is not deleted", http.StatusConflict)
+	default:
+		h.logger.Errorw("Error processing link request", zap.String("Request Id", requestId), zap.Error(err))
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+	}
+}
+
+// writeDeleted answers a redirect of a deleted link with 410, serving the
+// configured fallback page when there is one.
+func (h *handler) writeDeleted(w http.ResponseWriter, requestId string, err error) {
+	h.logger.Errorw("URL was deleted", zap.String("Request Id", requestId), zap.Error(err))
+
+	if h.deletedPage == nil {
+		http.Error(w, "Link was deleted", http.StatusGone)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusGone)
+	w.Write(h.deletedPage)
+}
+
+func writeJSON(w http.ResponseWriter, response interface{}, statusCode int) {
+	jsonBody, err := json.Marshal(response)
+
+	if err != nil {
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(jsonBody)
+}
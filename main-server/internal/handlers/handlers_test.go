@@ -2,18 +2,24 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	mock_cacheservice "main-server/external/cache-service/mocks"
 	mock_databaseservice "main-server/external/database-service/mocks"
-	mock_config "main-server/internal/config/mocks"
+	mock_clicks "main-server/internal/clicks/mocks"
+	"main-server/internal/config"
 	"main-server/internal/handlers"
 	"main-server/internal/models"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	api "url-shortner-api"
+
 	"github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -23,26 +29,17 @@ import (
 func TestHandleShorten(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
-	mockCtrl := gomock.NewController(t)
-	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
-	mockConfig := mock_config.NewMockConfigInterface(mockCtrl)
-	mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
-
-	mockConfig.EXPECT().Get("BASE_URL").Return("http://localhost:8080").AnyTimes()
-
-	handlers := handlers.NewBaseHandler(logger, mockDbService, mockConfig, mockCacheService)
-
 	tests := map[string]struct {
 		reqBody                  *models.RequestModel
-		HandleShorten            *gomock.Call
 		HandleShortenReturnError error
 		HandleShortenReturnUrl   *models.ShortenResponseModel
 		HandleShortenCallTimes   int
+		HandleStoreReturnError   error
+		HandleStoreCallTimes     int
 		ExpectedStatusCode       int
 	}{
 		"EmptyBody": {
 			reqBody:                  nil,
-			HandleShorten:            mockDbService.EXPECT().HandleShorten(gomock.Any(), gomock.Any()),
 			HandleShortenReturnError: nil,
 			HandleShortenReturnUrl:   nil,
 			HandleShortenCallTimes:   0,
@@ -52,7 +49,6 @@ func TestHandleShorten(t *testing.T) {
 			reqBody: &models.RequestModel{
 				ExpiresAt: time.Now(),
 			},
-			HandleShorten:            mockDbService.EXPECT().HandleShorten(gomock.Any(), gomock.Any()),
 			HandleShortenReturnError: nil,
 			HandleShortenReturnUrl:   nil,
 			HandleShortenCallTimes:   0,
@@ -62,19 +58,18 @@ func TestHandleShorten(t *testing.T) {
 			reqBody: &models.RequestModel{
 				Url: "http://localhost:8080",
 			},
-			HandleShorten:            mockDbService.EXPECT().HandleShorten(gomock.Any(), gomock.Any()),
 			HandleShortenReturnError: nil,
 			HandleShortenReturnUrl: &models.ShortenResponseModel{
 				ShortUrlPath: "http://localhost:8080/abc",
 			},
 			HandleShortenCallTimes: 1,
+			HandleStoreCallTimes:   1,
 			ExpectedStatusCode:     http.StatusOK,
 		},
 		"InvalidUrl": {
 			reqBody: &models.RequestModel{
 				Url: "/test",
 			},
-			HandleShorten:            mockDbService.EXPECT().HandleShorten(gomock.Any(), gomock.Any()),
 			HandleShortenReturnError: nil,
 			HandleShortenReturnUrl:   nil,
 			HandleShortenCallTimes:   0,
@@ -84,30 +79,56 @@ func TestHandleShorten(t *testing.T) {
 			reqBody: &models.RequestModel{
 				Url: "http://localhost:8080",
 			},
-			HandleShorten:            mockDbService.EXPECT().HandleShorten(gomock.Any(), gomock.Any()),
 			HandleShortenReturnError: assert.AnError,
 			HandleShortenReturnUrl:   nil,
 			HandleShortenCallTimes:   1,
 			ExpectedStatusCode:       http.StatusInternalServerError,
 		},
+		"CacheServiceFail": {
+			reqBody: &models.RequestModel{
+				Url: "http://localhost:8080",
+			},
+			HandleShortenReturnError: nil,
+			HandleShortenReturnUrl: &models.ShortenResponseModel{
+				ShortUrlPath: "abc",
+			},
+			HandleShortenCallTimes: 1,
+			HandleStoreReturnError: assert.AnError,
+			HandleStoreCallTimes:   1,
+			ExpectedStatusCode:     http.StatusOK,
+		},
 		"Success": {
 			reqBody: &models.RequestModel{
 				Url:       "http://localhost:8080",
 				ExpiresAt: time.Now().AddDate(1, 0, 0),
 			},
-			HandleShorten:            mockDbService.EXPECT().HandleShorten(gomock.Any(), gomock.Any()),
 			HandleShortenReturnError: nil,
 			HandleShortenReturnUrl: &models.ShortenResponseModel{
 				ShortUrlPath: "http://localhost:8080/abc",
 			},
 			HandleShortenCallTimes: 1,
+			HandleStoreCallTimes:   1,
 			ExpectedStatusCode:     http.StatusOK,
 		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			test.HandleShorten.Return(test.HandleShortenReturnUrl, test.HandleShortenReturnError).Times(test.HandleShortenCallTimes)
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockDbService.EXPECT().HandleShorten(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.HandleShortenReturnUrl, test.HandleShortenReturnError).Times(test.HandleShortenCallTimes)
+			mockCacheService.EXPECT().HandleStore(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, link *models.CacheStoreRequestModel, requestId string) error {
+				assert.Equal(t, test.HandleShortenReturnUrl.ShortUrlPath, link.ShortUrlPath)
+				assert.Equal(t, test.reqBody.Url, link.Url)
+				assert.True(t, test.reqBody.ExpiresAt.Equal(link.ExpiresAt))
+				return test.HandleStoreReturnError
+			}).Times(test.HandleStoreCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
 
 			body, err := json.Marshal(test.reqBody)
 
@@ -126,21 +147,12 @@ func TestHandleShorten(t *testing.T) {
 func TestHandleRedirect(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
-	mockCtrl := gomock.NewController(t)
-	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
-	mockConfig := mock_config.NewMockConfigInterface(mockCtrl)
-	mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
-
-	handlers := handlers.NewBaseHandler(logger, mockDbService, mockConfig, mockCacheService)
-
 	tests := map[string]struct {
 		reqUrl                         string
-		HandleRedirect                 *gomock.Call
 		HandleRedirectReturnError      error
 		HandleRedirectReturnUrl        *models.RedirectResponseModel
 		HandleRedirectCallTimes        int
 		ExpectedStatusCode             int
-		HandleRedirectCache            *gomock.Call
 		HandleRedirectCacheReturnError error
 		HandleRedirectCacheReturnUrl   *models.RedirectResponseModel
 		HandleRedirectCacheCallTimes   int
@@ -148,11 +160,9 @@ func TestHandleRedirect(t *testing.T) {
 	}{
 		"EmptyUrl": {
 			reqUrl:                         "/",
-			HandleRedirect:                 mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError:      nil,
 			HandleRedirectReturnUrl:        nil,
 			HandleRedirectCallTimes:        0,
-			HandleRedirectCache:            mockCacheService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectCacheReturnError: nil,
 			HandleRedirectCacheReturnUrl:   nil,
 			HandleRedirectCacheCallTimes:   0,
@@ -163,11 +173,9 @@ func TestHandleRedirect(t *testing.T) {
 		},
 		"URL Not Found From Cache": {
 			reqUrl:                         "/absdn",
-			HandleRedirect:                 mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError:      errors.New(http.StatusText(http.StatusNotFound)),
 			HandleRedirectReturnUrl:        nil,
 			HandleRedirectCallTimes:        0,
-			HandleRedirectCache:            mockCacheService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectCacheReturnError: errors.New(http.StatusText(http.StatusNotFound)),
 			HandleRedirectCacheReturnUrl:   nil,
 			HandleRedirectCacheCallTimes:   1,
@@ -178,11 +186,9 @@ func TestHandleRedirect(t *testing.T) {
 		},
 		"Cache Fail and URL Not Found in DB": {
 			reqUrl:                         "/absdn",
-			HandleRedirect:                 mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError:      errors.New(http.StatusText(http.StatusNotFound)),
 			HandleRedirectReturnUrl:        &models.RedirectResponseModel{},
 			HandleRedirectCallTimes:        1,
-			HandleRedirectCache:            mockCacheService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectCacheReturnError: assert.AnError,
 			HandleRedirectCacheReturnUrl:   nil,
 			HandleRedirectCacheCallTimes:   1,
@@ -191,13 +197,32 @@ func TestHandleRedirect(t *testing.T) {
 				"url": "absdn",
 			},
 		},
+		"URL Deleted From Cache": {
+			reqUrl:                         "/absdn",
+			HandleRedirectCallTimes:        0,
+			HandleRedirectCacheReturnError: errors.New(http.StatusText(http.StatusGone)),
+			HandleRedirectCacheCallTimes:   1,
+			ExpectedStatusCode:             http.StatusGone,
+			muxVars: map[string]string{
+				"url": "absdn",
+			},
+		},
+		"Cache Fail and URL Deleted in DB": {
+			reqUrl:                         "/absdn",
+			HandleRedirectReturnError:      errors.New(http.StatusText(http.StatusGone)),
+			HandleRedirectCallTimes:        1,
+			HandleRedirectCacheReturnError: assert.AnError,
+			HandleRedirectCacheCallTimes:   1,
+			ExpectedStatusCode:             http.StatusGone,
+			muxVars: map[string]string{
+				"url": "absdn",
+			},
+		},
 		"Cache and Database Services Failed": {
 			reqUrl:                         "/absdn",
-			HandleRedirect:                 mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError:      assert.AnError,
 			HandleRedirectReturnUrl:        nil,
 			HandleRedirectCallTimes:        1,
-			HandleRedirectCache:            mockCacheService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectCacheReturnError: assert.AnError,
 			HandleRedirectCacheReturnUrl:   nil,
 			HandleRedirectCacheCallTimes:   1,
@@ -208,34 +233,30 @@ func TestHandleRedirect(t *testing.T) {
 		},
 		"Success From Cache": {
 			reqUrl:                         "/adksjlkda",
-			HandleRedirect:                 mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError:      nil,
 			HandleRedirectReturnUrl:        nil,
 			HandleRedirectCallTimes:        0,
-			HandleRedirectCache:            mockCacheService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectCacheReturnError: nil,
 			HandleRedirectCacheReturnUrl: &models.RedirectResponseModel{
 				Url: "https://google.com",
 			},
 			HandleRedirectCacheCallTimes: 1,
-			ExpectedStatusCode:           http.StatusMovedPermanently,
+			ExpectedStatusCode:           http.StatusFound,
 			muxVars: map[string]string{
 				"url": "adksjlkda",
 			},
 		},
 		"Success From Database": {
 			reqUrl:                    "/adksjlkda",
-			HandleRedirect:            mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectReturnError: nil,
 			HandleRedirectReturnUrl: &models.RedirectResponseModel{
 				Url: "https://google.com",
 			},
 			HandleRedirectCallTimes:        1,
-			HandleRedirectCache:            mockCacheService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any()),
 			HandleRedirectCacheReturnError: assert.AnError,
 			HandleRedirectCacheReturnUrl:   nil,
 			HandleRedirectCacheCallTimes:   1,
-			ExpectedStatusCode:             http.StatusMovedPermanently,
+			ExpectedStatusCode:             http.StatusFound,
 			muxVars: map[string]string{
 				"url": "adksjlkda",
 			},
@@ -244,14 +265,592 @@ func TestHandleRedirect(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			test.HandleRedirect.Return(test.HandleRedirectReturnUrl, test.HandleRedirectReturnError).Times(test.HandleRedirectCallTimes)
-			test.HandleRedirectCache.Return(test.HandleRedirectCacheReturnUrl, test.HandleRedirectCacheReturnError).Times(test.HandleRedirectCacheCallTimes)
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockRecorder.EXPECT().Record(gomock.Any()).AnyTimes()
+			mockDbService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.HandleRedirectReturnUrl, test.HandleRedirectReturnError).Times(test.HandleRedirectCallTimes)
+			mockCacheService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.HandleRedirectCacheReturnUrl, test.HandleRedirectCacheReturnError).Times(test.HandleRedirectCacheCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
 
 			req := httptest.NewRequest("GET", test.reqUrl, nil)
 			resp := httptest.NewRecorder()
 			req = mux.SetURLVars(req, test.muxVars)
 			handlers.HandleRedirect(resp, req)
 			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode == http.StatusFound {
+				assert.Equal(t, "https://google.com", resp.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestHandleRedirectDeletedPage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+	cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+	mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+	mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+	mockCacheService.EXPECT().HandleRedirect(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(http.StatusText(http.StatusGone)))
+
+	handlers := handlers.NewBaseHandler(zap.NewNop().Sugar(), mockDbService, cfg, mockCacheService, mockRecorder, []byte("<h1>This link was deleted</h1>"))
+
+	req := httptest.NewRequest("GET", "/abc", nil)
+	req = mux.SetURLVars(req, map[string]string{"url": "abc"})
+	resp := httptest.NewRecorder()
+	handlers.HandleRedirect(resp, req)
+
+	assert.Equal(t, http.StatusGone, resp.Code)
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "<h1>This link was deleted</h1>", resp.Body.String())
+}
+
+func TestHandleListLinks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		reqUrl                        string
+		HandleListLinksReturnError    error
+		HandleListLinksReturnResponse *models.ListResponseModel
+		HandleListLinksCallTimes      int
+		ExpectedStatusCode            int
+		ExpectedShortUrl              string
+	}{
+		"Invalid CreatedAfter": {
+			reqUrl:                   "/api/links?created_after=yesterday",
+			HandleListLinksCallTimes: 0,
+			ExpectedStatusCode:       http.StatusBadRequest,
+		},
+		"Invalid Limit": {
+			reqUrl:                   "/api/links?limit=-1",
+			HandleListLinksCallTimes: 0,
+			ExpectedStatusCode:       http.StatusBadRequest,
+		},
+		"Rejected By Database Service": {
+			reqUrl:                     "/api/links?cursor=bad",
+			HandleListLinksReturnError: errors.New(http.StatusText(http.StatusBadRequest)),
+			HandleListLinksCallTimes:   1,
+			ExpectedStatusCode:         http.StatusBadRequest,
+		},
+		"Database Service Fail": {
+			reqUrl:                     "/api/links?tag=marketing",
+			HandleListLinksReturnError: assert.AnError,
+			HandleListLinksCallTimes:   1,
+			ExpectedStatusCode:         http.StatusInternalServerError,
+		},
+		"Success": {
+			reqUrl: "/api/links?tag=marketing&q=launch&created_after=2024-01-01T00:00:00Z&limit=10",
+			HandleListLinksReturnResponse: &models.ListResponseModel{
+				Links: []models.LinkModel{{ShortUrlPath: "abc1234", Url: "https://google.com"}},
+			},
+			HandleListLinksCallTimes: 1,
+			ExpectedStatusCode:       http.StatusOK,
+			ExpectedShortUrl:         "http://localhost:8080/abc1234",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockDbService.EXPECT().HandleListLinks(gomock.Any(), gomock.Any(), gomock.Any()).Return(test.HandleListLinksReturnResponse, test.HandleListLinksReturnError).Times(test.HandleListLinksCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+			req := httptest.NewRequest("GET", test.reqUrl, nil)
+			resp := httptest.NewRecorder()
+			handlers.HandleListLinks(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedShortUrl != "" {
+				response := models.ListResponseModel{}
+				assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+				assert.Equal(t, test.ExpectedShortUrl, response.Links[0].ShortUrl)
+			}
+		})
+	}
+}
+
+func TestHandleExportLinks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	stream := "{\"shorturlpath\":\"abc1234\",\"url\":\"https://google.com\",\"tags\":[\"a\",\"b\"]}\n" +
+		"{\"shorturlpath\":\"xyz7890\",\"url\":\"https://example.com\"}\n"
+
+	tests := map[string]struct {
+		reqUrl                       string
+		stream                       string
+		HandleExportLinksReturnError error
+		HandleExportLinksCallTimes   int
+		ExpectedStatusCode           int
+		ExpectedContentType          string
+		ExpectedBody                 string
+		ExpectedAbort                bool
+	}{
+		"Unsupported Format": {
+			reqUrl:                     "/api/links/export?format=xml",
+			HandleExportLinksCallTimes: 0,
+			ExpectedStatusCode:         http.StatusBadRequest,
+		},
+		"Database Service Fail": {
+			reqUrl:                       "/api/links/export",
+			HandleExportLinksReturnError: assert.AnError,
+			HandleExportLinksCallTimes:   1,
+			ExpectedStatusCode:           http.StatusInternalServerError,
+		},
+		"NDJSON": {
+			reqUrl:                     "/api/links/export?tag=a",
+			HandleExportLinksCallTimes: 1,
+			ExpectedStatusCode:         http.StatusOK,
+			ExpectedContentType:        "application/x-ndjson",
+			ExpectedBody:               "{\"shorturlpath\":\"abc1234\",\"url\":\"https://google.com\",\"title\":\"\",\"note\":\"\",\"tags\":[\"a\",\"b\"],\"owner\":\"\",\"created_at\":\"0001-01-01T00:00:00Z\",\"expires_at\":\"0001-01-01T00:00:00Z\"}\n{\"shorturlpath\":\"xyz7890\",\"url\":\"https://example.com\",\"title\":\"\",\"note\":\"\",\"tags\":null,\"owner\":\"\",\"created_at\":\"0001-01-01T00:00:00Z\",\"expires_at\":\"0001-01-01T00:00:00Z\"}\n",
+		},
+		"CSV": {
+			reqUrl:                     "/api/links/export?format=csv&owner=growth",
+			HandleExportLinksCallTimes: 1,
+			ExpectedStatusCode:         http.StatusOK,
+			ExpectedContentType:        "text/csv",
+			ExpectedBody:               "shorturlpath,url,title,note,tags,owner,created_at,expires_at\nabc1234,https://google.com,,,a|b,,,\nxyz7890,https://example.com,,,,,,\n",
+		},
+		"Truncated Stream": {
+			reqUrl:                     "/api/links/export",
+			stream:                     "{\"shorturlpath\":\"abc1234\",\"url\":\"https://google.com\"}\n{\"shorturlpath\":\"xyz",
+			HandleExportLinksCallTimes: 1,
+			ExpectedAbort:              true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			var body io.ReadCloser
+			if test.stream != "" {
+				body = io.NopCloser(strings.NewReader(test.stream))
+			} else if test.HandleExportLinksReturnError == nil {
+				body = io.NopCloser(strings.NewReader(stream))
+			}
+
+			mockDbService.EXPECT().HandleExportLinks(gomock.Any(), gomock.Any(), gomock.Any()).Return(body, test.HandleExportLinksReturnError).Times(test.HandleExportLinksCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+			req := httptest.NewRequest("GET", test.reqUrl, nil)
+			resp := httptest.NewRecorder()
+
+			if test.ExpectedAbort {
+				assert.PanicsWithValue(t, http.ErrAbortHandler, func() { handlers.HandleExportLinks(resp, req) })
+				return
+			}
+
+			handlers.HandleExportLinks(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode == http.StatusOK {
+				assert.Equal(t, test.ExpectedContentType, resp.Header().Get("Content-Type"))
+				assert.Equal(t, test.ExpectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleImportLinks(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		reqUrl                       string
+		reqBody                      string
+		HandleImportLinksReturnError error
+		HandleImportLinksCallTimes   int
+		ExpectedStatusCode           int
+		ExpectedSummary              *models.ImportSummaryModel
+	}{
+		"Unsupported Format": {
+			reqUrl:                     "/api/links/import?format=xml",
+			reqBody:                    "",
+			HandleImportLinksCallTimes: 0,
+			ExpectedStatusCode:         http.StatusBadRequest,
+		},
+		"Database Service Fail": {
+			reqUrl:                       "/api/links/import",
+			reqBody:                      "{\"url\":\"https://a.com\"}\n",
+			HandleImportLinksReturnError: assert.AnError,
+			HandleImportLinksCallTimes:   1,
+			ExpectedStatusCode:           http.StatusInternalServerError,
+			ExpectedSummary: &models.ImportSummaryModel{
+				Failed: 1,
+				Rows:   []models.ImportRowModel{{Row: 1, Status: models.ImportStatusFailed, Error: assert.AnError.Error()}},
+			},
+		},
+		"CSV With Conflict And Invalid Row": {
+			reqUrl:                     "/api/links/import?format=csv",
+			reqBody:                    "shorturlpath,url,expires_at\nabc,https://a.com,\ntaken,https://b.com,\nbad,https://c.com,never\n",
+			HandleImportLinksCallTimes: 1,
+			ExpectedStatusCode:         http.StatusOK,
+			ExpectedSummary: &models.ImportSummaryModel{
+				Created:   1,
+				Conflicts: 1,
+				Invalid:   1,
+				Rows: []models.ImportRowModel{
+					{Row: 3, ShortUrlPath: "bad", Status: models.ImportStatusInvalid, Error: "invalid expires_at: parsing time \"never\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"never\" as \"2006\""},
+					{Row: 2, ShortUrlPath: "taken", Status: models.ImportStatusConflict, Error: "duplicate short url path"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockDbService.EXPECT().HandleImportLinks(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, body io.Reader, requestId string) (*models.ImportResponseModel, error) {
+				if test.HandleImportLinksReturnError != nil {
+					return nil, test.HandleImportLinksReturnError
+				}
+
+				request := models.ImportRequestModel{}
+				assert.Nil(t, json.NewDecoder(body).Decode(&request))
+
+				response := &models.ImportResponseModel{}
+				for i, link := range request.Links {
+					status := models.ImportStatusCreated
+					errMessage := ""
+					if link.ShortUrlPath == "taken" {
+						status = models.ImportStatusConflict
+						errMessage = "duplicate short url path"
+					}
+					response.Results = append(response.Results, models.ImportResultModel{Index: i, ShortUrlPath: link.ShortUrlPath, Status: status, Error: errMessage})
+				}
+
+				return response, nil
+			}).Times(test.HandleImportLinksCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+			req := httptest.NewRequest("POST", test.reqUrl, strings.NewReader(test.reqBody))
+			resp := httptest.NewRecorder()
+			handlers.HandleImportLinks(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedSummary != nil {
+				summary := &models.ImportSummaryModel{}
+				assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), summary))
+				assert.Equal(t, test.ExpectedSummary, summary)
+			}
+		})
+	}
+}
+
+func TestHandleGetLink(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		HandleGetLinkReturnError error
+		ExpectedStatusCode       int
+		ExpectedShortUrl         string
+	}{
+		"Not Found": {
+			HandleGetLinkReturnError: errors.New(http.StatusText(http.StatusNotFound)),
+			ExpectedStatusCode:       http.StatusNotFound,
+		},
+		"Database Service Failed": {
+			HandleGetLinkReturnError: assert.AnError,
+			ExpectedStatusCode:       http.StatusInternalServerError,
+		},
+		"Success": {
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedShortUrl:   "http://localhost:8080/abc",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockDbService.EXPECT().HandleGetLink(gomock.Any(), "abc", gomock.Any()).Return(&models.LinkModel{ShortUrlPath: "abc"}, test.HandleGetLinkReturnError)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+			req := httptest.NewRequest("GET", "/api/links/abc", nil)
+			req = mux.SetURLVars(req, map[string]string{"url": "abc"})
+			resp := httptest.NewRecorder()
+			handlers.HandleGetLink(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedStatusCode == http.StatusOK {
+				link := models.LinkModel{}
+				assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &link))
+				assert.Equal(t, test.ExpectedShortUrl, link.ShortUrl)
+			}
 		})
 	}
 }
+
+func TestHandleUpdateLink(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		reqBody                     string
+		HandleUpdateLinkReturnError error
+		HandleUpdateLinkCallTimes   int
+		HandleEvictReturnError      error
+		HandleEvictCallTimes        int
+		ExpectedStatusCode          int
+	}{
+		"Invalid Body": {
+			reqBody:            "{",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Invalid Url": {
+			reqBody:            `{"url":"/test"}`,
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Not Found": {
+			reqBody:                     `{"title":"New title"}`,
+			HandleUpdateLinkReturnError: errors.New(http.StatusText(http.StatusNotFound)),
+			HandleUpdateLinkCallTimes:   1,
+			ExpectedStatusCode:          http.StatusNotFound,
+		},
+		"Rejected By Database Service": {
+			reqBody:                     `{}`,
+			HandleUpdateLinkReturnError: errors.New(http.StatusText(http.StatusBadRequest)),
+			HandleUpdateLinkCallTimes:   1,
+			ExpectedStatusCode:          http.StatusBadRequest,
+		},
+		"Eviction Failed": {
+			reqBody:                   `{"url":"https://www.google.com"}`,
+			HandleUpdateLinkCallTimes: 1,
+			HandleEvictReturnError:    assert.AnError,
+			HandleEvictCallTimes:      1,
+			ExpectedStatusCode:        http.StatusOK,
+		},
+		"Success": {
+			reqBody:                   `{"url":"https://www.google.com","tags":["go"]}`,
+			HandleUpdateLinkCallTimes: 1,
+			HandleEvictCallTimes:      1,
+			ExpectedStatusCode:        http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockDbService.EXPECT().HandleUpdateLink(gomock.Any(), "abc", gomock.Any(), gomock.Any()).Return(&models.LinkModel{ShortUrlPath: "abc"}, test.HandleUpdateLinkReturnError).Times(test.HandleUpdateLinkCallTimes)
+			mockCacheService.EXPECT().HandleEvict(gomock.Any(), "abc", gomock.Any()).Return(test.HandleEvictReturnError).Times(test.HandleEvictCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+			req := httptest.NewRequest("PATCH", "/api/links/abc", strings.NewReader(test.reqBody))
+			req = mux.SetURLVars(req, map[string]string{"url": "abc"})
+			resp := httptest.NewRecorder()
+			handlers.HandleUpdateLink(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+		})
+	}
+}
+
+func TestHandleDeleteLink(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		HandleDeleteLinkReturnError error
+		HandleEvictCallTimes        int
+		ExpectedStatusCode          int
+	}{
+		"Not Found": {
+			HandleDeleteLinkReturnError: errors.New(http.StatusText(http.StatusNotFound)),
+			ExpectedStatusCode:          http.StatusNotFound,
+		},
+		"Already Deleted": {
+			HandleDeleteLinkReturnError: errors.New(http.StatusText(http.StatusGone)),
+			ExpectedStatusCode:          http.StatusGone,
+		},
+		"Database Service Failed": {
+			HandleDeleteLinkReturnError: assert.AnError,
+			ExpectedStatusCode:          http.StatusInternalServerError,
+		},
+		"Success": {
+			HandleEvictCallTimes: 1,
+			ExpectedStatusCode:   http.StatusNoContent,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockDbService.EXPECT().HandleDeleteLink(gomock.Any(), "abc", gomock.Any()).Return(test.HandleDeleteLinkReturnError)
+			mockCacheService.EXPECT().HandleEvict(gomock.Any(), "abc", gomock.Any()).Return(nil).Times(test.HandleEvictCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+			req := httptest.NewRequest("DELETE", "/api/links/abc", nil)
+			req = mux.SetURLVars(req, map[string]string{"url": "abc"})
+			resp := httptest.NewRecorder()
+			handlers.HandleDeleteLink(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+		})
+	}
+}
+
+func TestHandleRestoreLink(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		HandleRestoreLinkReturnLink  *models.LinkModel
+		HandleRestoreLinkReturnError error
+		HandleEvictCallTimes         int
+		ExpectedStatusCode           int
+		ExpectedShortUrl             string
+	}{
+		"Not Found": {
+			HandleRestoreLinkReturnError: errors.New(http.StatusText(http.StatusNotFound)),
+			ExpectedStatusCode:           http.StatusNotFound,
+		},
+		"Not Deleted": {
+			HandleRestoreLinkReturnError: errors.New(http.StatusText(http.StatusConflict)),
+			ExpectedStatusCode:           http.StatusConflict,
+		},
+		"Deleted Too Long Ago": {
+			HandleRestoreLinkReturnError: errors.New(http.StatusText(http.StatusGone)),
+			ExpectedStatusCode:           http.StatusGone,
+		},
+		"Database Service Failed": {
+			HandleRestoreLinkReturnError: assert.AnError,
+			ExpectedStatusCode:           http.StatusInternalServerError,
+		},
+		"Success": {
+			HandleRestoreLinkReturnLink: &models.LinkModel{ShortUrlPath: "abc", Url: "https://google.com"},
+			HandleEvictCallTimes:        1,
+			ExpectedStatusCode:          http.StatusOK,
+			ExpectedShortUrl:            "http://localhost:8080/abc",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockDbService.EXPECT().HandleRestoreLink(gomock.Any(), "abc", gomock.Any()).Return(test.HandleRestoreLinkReturnLink, test.HandleRestoreLinkReturnError)
+			mockCacheService.EXPECT().HandleEvict(gomock.Any(), "abc", gomock.Any()).Return(nil).Times(test.HandleEvictCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+			req := httptest.NewRequest("POST", "/api/links/abc/restore", nil)
+			req = mux.SetURLVars(req, map[string]string{"url": "abc"})
+			resp := httptest.NewRecorder()
+			handlers.HandleRestoreLink(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+
+			if test.ExpectedShortUrl != "" {
+				response := models.LinkModel{}
+				assert.Nil(t, json.Unmarshal(resp.Body.Bytes(), &response))
+				assert.Equal(t, test.ExpectedShortUrl, response.ShortUrl)
+			}
+		})
+	}
+}
+
+func TestHandleLinkStats(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	tests := map[string]struct {
+		reqUrl                     string
+		HandleLinkStatsDays        int
+		HandleLinkStatsReturnError error
+		HandleLinkStatsCallTimes   int
+		ExpectedStatusCode         int
+	}{
+		"Invalid Days": {
+			reqUrl:             "/api/links/abc/stats?days=abc",
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"Not Found": {
+			reqUrl:                     "/api/links/abc/stats",
+			HandleLinkStatsReturnError: errors.New(http.StatusText(http.StatusNotFound)),
+			HandleLinkStatsCallTimes:   1,
+			ExpectedStatusCode:         http.StatusNotFound,
+		},
+		"Success": {
+			reqUrl:                   "/api/links/abc/stats?days=7",
+			HandleLinkStatsDays:      7,
+			HandleLinkStatsCallTimes: 1,
+			ExpectedStatusCode:       http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+			cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+			mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+			mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+			mockDbService.EXPECT().HandleLinkStats(gomock.Any(), "abc", test.HandleLinkStatsDays, gomock.Any()).Return(&models.StatsResponseModel{ShortUrlPath: "abc"}, test.HandleLinkStatsReturnError).Times(test.HandleLinkStatsCallTimes)
+
+			handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+			req := httptest.NewRequest("GET", test.reqUrl, nil)
+			req = mux.SetURLVars(req, map[string]string{"url": "abc"})
+			resp := httptest.NewRecorder()
+			handlers.HandleLinkStats(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code, resp.Result().Status)
+		})
+	}
+}
+
+func TestHandleOpenAPISpec(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	mockCtrl := gomock.NewController(t)
+	mockDbService := mock_databaseservice.NewMockDatabaseServiceInterface(mockCtrl)
+	cfg := &config.Config{BaseUrl: "http://localhost:8080"}
+	mockCacheService := mock_cacheservice.NewMockCacheServiceInterface(mockCtrl)
+	mockRecorder := mock_clicks.NewMockRecorderInterface(mockCtrl)
+
+	handlers := handlers.NewBaseHandler(logger, mockDbService, cfg, mockCacheService, mockRecorder, nil)
+
+	req := httptest.NewRequest("GET", "/openapi.json", nil)
+	resp := httptest.NewRecorder()
+	handlers.HandleOpenAPISpec(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	assert.Equal(t, api.Spec, resp.Body.Bytes())
+}
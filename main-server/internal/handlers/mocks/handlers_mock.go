@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: D:\Programming\Go\url-shortner\main-server\internal\handlers\handlers.go
+// Source: internal/handlers/handlers.go
 
 // Package mock_handlers is a generated GoMock package.
 package mock_handlers
@@ -34,6 +34,90 @@ func (m *MockHandlerInterface) EXPECT() *MockHandlerInterfaceMockRecorder {
 	return m.recorder
 }
 
+// HandleDeleteLink mocks base method.
+func (m *MockHandlerInterface) HandleDeleteLink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleDeleteLink", w, r)
+}
+
+// HandleDeleteLink indicates an expected call of HandleDeleteLink.
+func (mr *MockHandlerInterfaceMockRecorder) HandleDeleteLink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeleteLink", reflect.TypeOf((*MockHandlerInterface)(nil).HandleDeleteLink), w, r)
+}
+
+// HandleExportLinks mocks base method.
+func (m *MockHandlerInterface) HandleExportLinks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleExportLinks", w, r)
+}
+
+// HandleExportLinks indicates an expected call of HandleExportLinks.
+func (mr *MockHandlerInterfaceMockRecorder) HandleExportLinks(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleExportLinks", reflect.TypeOf((*MockHandlerInterface)(nil).HandleExportLinks), w, r)
+}
+
+// HandleGetLink mocks base method.
+func (m *MockHandlerInterface) HandleGetLink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleGetLink", w, r)
+}
+
+// HandleGetLink indicates an expected call of HandleGetLink.
+func (mr *MockHandlerInterfaceMockRecorder) HandleGetLink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleGetLink", reflect.TypeOf((*MockHandlerInterface)(nil).HandleGetLink), w, r)
+}
+
+// HandleImportLinks mocks base method.
+func (m *MockHandlerInterface) HandleImportLinks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleImportLinks", w, r)
+}
+
+// HandleImportLinks indicates an expected call of HandleImportLinks.
+func (mr *MockHandlerInterfaceMockRecorder) HandleImportLinks(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleImportLinks", reflect.TypeOf((*MockHandlerInterface)(nil).HandleImportLinks), w, r)
+}
+
+// HandleLinkStats mocks base method.
+func (m *MockHandlerInterface) HandleLinkStats(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleLinkStats", w, r)
+}
+
+// HandleLinkStats indicates an expected call of HandleLinkStats.
+func (mr *MockHandlerInterfaceMockRecorder) HandleLinkStats(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleLinkStats", reflect.TypeOf((*MockHandlerInterface)(nil).HandleLinkStats), w, r)
+}
+
+// HandleListLinks mocks base method.
+func (m *MockHandlerInterface) HandleListLinks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleListLinks", w, r)
+}
+
+// HandleListLinks indicates an expected call of HandleListLinks.
+func (mr *MockHandlerInterfaceMockRecorder) HandleListLinks(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleListLinks", reflect.TypeOf((*MockHandlerInterface)(nil).HandleListLinks), w, r)
+}
+
+// HandleOpenAPISpec mocks base method.
+func (m *MockHandlerInterface) HandleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleOpenAPISpec", w, r)
+}
+
+// HandleOpenAPISpec indicates an expected call of HandleOpenAPISpec.
+func (mr *MockHandlerInterfaceMockRecorder) HandleOpenAPISpec(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleOpenAPISpec", reflect.TypeOf((*MockHandlerInterface)(nil).HandleOpenAPISpec), w, r)
+}
+
 // HandleRedirect mocks base method.
 func (m *MockHandlerInterface) HandleRedirect(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -46,6 +130,18 @@ func (mr *MockHandlerInterfaceMockRecorder) HandleRedirect(w, r interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRedirect", reflect.TypeOf((*MockHandlerInterface)(nil).HandleRedirect), w, r)
 }
 
+// HandleRestoreLink mocks base method.
+func (m *MockHandlerInterface) HandleRestoreLink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleRestoreLink", w, r)
+}
+
+// HandleRestoreLink indicates an expected call of HandleRestoreLink.
+func (mr *MockHandlerInterfaceMockRecorder) HandleRestoreLink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRestoreLink", reflect.TypeOf((*MockHandlerInterface)(nil).HandleRestoreLink), w, r)
+}
+
 // HandleShorten mocks base method.
 func (m *MockHandlerInterface) HandleShorten(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -57,3 +153,15 @@ func (mr *MockHandlerInterfaceMockRecorder) HandleShorten(w, r interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleShorten", reflect.TypeOf((*MockHandlerInterface)(nil).HandleShorten), w, r)
 }
+
+// HandleUpdateLink mocks base method.
+func (m *MockHandlerInterface) HandleUpdateLink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleUpdateLink", w, r)
+}
+
+// HandleUpdateLink indicates an expected call of HandleUpdateLink.
+func (mr *MockHandlerInterfaceMockRecorder) HandleUpdateLink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleUpdateLink", reflect.TypeOf((*MockHandlerInterface)(nil).HandleUpdateLink), w, r)
+}
@@ -0,0 +1,232 @@
+package linkformat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"main-server/internal/models"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+
+	tagSeparator = "|"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+var csvHeader = []string{"shorturlpath", "url", "title", "note", "tags", "owner", "created_at", "expires_at"}
+
+// RowError is returned by a Reader when a single row could not be parsed.
+// Reading can continue with the next row.
+type RowError struct {
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+type Writer interface {
+	Write(link models.LinkModel) error
+	Flush() error
+}
+
+type Reader interface {
+	Read() (models.LinkModel, error)
+}
+
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv"
+	}
+
+	return "application/x-ndjson"
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		writer := &csvWriter{writer: csv.NewWriter(w)}
+		return writer, writer.writer.Write(csvHeader)
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func NewReader(format string, r io.Reader) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCsvReader(r)
+	case FormatNDJSON:
+		return &ndjsonReader{reader: bufio.NewReader(r)}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvWriter) Write(link models.LinkModel) error {
+	return c.writer.Write([]string{
+		link.ShortUrlPath,
+		link.Url,
+		link.Title,
+		link.Note,
+		strings.Join(link.Tags, tagSeparator),
+		link.Owner,
+		formatTime(link.CreatedAt),
+		formatTime(link.ExpiresAt),
+	})
+}
+
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(link models.LinkModel) error {
+	return n.encoder.Encode(link)
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return nil
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCsvReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	if _, ok := columns["url"]; !ok {
+		return nil, errors.New("csv header is missing the url column")
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (c *csvReader) Read() (models.LinkModel, error) {
+	link := models.LinkModel{}
+
+	record, err := c.reader.Read()
+
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return link, &RowError{Err: err}
+		}
+
+		return link, err
+	}
+
+	field := func(name string) string {
+		i, ok := c.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	link.ShortUrlPath = field("shorturlpath")
+	link.Url = field("url")
+	link.Title = field("title")
+	link.Note = field("note")
+	link.Owner = field("owner")
+
+	if tags := field("tags"); tags != "" {
+		link.Tags = strings.Split(tags, tagSeparator)
+	}
+
+	if link.CreatedAt, err = parseTime(field("created_at")); err != nil {
+		return link, &RowError{Err: fmt.Errorf("invalid created_at: %w", err)}
+	}
+
+	if link.ExpiresAt, err = parseTime(field("expires_at")); err != nil {
+		return link, &RowError{Err: fmt.Errorf("invalid expires_at: %w", err)}
+	}
+
+	return link, nil
+}
+
+type ndjsonReader struct {
+	reader *bufio.Reader
+}
+
+func (n *ndjsonReader) Read() (models.LinkModel, error) {
+	link := models.LinkModel{}
+
+	for {
+		line, err := n.reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+
+		if len(line) == 0 {
+			if err != nil {
+				return link, err
+			}
+
+			continue
+		}
+
+		if err != nil && err != io.EOF {
+			return link, err
+		}
+
+		if jsonErr := json.Unmarshal(line, &link); jsonErr != nil {
+			return link, &RowError{Err: jsonErr}
+		}
+
+		return link, nil
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,111 @@
+package linkformat_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"main-server/internal/linkformat"
+	"main-server/internal/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	links := []models.LinkModel{
+		{
+			ShortUrlPath: "abc1234",
+			Url:          "https://www.google.com/?q=a,b",
+			Title:        "Search, \"quoted\"",
+			Note:         "multi\nline",
+			Tags:         []string{"marketing", "q3"},
+			Owner:        "growth",
+			CreatedAt:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			ExpiresAt:    time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ShortUrlPath: "xyz7890",
+			Url:          "https://example.com",
+		},
+	}
+
+	for _, format := range []string{linkformat.FormatCSV, linkformat.FormatNDJSON} {
+		t.Run(format, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+
+			writer, err := linkformat.NewWriter(format, buffer)
+			assert.Nil(t, err)
+
+			for _, link := range links {
+				assert.Nil(t, writer.Write(link))
+			}
+			assert.Nil(t, writer.Flush())
+
+			reader, err := linkformat.NewReader(format, buffer)
+			assert.Nil(t, err)
+
+			for _, expected := range links {
+				actual, err := reader.Read()
+				assert.Nil(t, err)
+				assert.Equal(t, expected.ShortUrlPath, actual.ShortUrlPath)
+				assert.Equal(t, expected.Url, actual.Url)
+				assert.Equal(t, expected.Title, actual.Title)
+				assert.Equal(t, expected.Note, actual.Note)
+				assert.Equal(t, expected.Tags, actual.Tags)
+				assert.Equal(t, expected.Owner, actual.Owner)
+				assert.True(t, expected.CreatedAt.Equal(actual.CreatedAt))
+				assert.True(t, expected.ExpiresAt.Equal(actual.ExpiresAt))
+			}
+
+			_, err = reader.Read()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestReaderRowErrors(t *testing.T) {
+	tests := map[string]struct {
+		format string
+		input  string
+	}{
+		"CSV Invalid Time": {
+			format: linkformat.FormatCSV,
+			input:  "url,expires_at\nhttps://a.com,tomorrow\nhttps://b.com,\n",
+		},
+		"NDJSON Invalid Line": {
+			format: linkformat.FormatNDJSON,
+			input:  "{not json}\n\n{\"url\":\"https://b.com\"}",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reader, err := linkformat.NewReader(test.format, strings.NewReader(test.input))
+			assert.Nil(t, err)
+
+			_, err = reader.Read()
+			var rowErr *linkformat.RowError
+			assert.True(t, errors.As(err, &rowErr), "Expected a row error")
+
+			link, err := reader.Read()
+			assert.Nil(t, err)
+			assert.Equal(t, "https://b.com", link.Url)
+
+			_, err = reader.Read()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	_, err := linkformat.NewWriter("xml", &bytes.Buffer{})
+	assert.Equal(t, linkformat.ErrUnsupportedFormat, err)
+
+	_, err = linkformat.NewReader("xml", strings.NewReader(""))
+	assert.Equal(t, linkformat.ErrUnsupportedFormat, err)
+
+	_, err = linkformat.NewReader(linkformat.FormatCSV, strings.NewReader("shorturlpath,title\n"))
+	assert.NotNil(t, err, "CSV without url column should be rejected")
+}
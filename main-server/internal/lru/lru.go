@@ -0,0 +1,101 @@
+// Package lru is a size bounded, in-memory cache whose entries also expire
+// after a TTL. When it is full, the least recently used entry makes room.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+type Cache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// New returns a cache holding up to size entries.
+func New[V any](size int) *Cache[V] {
+	return &Cache[V]{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Get returns the value of key, unless it is missing or expired.
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+
+	element, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	cached := element.Value.(*entry[V])
+
+	if !time.Now().Before(cached.expires) {
+		c.remove(element)
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+
+	return cached.value, true
+}
+
+// Set stores value for key until ttl has passed.
+func (c *Cache[V]) Set(key string, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(ttl)
+
+	if element, ok := c.entries[key]; ok {
+		cached := element.Value.(*entry[V])
+		cached.value = value
+		cached.expires = expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value, expires: expires})
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete removes key, e.g. when the value it caches has changed.
+func (c *Cache[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Len returns the number of entries, including expired ones that were not
+// looked up since.
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[V]).key)
+}
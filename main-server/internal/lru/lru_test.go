@@ -0,0 +1,70 @@
+package lru_test
+
+import (
+	"main-server/internal/lru"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	cache := lru.New[string](2)
+
+	_, ok := cache.Get("abc")
+	assert.False(t, ok, "missing key")
+
+	cache.Set("abc", "https://google.com", time.Minute)
+
+	value, ok := cache.Get("abc")
+	assert.True(t, ok)
+	assert.Equal(t, "https://google.com", value)
+
+	cache.Set("abc", "https://example.com", time.Minute)
+
+	value, _ = cache.Get("abc")
+	assert.Equal(t, "https://example.com", value, "set replaces the value")
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestExpiry(t *testing.T) {
+	cache := lru.New[string](2)
+
+	cache.Set("abc", "https://google.com", 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	_, ok := cache.Get("abc")
+	assert.False(t, ok, "expired key")
+	assert.Equal(t, 0, cache.Len(), "expired entries are dropped when looked up")
+}
+
+func TestEviction(t *testing.T) {
+	cache := lru.New[string](2)
+
+	cache.Set("a", "1", time.Minute)
+	cache.Set("b", "2", time.Minute)
+	cache.Get("a")
+	cache.Set("c", "3", time.Minute)
+
+	_, ok := cache.Get("b")
+	assert.False(t, ok, "the least recently used entry is evicted")
+
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestDelete(t *testing.T) {
+	cache := lru.New[string](2)
+
+	cache.Set("abc", "https://google.com", time.Minute)
+	cache.Delete("abc")
+	cache.Delete("missing")
+
+	_, ok := cache.Get("abc")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
@@ -0,0 +1,38 @@
+// Package metrics holds the Prometheus collectors of the main server. They
+// are registered with the default registry and served at /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// HTTPRequests counts handled requests by route template, method and
+	// status code.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests handled.",
+	}, []string{"route", "method", "status"})
+
+	// HTTPRequestDuration observes how long requests take, with the same
+	// labels as HTTPRequests.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle HTTP requests.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// LocalCacheLookups counts the redirect lookups of the in-process cache
+	// by result: "hit", "negative_hit" for a cached 404, or "miss".
+	LocalCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "local_cache_lookups_total",
+		Help: "Number of redirect lookups in the in-process cache.",
+	}, []string{"result"})
+
+	// LocalCacheEntries is the number of links held by the in-process cache.
+	LocalCacheEntries = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "local_cache_entries",
+		Help: "Number of links held in the in-process cache.",
+	})
+)
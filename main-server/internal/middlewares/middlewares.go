@@ -1,8 +1,12 @@
 package middlewares
 
 import (
+	"main-server/internal/apikeys"
 	"main-server/internal/utils"
 	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 func LoggingMiddleware(h http.Handler) http.Handler {
@@ -11,3 +15,25 @@ func LoggingMiddleware(h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
+
+// ApiKeyMiddleware rejects requests without one of the API keys, sent as
+// "Authorization: Bearer <key>" or in the X-API-Key header.
+func ApiKeyMiddleware(keys apikeys.StoreInterface) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("X-API-Key")
+
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				key = strings.TrimSpace(bearer)
+			}
+
+			if _, ok := keys.Verify(key); !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Invalid or missing API key", http.StatusUnauthorized)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
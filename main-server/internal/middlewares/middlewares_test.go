@@ -0,0 +1,58 @@
+package middlewares_test
+
+import (
+	"main-server/internal/apikeys"
+	"main-server/internal/middlewares"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeyMiddleware(t *testing.T) {
+	keys, err := apikeys.NewStore("alice:" + apikeys.Hash("secret"))
+	assert.Nil(t, err)
+
+	tests := map[string]struct {
+		headers            map[string]string
+		ExpectedStatusCode int
+	}{
+		"Missing Key": {
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		"Invalid Key": {
+			headers:            map[string]string{"X-API-Key": "guess"},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		"Basic Authorization": {
+			headers:            map[string]string{"Authorization": "Basic secret"},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		"Api Key Header": {
+			headers:            map[string]string{"X-API-Key": "secret"},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"Bearer Token": {
+			headers:            map[string]string{"Authorization": "Bearer secret"},
+			ExpectedStatusCode: http.StatusOK,
+		},
+	}
+
+	handler := middlewares.ApiKeyMiddleware(keys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/links", nil)
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			assert.Equal(t, test.ExpectedStatusCode, resp.Code)
+		})
+	}
+}
@@ -1,29 +1,35 @@
 package models
 
-import "time"
+import api "url-shortner-api"
 
-type RequestModel struct {
-	Url       string    `json:"url"`
-	ExpiresAt time.Time `json:"expires_at"`
-}
+// The request and response models are generated from the OpenAPI
+// specification in the api module and shared with the other services.
+type (
+	RequestModel             = api.ShortenRequestModel
+	ResponseModel            = api.ShortenResponseModel
+	ShortenRequestModel      = api.ShortenRequestModel
+	ShortenResponseModel     = api.ShortenResponseModel
+	RedirectRequestModel     = api.RedirectRequestModel
+	RedirectResponseModel    = api.RedirectResponseModel
+	ListRequestModel         = api.ListRequestModel
+	LinkModel                = api.LinkModel
+	ListResponseModel        = api.ListResponseModel
+	ExportRequestModel       = api.ExportRequestModel
+	ImportRequestModel       = api.ImportRequestModel
+	ImportResultModel        = api.ImportResultModel
+	ImportResponseModel      = api.ImportResponseModel
+	ImportRowModel           = api.ImportRowModel
+	ImportSummaryModel       = api.ImportSummaryModel
+	UpdateRequestModel       = api.UpdateRequestModel
+	RecordClicksRequestModel = api.RecordClicksRequestModel
+	DailyClicksModel         = api.DailyClicksModel
+	StatsResponseModel       = api.StatsResponseModel
+	CacheStoreRequestModel   = api.CacheStoreRequestModel
+)
 
-type ShortenRequestModel struct {
-	Url       string    `json:"url"`
-	ExpiresAt time.Time `json:"expires_at"`
-}
-
-type ShortenResponseModel struct {
-	ShortUrlPath string `json:"shorturlpath"`
-}
-
-type RedirectRequestModel struct {
-	ShortUrlPath string `json:"shorturlpath"`
-}
-
-type RedirectResponseModel struct {
-	Url string `json:"redirecturl"`
-}
-
-type ResponseModel struct {
-	Url string `json:"url"`
-}
+const (
+	ImportStatusCreated  = api.ImportStatusCreated
+	ImportStatusConflict = api.ImportStatusConflict
+	ImportStatusInvalid  = api.ImportStatusInvalid
+	ImportStatusFailed   = api.ImportStatusFailed
+)
@@ -1,21 +1,53 @@
 package main
 
 import (
+	"context"
+	"errors"
 	cacheservice "main-server/external/cache-service"
 	databaseservice "main-server/external/database-service"
+	"main-server/internal/apikeys"
+	"main-server/internal/clicks"
 	"main-server/internal/config"
+	"main-server/internal/dashboard"
 	"main-server/internal/handlers"
 	"main-server/internal/logging"
+	"main-server/internal/metrics"
 	"main-server/internal/middlewares"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 
-	kafka "github.com/cursed-ninja/go-kafka-producer"
+	"url-shortner-api/health"
+	"url-shortner-api/httpmiddleware"
+	"url-shortner-api/kafkalog"
+	"url-shortner-api/resilience"
+	"url-shortner-api/settings"
+	"url-shortner-api/tlsconfig"
+	"url-shortner-api/tracing"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+const (
+	// clickFlushInterval is how often recorded redirects are sent to the
+	// database service.
+	clickFlushInterval = 10 * time.Second
+	// shutdownTimeout is how long in-flight requests may take to finish once
+	// the service is asked to stop.
+	shutdownTimeout = 20 * time.Second
 )
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	initialLogger, err := zap.NewDevelopment()
 
 	if err != nil {
@@ -24,12 +56,13 @@ func main() {
 
 	logger := initialLogger.Sugar()
 
-	config, err := config.NewConfig(logger)
+	cfg, source, err := config.Load(os.Args[1:])
 	if err != nil {
 		logger.Fatalw("Could not load config", zap.Error(err))
 	}
 
-	producer := kafka.NewKafkaProducer([]string{config.Get("KAFKA_SERVICE_BASE_URL")}, "main-server", true)
+	producer := kafkalog.NewWriter(cfg.KafkaBrokers, "main-server")
+	defer producer.Close()
 
 	logger, err = logging.NewLogger(producer)
 	if err != nil {
@@ -37,15 +70,173 @@ func main() {
 	}
 	defer logger.Sync()
 
-	databaseservice := databaseservice.NewDatabaseService(config, logger)
-	cacheservice := cacheservice.NewCacheService(config, logger)
+	logger.Infow("Loaded config", zap.Any("config", settings.Redacted(cfg)))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "main-server", cfg.OtlpEndpoint)
+	if err != nil {
+		logger.Fatalw("Could not set up tracing", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
+
+	// The main service is public, so its clients are not asked for a
+	// certificate; the database and cache services verify its own.
+	tlsConfig, err := tlsconfig.Load(cfg.TlsFiles(), false, logger)
+	if err != nil {
+		logger.Fatalw("Could not load TLS certificates", zap.Error(err))
+	}
+	defer tlsConfig.Close()
+
+	var databaseService databaseservice.DatabaseServiceInterface
+	var cacheService cacheservice.CacheServiceInterface
+
+	switch cfg.ServiceTransport {
+	case "grpc":
+		databaseConn, err := grpc.NewClient(cfg.DatabaseServiceGrpcAddr, tlsConfig.DialOption(), grpc.WithStatsHandler(otelgrpc.NewClientHandler()), grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(resilience.DefaultConfig("database-service-grpc"))))
+		if err != nil {
+			logger.Fatalw("Could not create database service client", zap.Error(err))
+		}
+		defer databaseConn.Close()
+
+		databaseService, err = databaseservice.NewGrpcDatabaseService(cfg, tlsConfig.Client, logger, databaseConn)
+		if err != nil {
+			logger.Fatalw("Could not create database service client", zap.Error(err))
+		}
+
+		cacheConn, err := grpc.NewClient(cfg.CacheServiceGrpcAddr, tlsConfig.DialOption(), grpc.WithStatsHandler(otelgrpc.NewClientHandler()), grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(resilience.DefaultConfig("cache-service-grpc"))))
+		if err != nil {
+			logger.Fatalw("Could not create cache service client", zap.Error(err))
+		}
+		defer cacheConn.Close()
+
+		cacheService, err = cacheservice.NewGrpcCacheService(cfg, tlsConfig.Client, logger, cacheConn)
+		if err != nil {
+			logger.Fatalw("Could not create cache service client", zap.Error(err))
+		}
+	default:
+		databaseService, err = databaseservice.NewDatabaseService(cfg, tlsConfig.Client, logger)
+		if err != nil {
+			logger.Fatalw("Could not create database service client", zap.Error(err))
+		}
+
+		cacheService, err = cacheservice.NewCacheService(cfg, tlsConfig.Client, logger)
+		if err != nil {
+			logger.Fatalw("Could not create cache service client", zap.Error(err))
+		}
+	}
+
+	// Hot links are served from memory unless LOCAL_CACHE_SIZE is 0.
+	if cfg.LocalCacheSize > 0 && cfg.LocalCacheTtl > 0 {
+		cacheService = cacheservice.NewLocalCacheService(cacheService, cfg.LocalCacheSize, cfg.LocalCacheTtl, cfg.LocalCacheNegativeTtl, logger)
+	}
+
+	recorder := clicks.NewRecorder(databaseService, logger)
+	go recorder.Run(ctx, clickFlushInterval)
+
+	deletedPage, err := cfg.DeletedPage()
+	if err != nil {
+		logger.Fatalw("Could not read the deleted link page", zap.Error(err))
+	}
+
+	handlers := handlers.NewBaseHandler(logger, databaseService, cfg, cacheService, recorder, deletedPage)
 
-	handlers := handlers.NewBaseHandler(logger, databaseservice, config, cacheservice)
+	keys, err := apikeys.NewStore(cfg.ApiKeys)
+	if err != nil {
+		logger.Fatalw("Could not load API keys", zap.Error(err))
+	}
+
+	dashboard, err := dashboard.NewDashboard(logger, databaseService, cacheService, cfg, keys)
+	if err != nil {
+		logger.Fatalw("Could not create dashboard", zap.Error(err))
+	}
+
+	var timeouts atomic.Pointer[httpmiddleware.Timeouts]
+
+	initialTimeouts, err := parseTimeouts(cfg)
+	if err != nil {
+		logger.Fatalw("Invalid request timeouts", zap.Error(err))
+	}
+	timeouts.Store(&initialTimeouts)
+
+	source.Watch(logger, func(changed interface{}) {
+		reloaded := changed.(*config.Config)
+
+		if reloadedTimeouts, err := parseTimeouts(reloaded); err != nil {
+			logger.Errorw("Keeping the current request timeouts", zap.Error(err))
+		} else {
+			timeouts.Store(&reloadedTimeouts)
+		}
+
+		if err := keys.Replace(reloaded.ApiKeys); err != nil {
+			logger.Errorw("Keeping the current API keys", zap.Error(err))
+		}
+	})
 
 	r := mux.NewRouter()
+	r.Use(httpmiddleware.TracingMiddleware, httpmiddleware.MetricsMiddleware(metrics.HTTPRequests, metrics.HTTPRequestDuration), httpmiddleware.TimeoutMiddleware(&timeouts))
 	r.HandleFunc("/shorten", handlers.HandleShorten).Methods(http.MethodPost)
+	r.HandleFunc("/openapi.json", handlers.HandleOpenAPISpec).Methods(http.MethodGet)
+
+	// The link management API needs one of the API_KEYS.
+	links := r.PathPrefix("/api/links").Subrouter()
+	links.Use(middlewares.ApiKeyMiddleware(keys))
+	links.HandleFunc("", handlers.HandleListLinks).Methods(http.MethodGet)
+	links.HandleFunc("/export", handlers.HandleExportLinks).Methods(http.MethodGet)
+	links.HandleFunc("/import", handlers.HandleImportLinks).Methods(http.MethodPost)
+	links.HandleFunc("/{url}", handlers.HandleGetLink).Methods(http.MethodGet)
+	links.HandleFunc("/{url}", handlers.HandleUpdateLink).Methods(http.MethodPatch)
+	links.HandleFunc("/{url}", handlers.HandleDeleteLink).Methods(http.MethodDelete)
+	links.HandleFunc("/{url}/restore", handlers.HandleRestoreLink).Methods(http.MethodPost)
+	links.HandleFunc("/{url}/stats", handlers.HandleLinkStats).Methods(http.MethodGet)
+
+	r.HandleFunc("/dashboard/login", dashboard.HandleLoginPage).Methods(http.MethodGet)
+	r.HandleFunc("/dashboard/login", dashboard.HandleLogin).Methods(http.MethodPost)
+	r.HandleFunc("/dashboard/logout", dashboard.HandleLogout).Methods(http.MethodPost)
+	r.PathPrefix("/dashboard/static/").Handler(dashboard.StaticHandler()).Methods(http.MethodGet)
+	r.HandleFunc("/dashboard", dashboard.RequireSession(dashboard.HandleLinks)).Methods(http.MethodGet)
+	r.HandleFunc("/dashboard/links/new", dashboard.RequireSession(dashboard.HandleNewLink)).Methods(http.MethodGet)
+	r.HandleFunc("/dashboard/links", dashboard.RequireSession(dashboard.HandleCreateLink)).Methods(http.MethodPost)
+	r.HandleFunc("/dashboard/links/{url}", dashboard.RequireSession(dashboard.HandleLink)).Methods(http.MethodGet)
+	r.HandleFunc("/dashboard/links/{url}", dashboard.RequireSession(dashboard.HandleUpdateLink)).Methods(http.MethodPost)
+	r.HandleFunc("/dashboard/links/{url}/qr.png", dashboard.RequireSession(dashboard.HandleQRCode)).Methods(http.MethodGet)
 	r.HandleFunc("/{url}", handlers.HandleRedirect).Methods(http.MethodGet)
 
+	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/healthz", health.Live)
+	// Redirects fall back to the database service while the cache service is
+	// down, so only the database service gates readiness.
+	http.HandleFunc("/readyz", health.ReadyWithOptional(
+		health.Checks{"database-service": databaseService.HandleReady},
+		health.Checks{"cache-service": cacheService.HandleReady},
+	))
 	http.Handle("/", middlewares.LoggingMiddleware(r))
-	logger.Error(http.ListenAndServe(":8080", nil))
+
+	server := &http.Server{Addr: cfg.ListenAddr}
+
+	go func() {
+		if err := tlsConfig.ListenAndServe(server); !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorw("HTTP server failed", zap.Error(err))
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Infow("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorw("Could not finish in-flight requests", zap.Error(err))
+	}
+
+	// The redirects served while draining are sent before the database
+	// service connection is closed.
+	recorder.Flush()
+}
+
+// parseTimeouts reads the request timeouts of the config. Exports and imports
+// stream for as long as the client keeps up, so they are not bounded unless
+// ROUTE_TIMEOUTS says otherwise.
+func parseTimeouts(config *config.Config) (httpmiddleware.Timeouts, error) {
+	return httpmiddleware.ParseTimeouts(config.RequestTimeout, "/api/links/export=0,/api/links/import=0,"+config.RouteTimeouts)
 }
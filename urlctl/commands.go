@@ -0,0 +1,339 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"urlctl/internal/logs"
+
+	api "url-shortner-api"
+)
+
+func (a *app) create(args []string) error {
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+
+	longUrl := flags.String("url", "", "url to shorten")
+	title := flags.String("title", "", "title of the link")
+	note := flags.String("note", "", "note for the link")
+	tags := flags.String("tags", "", "comma separated tags")
+	owner := flags.String("owner", "", "owner of the link")
+	expires := flags.String("expires", "", "expiry as RFC3339 timestamp")
+
+	flags.Parse(args)
+
+	if *longUrl == "" && flags.NArg() > 0 {
+		*longUrl = flags.Arg(0)
+	}
+
+	if *longUrl == "" {
+		return errors.New("create: -url is required")
+	}
+
+	request := api.ShortenRequestModel{
+		Url:   *longUrl,
+		Title: *title,
+		Note:  *note,
+		Tags:  splitList(*tags),
+		Owner: *owner,
+	}
+
+	if *expires != "" {
+		expiresAt, err := time.Parse(time.RFC3339, *expires)
+
+		if err != nil {
+			return fmt.Errorf("create: invalid -expires: %w", err)
+		}
+
+		request.ExpiresAt = expiresAt
+	}
+
+	response, err := a.client.CreateLink(request)
+
+	if err != nil {
+		return err
+	}
+
+	return a.printer.ShortUrl(response)
+}
+
+func (a *app) get(args []string) error {
+	flags := flag.NewFlagSet("get", flag.ExitOnError)
+
+	shortUrlPath, err := parseWithPath(flags, args)
+
+	if err != nil {
+		return err
+	}
+
+	link, err := a.client.GetLink(shortUrlPath)
+
+	if err != nil {
+		return err
+	}
+
+	return a.printer.Link(link)
+}
+
+func (a *app) list(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+
+	tag := flags.String("tag", "", "only links with this tag")
+	search := flags.String("q", "", "full text search on title, note and url")
+	createdAfter := flags.String("created-after", "", "only links created after this RFC3339 timestamp")
+	sort := flags.String("sort", "", "created_at, -created_at, expires_at or -expires_at")
+	limit := flags.Int("limit", 0, "links per page")
+	cursor := flags.String("cursor", "", "cursor of the page to fetch")
+	all := flags.Bool("all", false, "follow cursors and fetch every page")
+
+	flags.Parse(args)
+
+	params := api.ListLinksParams{
+		Tag:    nonEmpty(*tag),
+		Q:      nonEmpty(*search),
+		Sort:   nonEmpty(*sort),
+		Cursor: nonEmpty(*cursor),
+	}
+
+	if *createdAfter != "" {
+		after, err := time.Parse(time.RFC3339, *createdAfter)
+
+		if err != nil {
+			return fmt.Errorf("list: invalid -created-after: %w", err)
+		}
+
+		params.CreatedAfter = &after
+	}
+
+	if *limit > 0 {
+		params.Limit = limit
+	}
+
+	response, err := a.client.ListLinks(params)
+
+	if err != nil {
+		return err
+	}
+
+	for *all && response.NextCursor != "" {
+		next := response.NextCursor
+		params.Cursor = &next
+
+		page, err := a.client.ListLinks(params)
+
+		if err != nil {
+			return err
+		}
+
+		response.Links = append(response.Links, page.Links...)
+		response.NextCursor = page.NextCursor
+	}
+
+	return a.printer.Links(response)
+}
+
+func (a *app) update(args []string) error {
+	flags := flag.NewFlagSet("update", flag.ExitOnError)
+
+	longUrl := flags.String("url", "", "new destination url")
+	title := flags.String("title", "", "new title")
+	note := flags.String("note", "", "new note")
+	tags := flags.String("tags", "", "new comma separated tags, replaces the current tags")
+	expires := flags.String("expires", "", "new expiry as RFC3339 timestamp")
+
+	shortUrlPath, err := parseWithPath(flags, args)
+
+	if err != nil {
+		return err
+	}
+
+	request := api.UpdateRequestModel{}
+
+	// Only flags given on the command line are sent, so "-title ''" clears the
+	// title while leaving it out keeps it.
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "url":
+			request.Url = longUrl
+		case "title":
+			request.Title = title
+		case "note":
+			request.Note = note
+		case "tags":
+			list := splitList(*tags)
+			if list == nil {
+				list = []string{}
+			}
+			request.Tags = &list
+		}
+	})
+
+	if *expires != "" {
+		expiresAt, err := time.Parse(time.RFC3339, *expires)
+
+		if err != nil {
+			return fmt.Errorf("update: invalid -expires: %w", err)
+		}
+
+		request.ExpiresAt = &expiresAt
+	}
+
+	link, err := a.client.UpdateLink(shortUrlPath, request)
+
+	if err != nil {
+		return err
+	}
+
+	return a.printer.Link(link)
+}
+
+func (a *app) delete(args []string) error {
+	flags := flag.NewFlagSet("delete", flag.ExitOnError)
+
+	shortUrlPath, err := parseWithPath(flags, args)
+
+	if err != nil {
+		return err
+	}
+
+	if err := a.client.DeleteLink(shortUrlPath); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Deleted %s\n", shortUrlPath)
+
+	return nil
+}
+
+func (a *app) restore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+
+	shortUrlPath, err := parseWithPath(flags, args)
+
+	if err != nil {
+		return err
+	}
+
+	link, err := a.client.RestoreLink(shortUrlPath)
+
+	if err != nil {
+		return err
+	}
+
+	return a.printer.Link(link)
+}
+
+func (a *app) stats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+
+	days := flags.Int("days", 30, "number of days of daily clicks")
+
+	shortUrlPath, err := parseWithPath(flags, args)
+
+	if err != nil {
+		return err
+	}
+
+	stats, err := a.client.LinkStats(shortUrlPath, *days)
+
+	if err != nil {
+		return err
+	}
+
+	return a.printer.Stats(stats)
+}
+
+func (a *app) cache(args []string) error {
+	if len(args) == 0 {
+		return errors.New("cache: expected evict or flush")
+	}
+
+	switch args[0] {
+	case "evict":
+		if len(args) < 2 {
+			return errors.New("cache evict: expected at least one short url path")
+		}
+
+		for _, shortUrlPath := range args[1:] {
+			if err := a.client.EvictCache(shortUrlPath); err != nil {
+				return fmt.Errorf("evicting %s: %w", shortUrlPath, err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Evicted %s\n", shortUrlPath)
+		}
+
+		return nil
+	case "flush":
+		if err := a.client.FlushCache(); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "Flushed cache")
+
+		return nil
+	default:
+		return fmt.Errorf("cache: unknown subcommand %q", args[0])
+	}
+}
+
+func (a *app) logs(args []string) error {
+	if len(args) == 0 || args[0] != "tail" {
+		return errors.New("logs: expected tail")
+	}
+
+	flags := flag.NewFlagSet("logs tail", flag.ExitOnError)
+
+	topics := flags.String("topics", strings.Join(logs.Topics, ","), "comma separated topics to follow")
+	fromBeginning := flags.Bool("from-beginning", false, "print retained entries before following new ones")
+
+	flags.Parse(args[1:])
+
+	brokers := splitList(a.config.Get("KAFKA_SERVICE_BASE_URL"))
+
+	if len(brokers) == 0 {
+		return errors.New("logs tail: KAFKA_SERVICE_BASE_URL is not configured")
+	}
+
+	return logs.Tail(brokers, splitList(*topics), *fromBeginning, a.printer.LogEntry)
+}
+
+// parseWithPath parses flags and returns the short url path argument. The path
+// may come before or after the flags.
+func parseWithPath(flags *flag.FlagSet, args []string) (string, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		flags.Parse(args[1:])
+		return args[0], nil
+	}
+
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		return "", fmt.Errorf("%s: expected a short url path", flags.Name())
+	}
+
+	return flags.Arg(0), nil
+}
+
+func splitList(value string) []string {
+	var list []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// nonEmpty returns a pointer to value, or nil to leave an empty value out of
+// a query.
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
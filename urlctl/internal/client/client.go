@@ -0,0 +1,255 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"urlctl/internal/config"
+
+	api "url-shortner-api"
+	"url-shortner-api/tlsconfig"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// APIError is returned when a service answers with a non 2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s: %s", http.StatusText(e.StatusCode), e.Message)
+}
+
+// maxErrorMessage is the most of an error response kept in an APIError.
+const maxErrorMessage = 1024
+
+type ClientInterface interface {
+	CreateLink(request api.ShortenRequestModel) (*api.ShortenResponseModel, error)
+	GetLink(shortUrlPath string) (*api.LinkModel, error)
+	ListLinks(params api.ListLinksParams) (*api.ListResponseModel, error)
+	UpdateLink(shortUrlPath string, request api.UpdateRequestModel) (*api.LinkModel, error)
+	DeleteLink(shortUrlPath string) error
+	RestoreLink(shortUrlPath string) (*api.LinkModel, error)
+	LinkStats(shortUrlPath string, days int) (*api.StatsResponseModel, error)
+	EvictCache(shortUrlPath string) error
+	FlushCache() error
+}
+
+type client struct {
+	mainService  api.ClientWithResponsesInterface
+	cacheService api.ClientWithResponsesInterface
+}
+
+// NewClient presents the certificate of TLS_CERT_FILE and TLS_KEY_FILE, and
+// verifies the services against TLS_CA_FILE, when they are set, so it can
+// call a cache service that requires mutual TLS.
+func NewClient(config config.ConfigInterface) (*client, error) {
+	files := tlsconfig.Files{
+		CertFile: config.Get("TLS_CERT_FILE"),
+		KeyFile:  config.Get("TLS_KEY_FILE"),
+		CAFile:   config.Get("TLS_CA_FILE"),
+	}
+
+	if err := files.Validate(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := tlsconfig.Load(files, false, zap.NewNop().Sugar())
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig.Client
+	httpClient := &http.Client{Transport: transport, Timeout: 30 * time.Second}
+
+	mainService, err := api.NewClientWithResponses(config.Get("MAIN_SERVICE_BASE_URL"),
+		api.WithHTTPClient(httpClient), api.WithRequestEditorFn(withNewRequestId), api.WithRequestEditorFn(withApiKey(config.Get("API_KEY"))))
+
+	if err != nil {
+		return nil, err
+	}
+
+	cacheService, err := api.NewClientWithResponses(config.Get("CACHE_SERVICE_BASE_URL"),
+		api.WithHTTPClient(httpClient), api.WithRequestEditorFn(withNewRequestId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{
+		mainService:  mainService,
+		cacheService: cacheService,
+	}, nil
+}
+
+func (c *client) CreateLink(request api.ShortenRequestModel) (*api.ShortenResponseModel, error) {
+	resp, err := c.mainService.ShortenWithResponse(context.Background(), request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(resp.StatusCode(), resp.Body); err != nil {
+		return nil, err
+	}
+
+	return decoded(resp.JSON200)
+}
+
+func (c *client) GetLink(shortUrlPath string) (*api.LinkModel, error) {
+	resp, err := c.mainService.GetLinkWithResponse(context.Background(), shortUrlPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(resp.StatusCode(), resp.Body); err != nil {
+		return nil, err
+	}
+
+	return decoded(resp.JSON200)
+}
+
+func (c *client) ListLinks(params api.ListLinksParams) (*api.ListResponseModel, error) {
+	resp, err := c.mainService.ListLinksWithResponse(context.Background(), &params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(resp.StatusCode(), resp.Body); err != nil {
+		return nil, err
+	}
+
+	return decoded(resp.JSON200)
+}
+
+func (c *client) UpdateLink(shortUrlPath string, request api.UpdateRequestModel) (*api.LinkModel, error) {
+	resp, err := c.mainService.UpdateLinkWithResponse(context.Background(), shortUrlPath, request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(resp.StatusCode(), resp.Body); err != nil {
+		return nil, err
+	}
+
+	return decoded(resp.JSON200)
+}
+
+func (c *client) DeleteLink(shortUrlPath string) error {
+	resp, err := c.mainService.DeleteLinkWithResponse(context.Background(), shortUrlPath)
+
+	if err != nil {
+		return err
+	}
+
+	return checkStatus(resp.StatusCode(), resp.Body)
+}
+
+func (c *client) RestoreLink(shortUrlPath string) (*api.LinkModel, error) {
+	resp, err := c.mainService.RestoreLinkWithResponse(context.Background(), shortUrlPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(resp.StatusCode(), resp.Body); err != nil {
+		return nil, err
+	}
+
+	return decoded(resp.JSON200)
+}
+
+func (c *client) LinkStats(shortUrlPath string, days int) (*api.StatsResponseModel, error) {
+	params := &api.GetLinkStatsParams{}
+
+	if days > 0 {
+		params.Days = &days
+	}
+
+	resp, err := c.mainService.GetLinkStatsWithResponse(context.Background(), shortUrlPath, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkStatus(resp.StatusCode(), resp.Body); err != nil {
+		return nil, err
+	}
+
+	return decoded(resp.JSON200)
+}
+
+func (c *client) EvictCache(shortUrlPath string) error {
+	resp, err := c.cacheService.EvictCacheWithResponse(context.Background(), shortUrlPath)
+
+	if err != nil {
+		return err
+	}
+
+	return checkStatus(resp.StatusCode(), resp.Body)
+}
+
+func (c *client) FlushCache() error {
+	resp, err := c.cacheService.FlushCacheWithResponse(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	return checkStatus(resp.StatusCode(), resp.Body)
+}
+
+// withNewRequestId gives every request its own X-request-id, so it can be
+// found in the service logs.
+func withNewRequestId(ctx context.Context, req *http.Request) error {
+	return api.WithRequestId(uuid.New().String())(ctx, req)
+}
+
+// withApiKey sends the API key of the main service, if there is one.
+func withApiKey(apiKey string) api.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+
+		return nil
+	}
+}
+
+// checkStatus turns a non 2xx response into an APIError carrying the start of
+// its body.
+func checkStatus(statusCode int, body []byte) error {
+	if statusCode >= 200 && statusCode < 300 {
+		return nil
+	}
+
+	if len(body) > maxErrorMessage {
+		body = body[:maxErrorMessage]
+	}
+
+	return &APIError{StatusCode: statusCode, Message: strings.TrimSpace(string(body))}
+}
+
+// decoded returns the decoded body of a successful response, which is nil
+// when the service did not answer with JSON.
+func decoded[T any](body *T) (*T, error) {
+	if body == nil {
+		return nil, errors.New("unexpected response, expected JSON")
+	}
+
+	return body, nil
+}
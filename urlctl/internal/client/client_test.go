@@ -0,0 +1,211 @@
+package client_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"urlctl/internal/client"
+
+	api "url-shortner-api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticConfig map[string]string
+
+func (c staticConfig) Get(key string) string {
+	return c[key]
+}
+
+type recordedRequest struct {
+	Method    string
+	Path      string
+	Query     string
+	Body      string
+	RequestId string
+}
+
+func newServer(t *testing.T, statusCode int, response string) (*httptest.Server, *[]recordedRequest) {
+	requests := &[]recordedRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, recordedRequest{
+			Method:    r.Method,
+			Path:      r.URL.EscapedPath(),
+			Query:     r.URL.RawQuery,
+			Body:      string(body),
+			RequestId: r.Header.Get("X-request-id"),
+		})
+
+		if statusCode >= 300 {
+			http.Error(w, response, statusCode)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		io.WriteString(w, response)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func TestClientLinks(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK, `{"shorturlpath":"abc","url":"https://example.com","tags":["go"]}`)
+
+	c, err := client.NewClient(staticConfig{"MAIN_SERVICE_BASE_URL": server.URL + "/"})
+	assert.Nil(t, err)
+
+	link, err := c.GetLink("a b")
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", link.ShortUrlPath)
+	assert.Equal(t, []string{"go"}, link.Tags)
+
+	title := "New title"
+	_, err = c.UpdateLink("abc", api.UpdateRequestModel{Title: &title})
+	assert.Nil(t, err)
+
+	_, err = c.LinkStats("abc", 7)
+	assert.Nil(t, err)
+
+	tag := "go"
+	_, err = c.ListLinks(api.ListLinksParams{Tag: &tag})
+	assert.Nil(t, err)
+
+	_, err = c.RestoreLink("abc")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 5, len(*requests))
+	assert.Equal(t, recordedRequest{Method: http.MethodGet, Path: "/api/links/a%20b"}, withoutRequestId((*requests)[0]))
+	assert.Equal(t, recordedRequest{Method: http.MethodPatch, Path: "/api/links/abc", Body: `{"title":"New title"}`}, withoutRequestId((*requests)[1]))
+	assert.Equal(t, recordedRequest{Method: http.MethodGet, Path: "/api/links/abc/stats", Query: "days=7"}, withoutRequestId((*requests)[2]))
+	assert.Equal(t, recordedRequest{Method: http.MethodGet, Path: "/api/links", Query: "tag=go"}, withoutRequestId((*requests)[3]))
+	assert.Equal(t, recordedRequest{Method: http.MethodPost, Path: "/api/links/abc/restore"}, withoutRequestId((*requests)[4]))
+	assert.NotEqual(t, (*requests)[0].RequestId, (*requests)[1].RequestId)
+}
+
+func TestClientApiKey(t *testing.T) {
+	var authorization []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, "{}")
+	}))
+	t.Cleanup(server.Close)
+
+	cache := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(cache.Close)
+
+	c, err := client.NewClient(staticConfig{"MAIN_SERVICE_BASE_URL": server.URL, "CACHE_SERVICE_BASE_URL": cache.URL, "API_KEY": "secret"})
+	assert.Nil(t, err)
+
+	_, err = c.GetLink("abc")
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.EvictCache("abc"))
+
+	assert.Equal(t, []string{"Bearer secret", ""}, authorization, "the key is only sent to the main service")
+}
+
+func TestClientCreateLink(t *testing.T) {
+	server, requests := newServer(t, http.StatusOK, `{"url":"http://localhost:8080/abc"}`)
+
+	c, err := client.NewClient(staticConfig{"MAIN_SERVICE_BASE_URL": server.URL})
+	assert.Nil(t, err)
+
+	response, err := c.CreateLink(api.ShortenRequestModel{Url: "https://example.com", Tags: []string{"go"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "http://localhost:8080/abc", response.Url)
+
+	request := api.ShortenRequestModel{}
+	assert.Nil(t, json.Unmarshal([]byte((*requests)[0].Body), &request))
+	assert.Equal(t, api.ShortenRequestModel{Url: "https://example.com", Tags: []string{"go"}}, request)
+	assert.Equal(t, recordedRequest{Method: http.MethodPost, Path: "/shorten", Body: (*requests)[0].Body}, withoutRequestId((*requests)[0]))
+}
+
+func TestClientCache(t *testing.T) {
+	server, requests := newServer(t, http.StatusNoContent, "")
+
+	c, err := client.NewClient(staticConfig{"CACHE_SERVICE_BASE_URL": server.URL})
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.EvictCache("abc"))
+	assert.Nil(t, c.FlushCache())
+
+	assert.Equal(t, recordedRequest{Method: http.MethodDelete, Path: "/cache/abc"}, withoutRequestId((*requests)[0]))
+	assert.Equal(t, recordedRequest{Method: http.MethodPost, Path: "/cache/flush"}, withoutRequestId((*requests)[1]))
+}
+
+func TestClientError(t *testing.T) {
+	server, _ := newServer(t, http.StatusNotFound, "URL not found")
+
+	c, err := client.NewClient(staticConfig{"MAIN_SERVICE_BASE_URL": server.URL})
+	assert.Nil(t, err)
+
+	_, err = c.GetLink("abc")
+
+	apiErr, ok := err.(*client.APIError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "Not Found: URL not found", apiErr.Error())
+}
+
+func TestClientNotJson(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "<html></html>")
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(staticConfig{"MAIN_SERVICE_BASE_URL": server.URL})
+	assert.Nil(t, err)
+
+	link, err := c.GetLink("abc")
+	assert.Nil(t, link)
+	assert.Error(t, err)
+}
+
+func withoutRequestId(request recordedRequest) recordedRequest {
+	request.RequestId = ""
+	return request
+}
+
+func TestNewClientTls(t *testing.T) {
+	tests := map[string]struct {
+		config        staticConfig
+		ExpectedError bool
+	}{
+		"Without Tls": {
+			config: staticConfig{},
+		},
+		"Certificate Without Key": {
+			config:        staticConfig{"TLS_CERT_FILE": "tls.crt"},
+			ExpectedError: true,
+		},
+		"Missing Files": {
+			config:        staticConfig{"TLS_CERT_FILE": "missing.crt", "TLS_KEY_FILE": "missing.key"},
+			ExpectedError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.NewClient(test.config)
+
+			if test.ExpectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+		})
+	}
+}
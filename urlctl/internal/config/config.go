@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+type ConfigInterface interface {
+	Get(key string) string
+}
+
+type config struct {
+	viper *viper.Viper
+}
+
+var defaults = map[string]string{
+	"MAIN_SERVICE_BASE_URL":  "http://localhost:8080",
+	"API_KEY":                "",
+	"CACHE_SERVICE_BASE_URL": "http://localhost:8082",
+	"TLS_CERT_FILE":          "",
+	"TLS_KEY_FILE":           "",
+	"TLS_CA_FILE":            "",
+	"KAFKA_SERVICE_BASE_URL": "localhost:29092",
+	"OUTPUT":                 "table",
+}
+
+// NewConfig reads the given config file, or urlctl.env from the current
+// directory or ~/.config/urlctl when path is empty. A missing default file is
+// not an error; every key has a default and can be overridden with an
+// URLCTL_ prefixed environment variable.
+func NewConfig(path string) (*config, error) {
+	v := viper.New()
+
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+
+	v.SetEnvPrefix("URLCTL")
+	v.AutomaticEnv()
+	v.SetConfigType("env")
+
+	if path != "" {
+		v.SetConfigFile(path)
+	} else {
+		v.SetConfigName("urlctl")
+		v.AddConfigPath(".")
+
+		if home, err := os.UserHomeDir(); err == nil {
+			v.AddConfigPath(filepath.Join(home, ".config", "urlctl"))
+		}
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if path != "" || !errors.As(err, &notFound) {
+			return nil, err
+		}
+	}
+
+	return &config{viper: v}, nil
+}
+
+func (c *config) Get(key string) string {
+	return c.viper.GetString(key)
+}
@@ -0,0 +1,137 @@
+package logs
+
+import (
+	"encoding/json"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/IBM/sarama"
+)
+
+// Entry is a log line produced by one of the services and published on its
+// Kafka topic.
+type Entry struct {
+	Topic     string `json:"topic"`
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	RequestId string `json:"Request Id"`
+	Message   string `json:"msg"`
+	Raw       []byte `json:"-"`
+}
+
+// Topics are the log topics the services publish to and kafka-server consumes.
+var Topics = []string{"main-server", "cache-server", "database-server"}
+
+// Tail follows every partition of the given topics and calls handle for each
+// log entry until interrupted or every partition consumer stops. Entries that
+// are not JSON are passed on with only their raw value set. Tailing does not
+// join a consumer group, so it never moves the offsets of kafka-server.
+func Tail(brokers []string, topics []string, fromBeginning bool, handle func(*Entry) error) error {
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = false
+
+	consumer, err := sarama.NewConsumer(brokers, config)
+
+	if err != nil {
+		return err
+	}
+
+	defer consumer.Close()
+
+	offset := sarama.OffsetNewest
+	if fromBeginning {
+		offset = sarama.OffsetOldest
+	}
+
+	messages := make(chan *sarama.ConsumerMessage)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for _, topic := range topics {
+		partitions, err := consumer.Partitions(topic)
+
+		if err != nil {
+			close(done)
+			wg.Wait()
+			return err
+		}
+
+		for _, partition := range partitions {
+			partitionConsumer, err := consumer.ConsumePartition(topic, partition, offset)
+
+			if err != nil {
+				close(done)
+				wg.Wait()
+				return err
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer partitionConsumer.Close()
+
+				for {
+					select {
+					case message, ok := <-partitionConsumer.Messages():
+						if !ok {
+							return
+						}
+
+						select {
+						case messages <- message:
+						case <-done:
+							return
+						}
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+	}
+
+	// messages is closed once every partition consumer has stopped, as no
+	// more entries can come.
+	go func() {
+		wg.Wait()
+		close(messages)
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	for {
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			if err := handle(ParseEntry(message.Topic, message.Value)); err != nil {
+				return err
+			}
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+func ParseEntry(topic string, value []byte) *Entry {
+	entry := &Entry{}
+
+	if err := json.Unmarshal(value, entry); err != nil {
+		entry = &Entry{Message: string(value)}
+	}
+
+	entry.Topic = topic
+	entry.Raw = value
+
+	return entry
+}
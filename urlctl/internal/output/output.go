@@ -0,0 +1,158 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"urlctl/internal/logs"
+
+	api "url-shortner-api"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+
+	dateLayout = "2006-01-02"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported output format, expected table or json")
+
+type Printer struct {
+	format string
+	w      io.Writer
+}
+
+func NewPrinter(format string, w io.Writer) (*Printer, error) {
+	if format != FormatTable && format != FormatJSON {
+		return nil, ErrUnsupportedFormat
+	}
+
+	return &Printer{format: format, w: w}, nil
+}
+
+func (p *Printer) ShortUrl(response *api.ShortenResponseModel) error {
+	if p.format == FormatJSON {
+		return p.json(response)
+	}
+
+	_, err := fmt.Fprintln(p.w, response.Url)
+	return err
+}
+
+func (p *Printer) Link(link *api.LinkModel) error {
+	if p.format == FormatJSON {
+		return p.json(link)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Short URL:\t%s\n", link.ShortUrl)
+	fmt.Fprintf(tw, "Path:\t%s\n", link.ShortUrlPath)
+	fmt.Fprintf(tw, "URL:\t%s\n", link.Url)
+	fmt.Fprintf(tw, "Title:\t%s\n", link.Title)
+	fmt.Fprintf(tw, "Note:\t%s\n", link.Note)
+	fmt.Fprintf(tw, "Tags:\t%s\n", strings.Join(link.Tags, ", "))
+	fmt.Fprintf(tw, "Owner:\t%s\n", link.Owner)
+	fmt.Fprintf(tw, "Created:\t%s\n", formatTime(link.CreatedAt))
+	fmt.Fprintf(tw, "Expires:\t%s\n", formatTime(link.ExpiresAt))
+
+	return tw.Flush()
+}
+
+func (p *Printer) Links(response *api.ListResponseModel) error {
+	if p.format == FormatJSON {
+		return p.json(response)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "PATH\tURL\tTITLE\tTAGS\tCREATED\tEXPIRES")
+
+	for _, link := range response.Links {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			link.ShortUrlPath,
+			truncate(link.Url, 60),
+			truncate(link.Title, 30),
+			strings.Join(link.Tags, ","),
+			formatTime(link.CreatedAt),
+			formatTime(link.ExpiresAt),
+		)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if response.NextCursor != "" {
+		_, err := fmt.Fprintf(p.w, "\nMore links available, continue with -cursor %s\n", response.NextCursor)
+		return err
+	}
+
+	return nil
+}
+
+func (p *Printer) Stats(stats *api.StatsResponseModel) error {
+	if p.format == FormatJSON {
+		return p.json(stats)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Path:\t%s\n", stats.ShortUrlPath)
+	fmt.Fprintf(tw, "Total clicks:\t%d\n", stats.TotalClicks)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "DAY\tCLICKS")
+
+	for _, day := range stats.Daily {
+		fmt.Fprintf(tw, "%s\t%d\n", day.Day.Format(dateLayout), day.Count)
+	}
+
+	return tw.Flush()
+}
+
+// LogEntry prints a single log line. JSON output is the raw entry as it was
+// published, one per line.
+func (p *Printer) LogEntry(entry *logs.Entry) error {
+	if p.format == FormatJSON {
+		_, err := fmt.Fprintf(p.w, "%s\n", entry.Raw)
+		return err
+	}
+
+	requestId := entry.RequestId
+	if requestId == "" {
+		requestId = "-"
+	}
+
+	_, err := fmt.Fprintf(p.w, "%s  %-15s  %-5s  %s  %s\n", entry.Timestamp, entry.Topic, strings.ToUpper(entry.Level), requestId, entry.Message)
+	return err
+}
+
+func (p *Printer) json(value interface{}) error {
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return "-"
+	}
+
+	return value.Format(time.RFC3339)
+}
+
+func truncate(value string, length int) string {
+	runes := []rune(value)
+
+	if len(runes) <= length {
+		return value
+	}
+
+	return string(runes[:length-3]) + "..."
+}
@@ -0,0 +1,124 @@
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"urlctl/internal/logs"
+	"urlctl/internal/output"
+
+	api "url-shortner-api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPrinter(t *testing.T) {
+	_, err := output.NewPrinter("yaml", &bytes.Buffer{})
+	assert.Equal(t, output.ErrUnsupportedFormat, err)
+}
+
+func TestPrinterLinks(t *testing.T) {
+	response := &api.ListResponseModel{
+		Links: []api.LinkModel{
+			{
+				ShortUrlPath: "abc",
+				Url:          "https://example.com/" + strings.Repeat("a", 80),
+				Title:        "Example",
+				Tags:         []string{"go", "docs"},
+				CreatedAt:    time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		NextCursor: "next",
+	}
+
+	tests := map[string]struct {
+		format   string
+		expected []string
+	}{
+		"Table": {
+			format: output.FormatTable,
+			expected: []string{
+				"PATH  URL",
+				"abc   https://example.com/aaa",
+				"...  Example  go,docs  2024-05-01T10:00:00Z  -",
+				"continue with -cursor next",
+			},
+		},
+		"JSON": {
+			format: output.FormatJSON,
+			expected: []string{
+				`"shorturlpath": "abc"`,
+				`"next_cursor": "next"`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+
+			printer, err := output.NewPrinter(test.format, buffer)
+			assert.Nil(t, err)
+			assert.Nil(t, printer.Links(response))
+
+			for _, expected := range test.expected {
+				assert.Contains(t, buffer.String(), expected)
+			}
+		})
+	}
+}
+
+func TestPrinterStats(t *testing.T) {
+	buffer := &bytes.Buffer{}
+
+	printer, _ := output.NewPrinter(output.FormatTable, buffer)
+
+	assert.Nil(t, printer.Stats(&api.StatsResponseModel{
+		ShortUrlPath: "abc",
+		TotalClicks:  12,
+		Daily: []api.DailyClicksModel{
+			{Day: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Count: 2},
+			{Day: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), Count: 10},
+		},
+	}))
+
+	assert.Contains(t, buffer.String(), "Total clicks:  12")
+	assert.Contains(t, buffer.String(), "2024-05-02  10")
+}
+
+func TestPrinterLogEntry(t *testing.T) {
+	raw := []byte(`{"level":"info","timestamp":"2024-05-01T10:00:00.000Z","msg":"Handling redirect request","Request Id":"42"}`)
+
+	tests := map[string]struct {
+		format   string
+		value    []byte
+		expected string
+	}{
+		"Table": {
+			format:   output.FormatTable,
+			value:    raw,
+			expected: "2024-05-01T10:00:00.000Z  main-server      INFO   42  Handling redirect request\n",
+		},
+		"Table Not JSON": {
+			format:   output.FormatTable,
+			value:    []byte("plain text"),
+			expected: "  main-server             -  plain text\n",
+		},
+		"JSON": {
+			format:   output.FormatJSON,
+			value:    raw,
+			expected: string(raw) + "\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+
+			printer, _ := output.NewPrinter(test.format, buffer)
+			assert.Nil(t, printer.LogEntry(logs.ParseEntry("main-server", test.value)))
+			assert.Equal(t, test.expected, buffer.String())
+		})
+	}
+}
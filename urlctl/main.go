@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"urlctl/internal/client"
+	"urlctl/internal/config"
+	"urlctl/internal/output"
+)
+
+const usage = `Usage: urlctl [-config file] [-o table|json] <command> [flags] [args]
+
+Commands:
+  create -url URL [-title] [-note] [-tags a,b] [-owner] [-expires RFC3339]
+  get <shorturlpath>
+  list [-tag] [-q] [-created-after RFC3339] [-sort] [-limit] [-cursor] [-all]
+  update <shorturlpath> [-url] [-title] [-note] [-tags a,b] [-expires RFC3339]
+  delete <shorturlpath>
+  restore <shorturlpath>
+  stats <shorturlpath> [-days N]
+  cache evict <shorturlpath>...
+  cache flush
+  logs tail [-topics main-server,cache-server,database-server] [-from-beginning]
+`
+
+type app struct {
+	config  config.ConfigInterface
+	client  client.ClientInterface
+	printer *output.Printer
+}
+
+func main() {
+	flags := flag.NewFlagSet("urlctl", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+
+	configPath := flags.String("config", "", "path to the config file, defaults to ./urlctl.env or ~/.config/urlctl/urlctl.env")
+	format := flags.String("o", "", "output format, table or json")
+
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	config, err := config.NewConfig(*configPath)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *format == "" {
+		*format = config.Get("OUTPUT")
+	}
+
+	printer, err := output.NewPrinter(*format, os.Stdout)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	client, err := client.NewClient(config)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not create client: %v\n", err)
+		os.Exit(1)
+	}
+
+	app := &app{
+		config:  config,
+		client:  client,
+		printer: printer,
+	}
+
+	if err := app.run(flags.Arg(0), flags.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "urlctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (a *app) run(command string, args []string) error {
+	switch command {
+	case "create":
+		return a.create(args)
+	case "get":
+		return a.get(args)
+	case "list":
+		return a.list(args)
+	case "update":
+		return a.update(args)
+	case "delete":
+		return a.delete(args)
+	case "restore":
+		return a.restore(args)
+	case "stats":
+		return a.stats(args)
+	case "cache":
+		return a.cache(args)
+	case "logs":
+		return a.logs(args)
+	case "help":
+		fmt.Print(usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q, run urlctl help", command)
+	}
+}